@@ -26,7 +26,7 @@ import { FileContentSearchResult } from '../components/FileContentSearchResult'
 import { FilePathSearchResult } from '../components/FilePathSearchResult'
 import { RepoSearchResult } from '../components/RepoSearchResult'
 import { SymbolSearchResult } from '../components/SymbolSearchResult'
-import { smartSearchClickedEvent } from '../util/events'
+import { smartSearchClickedEvent, smartSearchRules } from '../util/events'
 
 import { NoResultsPage } from './NoResultsPage'
 import { StreamingSearchResultFooter } from './StreamingSearchResultsFooter'
@@ -128,6 +128,10 @@ export const StreamingSearchResultsList: React.FunctionComponent<
                 )
 
                 telemetryService.log(event)
+                telemetryService.log('SmartSearchResultClicked', {
+                    alertKind: results.alert.kind,
+                    rules: smartSearchRules(results.alert.proposedQueries, index, results.results.length),
+                })
             }
         },
         [telemetryService, results]
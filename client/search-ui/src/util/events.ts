@@ -1,4 +1,13 @@
-import { AlertKind } from '@sourcegraph/shared/src/search/stream'
+import { AlertKind, AnnotationName } from '@sourcegraph/shared/src/search/stream'
+
+/** The event suffixes of rules, by a pattern matching their description. */
+const ruleDescriptionPatterns: [RegExp, string][] = [
+    [/patterns as regular expressions/, 'Regexp'],
+    [/unquote patterns/, 'Unquote'],
+    [/AND patterns together/, 'And'],
+    [/language filter for pattern/, 'Lang'],
+    [/search type for pattern/, 'Type'],
+]
 
 /**
  * For logging lucky search events. Calculates events based on
@@ -6,24 +15,9 @@ import { AlertKind } from '@sourcegraph/shared/src/search/stream'
  * To be removed at latest by 12/2022.
  */
 export const smartSearchClickedEvent = (alertKind: AlertKind, alertTitle: string, descriptions: string[]): string => {
-    const rules = descriptions.map(entry => {
-        if (entry.match(/patterns as regular expressions/)) {
-            return 'Regexp'
-        }
-        if (entry.match(/unquote patterns/)) {
-            return 'Unquote'
-        }
-        if (entry.match(/AND patterns together/)) {
-            return 'And'
-        }
-        if (entry.match(/language filter for pattern/)) {
-            return 'Lang'
-        }
-        if (entry.match(/search type for pattern/)) {
-            return 'Type'
-        }
-        return 'Other'
-    })
+    const rules = descriptions.map(
+        entry => ruleDescriptionPatterns.find(([pattern]) => pattern.test(entry))?.[1] ?? 'Other'
+    )
 
     const prefix =
         alertKind === 'smart-search-pure-results' ? 'SearchResultClickedAutoPure' : 'SearchResultClickedAdded'
@@ -34,3 +28,48 @@ export const smartSearchClickedEvent = (alertKind: AlertKind, alertTitle: string
 
     return `${prefix}${rule}`
 }
+
+interface AnnotatedQuery {
+    annotations?: { name: AnnotationName; value: string }[]
+}
+
+const annotation = (proposedQuery: AnnotatedQuery, name: AnnotationName): string | undefined =>
+    proposedQuery.annotations?.find(entry => entry.name === name)?.value
+
+/**
+ * Returns the names of the Smart Search rules that generated the result at
+ * index in the results of a search, as reported by the SmartSearchRules
+ * annotation of the proposed query that generated it. Generated queries stream
+ * their results one after the other, after the results of the original query,
+ * and the MatchCount annotation tells how many results each of them streamed.
+ * The rules are sent with the SmartSearchResultClicked event, which is counted
+ * per rule on the backend.
+ */
+export const smartSearchRules = (
+    proposedQueries: AnnotatedQuery[] | null,
+    index: number,
+    resultCount: number
+): string[] => {
+    const queries = (proposedQueries || []).map(proposedQuery => ({
+        rules: annotation(proposedQuery, 'SmartSearchRules')?.split(',').filter(Boolean) || [],
+        matchCount: parseInt(annotation(proposedQuery, 'MatchCount') || '', 10),
+    }))
+    if (queries.some(({ matchCount }) => isNaN(matchCount))) {
+        return []
+    }
+
+    // Results past the display limit are not streamed, in which case there are
+    // fewer results than matches.
+    let start = Math.max(0, resultCount - queries.reduce((total, { matchCount }) => total + matchCount, 0))
+    if (index < start) {
+        // The result was generated by the original query.
+        return []
+    }
+    for (const { rules, matchCount } of queries) {
+        if (index < start + matchCount) {
+            return rules
+        }
+        start += matchCount
+    }
+    return []
+}
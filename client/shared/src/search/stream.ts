@@ -250,7 +250,7 @@ interface Alert {
 }
 
 // Same key values from internal/search/alert.go
-export type AnnotationName = 'ResultCount'
+export type AnnotationName = 'ResultCount' | 'SmartSearchRules' | 'MatchCount'
 
 interface ProposedQuery {
     description?: string | null
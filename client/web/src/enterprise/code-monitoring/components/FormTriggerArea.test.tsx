@@ -28,7 +28,6 @@ describe('FormTriggerArea', () => {
             query: '',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
             repoChecked: false,
             validChecked: false,
         },
@@ -36,7 +35,6 @@ describe('FormTriggerArea', () => {
             query: 'test',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
             repoChecked: false,
             validChecked: true,
         },
@@ -44,7 +42,6 @@ describe('FormTriggerArea', () => {
             query: 'test patternType:literal',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
             repoChecked: false,
             validChecked: true,
         },
@@ -52,7 +49,6 @@ describe('FormTriggerArea', () => {
             query: 'test patternType:regexp',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
             repoChecked: false,
             validChecked: true,
         },
@@ -60,7 +56,6 @@ describe('FormTriggerArea', () => {
             query: 'test patternType:structural',
             isSourcegraphDotCom: true,
             patternTypeChecked: false,
-            typeChecked: false,
             repoChecked: false,
             validChecked: true,
         },
@@ -68,7 +63,6 @@ describe('FormTriggerArea', () => {
             query: 'test type:repo',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
             repoChecked: false,
             validChecked: true,
         },
@@ -76,7 +70,6 @@ describe('FormTriggerArea', () => {
             query: 'test type:diff',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: true,
             repoChecked: false,
             validChecked: true,
         },
@@ -84,7 +77,6 @@ describe('FormTriggerArea', () => {
             query: 'test type:commit',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: true,
             repoChecked: false,
             validChecked: true,
         },
@@ -92,7 +84,6 @@ describe('FormTriggerArea', () => {
             query: 'test repo:test',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: false,
             repoChecked: true,
             validChecked: true,
         },
@@ -100,7 +91,6 @@ describe('FormTriggerArea', () => {
             query: 'test repo:test type:diff',
             isSourcegraphDotCom: true,
             patternTypeChecked: true,
-            typeChecked: true,
             repoChecked: true,
             validChecked: true,
         },
@@ -131,13 +121,6 @@ describe('FormTriggerArea', () => {
                 expect(patternTypeCheckbox).not.toBeChecked()
             }
 
-            const typeCheckbox = screen.getByTestId('type-checkbox')
-            if (testCase.typeChecked) {
-                expect(typeCheckbox).toBeChecked()
-            } else {
-                expect(typeCheckbox).not.toBeChecked()
-            }
-
             const repoCheckbox = screen.getByTestId('repo-checkbox')
             if (testCase.isSourcegraphDotCom) {
                 const repoCheckbox = screen.getByTestId('repo-checkbox')
@@ -196,4 +179,26 @@ describe('FormTriggerArea', () => {
 
         sinon.assert.calledOnceWithExactly(onQueryChange, 'test patternType:regexp type:diff repo:test')
     })
+
+    test('Accept queries that do not search commits', () => {
+        const onQueryChange = sinon.spy()
+        renderWithBrandedContext(
+            <FormTriggerArea
+                query="file:Dockerfile FROM ubuntu:18.04 select:repo"
+                triggerCompleted={false}
+                onQueryChange={onQueryChange}
+                setTriggerCompleted={sinon.spy()}
+                startExpanded={false}
+                isLightTheme={true}
+                isSourcegraphDotCom={false}
+            />
+        )
+        userEvent.click(screen.getByTestId('trigger-button'))
+        userEvent.click(screen.getByTestId('submit-trigger'))
+
+        sinon.assert.calledOnceWithExactly(
+            onQueryChange,
+            'file:Dockerfile FROM ubuntu:18.04 select:repo patternType:literal'
+        )
+    })
 })
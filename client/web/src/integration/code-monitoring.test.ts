@@ -133,10 +133,10 @@ describe('Code monitoring', () => {
             await driver.page.click('.test-trigger-button')
 
             const input = await createEditorAPI(driver, '.test-trigger-input')
-            await input.append('foobar', 'type')
+            await input.append('foobar patternType:structural', 'type')
             await driver.page.waitForSelector('.test-is-invalid')
 
-            await input.append(' type:diff', 'type')
+            await input.replace('foobar', 'type')
             await driver.page.waitForSelector('.test-is-valid')
             await driver.page.waitForSelector('.test-preview-link')
             expect(
@@ -3,12 +3,21 @@ package blobstore
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -26,9 +35,15 @@ type Service struct {
 	Log            log.Logger
 	ObservationCtx *observation.Context
 
+	// Lifecycle are the lifecycle rules of buckets by name, enforced by the janitor.
+	Lifecycle map[string]BucketLifecycle
+
 	initOnce      sync.Once
 	bucketLocksMu sync.Mutex
 	bucketLocks   map[string]*sync.RWMutex
+	keyLocks      keyLocks
+	keyIndexesMu  sync.Mutex
+	keyIndexes    map[string]*keyIndex
 }
 
 func (s *Service) init() {
@@ -38,6 +53,15 @@ func (s *Service) init() {
 		if err := os.MkdirAll(filepath.Join(s.DataDir, "buckets"), os.ModePerm); err != nil {
 			s.Log.Fatal("cannot create buckets directory:", sglog.Error(err))
 		}
+
+		// Content is written to the tmp directory before being moved in place, so it must be on
+		// the same filesystem as the buckets. Leftovers from a previous run are never committed.
+		if err := os.RemoveAll(s.tmpDir()); err != nil {
+			s.Log.Fatal("cannot clean tmp directory:", sglog.Error(err))
+		}
+		if err := os.MkdirAll(s.tmpDir(), os.ModePerm); err != nil {
+			s.Log.Fatal("cannot create tmp directory:", sglog.Error(err))
+		}
 	})
 }
 
@@ -47,50 +71,544 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	metricRunning.Inc()
 	defer metricRunning.Dec()
 
-	err := s.serve(w, r)
+	sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+	defer func() {
+		metricRequestTotal.WithLabelValues(strconv.Itoa(sw.status)).Inc()
+	}()
+
+	err := s.serve(sw, r)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		var s3Err *s3Error
+		if errors.As(err, &s3Err) {
+			s.writeS3Error(sw, r, s3Err)
+			return
+		}
+		sw.WriteHeader(http.StatusInternalServerError)
 		s.Log.Error("serving request", sglog.Error(err))
-		fmt.Fprintf(w, "error: %v", err)
+		fmt.Fprintf(sw, "error: %v", err)
 		return
 	}
 }
 
+// statusWriter records the status code of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// serve implements the subset of the S3 API used by the uploadstore package. Requests use
+// path-style addressing, i.e. /<bucket>/<key>. Errors of type *s3Error are returned to the
+// client as S3 error responses, other errors as internal server errors.
 func (s *Service) serve(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
-	path := strings.FieldsFunc(r.URL.Path, func(r rune) bool { return r == '/' })
-	switch r.Method {
-	case "PUT":
-		if len(path) == 1 {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !bucketNamePattern.MatchString(bucket) {
+		return errInvalidBucketName
+	}
+	if len(key) > maxKeyLength {
+		return errKeyTooLong
+	}
+	if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		// aws-chunked payloads are not supported.
+		return errNotImplemented
+	}
+	query := r.URL.Query()
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodPut:
 			// PUT /<bucket>
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_CreateBucket.html
-			if r.ContentLength != 0 {
-				return errors.Newf("expected CreateBucket request to have content length 0: %s %s", r.Method, r.URL)
+			if r.ContentLength > 0 {
+				// The optional CreateBucketConfiguration only specifies a region.
+				_, _ = io.Copy(io.Discard, io.LimitReader(r.Body, 64*1024))
 			}
-			if err := s.createBucket(ctx, path[0]); err != nil {
+			if err := s.createBucket(ctx, bucket); err != nil {
 				if err == ErrBucketAlreadyExists {
-					w.WriteHeader(http.StatusConflict)
-					fmt.Fprintf(w, "bucket already exists")
-					return nil
+					return errBucketAlreadyOwnedByYou
 				}
 				return errors.Wrap(err, "createBucket")
 			}
 			w.WriteHeader(http.StatusOK)
 			return nil
+		case http.MethodHead:
+			// HEAD /<bucket>
+			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadBucket.html
+			return s.withBucket(bucket, func() error {
+				w.WriteHeader(http.StatusOK)
+				return nil
+			})
+		case http.MethodGet:
+			// GET /<bucket>?list-type=2
+			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectsV2.html
+			if query.Get("list-type") != "2" {
+				return errNotImplemented
+			}
+			return s.withBucket(bucket, func() error { return s.serveListObjectsV2(w, bucket, query) })
+		case http.MethodPost:
+			// POST /<bucket>?delete
+			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteObjects.html
+			if !query.Has("delete") {
+				return errNotImplemented
+			}
+			return s.withBucket(bucket, func() error { return s.serveDeleteObjects(w, r, bucket) })
+		default:
+			return errMethodNotAllowed
 		}
-		return errors.Newf("unexpected PUT request: %s", r.URL)
-	case "GET":
-		if len(path) == 2 && r.URL.Query().Get("x-id") == "GetObject" {
+	}
+
+	uploadID := query.Get("uploadId")
+	return s.withBucket(bucket, func() error {
+		switch r.Method {
+		case http.MethodPut:
+			if uploadID != "" {
+				// PUT /<bucket>/<key>?partNumber=<n>&uploadId=<id>
+				// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPart.html
+				// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html
+				return s.serveUploadPart(w, r, bucket, key, uploadID)
+			}
+			if r.Header.Get("X-Amz-Copy-Source") != "" {
+				// PUT /<bucket>/<key> with x-amz-copy-source
+				// https://docs.aws.amazon.com/AmazonS3/latest/API/API_CopyObject.html
+				return s.serveCopyObject(w, r, bucket, key)
+			}
+			// PUT /<bucket>/<key>
+			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObject.html
+			return s.servePutObject(w, r, bucket, key)
+		case http.MethodGet, http.MethodHead:
 			// GET /<bucket>/<key>?x-id=GetObject
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObject.html
-			// TODO(blobstore): implement me!
-			w.WriteHeader(http.StatusNotFound)
+			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadObject.html
+			return s.serveGetObject(w, r, bucket, key)
+		case http.MethodDelete:
+			if uploadID != "" {
+				// DELETE /<bucket>/<key>?uploadId=<id>
+				// https://docs.aws.amazon.com/AmazonS3/latest/API/API_AbortMultipartUpload.html
+				if err := s.abortMultipartUpload(bucket, key, uploadID); err != nil {
+					return err
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			}
+			// DELETE /<bucket>/<key>
+			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteObject.html
+			if err := s.deleteObject(bucket, key); err != nil {
+				return errors.Wrap(err, "deleteObject")
+			}
+			w.WriteHeader(http.StatusNoContent)
 			return nil
+		case http.MethodPost:
+			if query.Has("uploads") {
+				// POST /<bucket>/<key>?uploads
+				// https://docs.aws.amazon.com/AmazonS3/latest/API/API_CreateMultipartUpload.html
+				uploadID, err := s.createMultipartUpload(bucket, key)
+				if err != nil {
+					return errors.Wrap(err, "createMultipartUpload")
+				}
+				s.writeXML(w, http.StatusOK, initiateMultipartUploadResult{Xmlns: s3Namespace, Bucket: bucket, Key: key, UploadID: uploadID})
+				return nil
+			}
+			if uploadID != "" {
+				// POST /<bucket>/<key>?uploadId=<id>
+				// https://docs.aws.amazon.com/AmazonS3/latest/API/API_CompleteMultipartUpload.html
+				return s.serveCompleteMultipartUpload(w, r, bucket, key, uploadID)
+			}
+			return errNotImplemented
+		default:
+			return errMethodNotAllowed
 		}
-		return errors.Newf("unexpected GET request: %s", r.URL)
-	default:
-		return errors.Newf("unexpected request: %s %s", r.Method, r.URL)
+	})
+}
+
+// withBucket calls fn while holding the read lock of bucket, if the bucket exists.
+func (s *Service) withBucket(bucket string, fn func() error) error {
+	lock := s.bucketLock(bucket)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	if err := s.checkBucket(bucket); err != nil {
+		return err
 	}
+	return fn()
+}
+
+func (s *Service) servePutObject(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	tmp, err := s.receive(r)
+	if err != nil {
+		return err
+	}
+	meta, err := s.putObject(bucket, key, tmp, tmp.etag())
+	if err != nil {
+		os.Remove(tmp.path)
+		return errors.Wrap(err, "putObject")
+	}
+	w.Header().Set("ETag", quoteETag(meta.ETag))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// receive writes the body of a request to a temporary file, and verifies its checksums.
+func (s *Service) receive(r *http.Request) (*tempFile, error) {
+	tmp, err := s.writeTemp(r.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "receiving content")
+	}
+	if err := verifyChecksums(tmp.checksums, r.Header.Get("Content-Md5"), r.Header.Get("X-Amz-Content-Sha256")); err != nil {
+		os.Remove(tmp.path)
+		return nil, err
+	}
+	return tmp, nil
+}
+
+func (s *Service) serveGetObject(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	meta, f, err := s.openObject(bucket, key)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if r.Method == http.MethodGet {
+		s.touchBlob(bucket, f)
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "application/octet-stream")
+	h.Set("ETag", quoteETag(meta.ETag))
+	h.Set("Last-Modified", meta.LastModified.UTC().Format(http.TimeFormat))
+	h.Set("Accept-Ranges", "bytes")
+
+	status := http.StatusOK
+	var body io.Reader = &verifyingReader{r: f, hash: sha256.New(), want: meta.SHA256}
+	length := meta.Size
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		start, n, err := parseRange(rangeHeader, meta.Size)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Seek(start, io.SeekStart); err != nil {
+			return errors.Wrap(err, "Seek")
+		}
+		// Partial reads cannot be verified against the checksum of the whole object.
+		body = io.LimitReader(f, n)
+		length = n
+		status = http.StatusPartialContent
+		h.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+n-1, meta.Size))
+	}
+	h.Set("Content-Length", strconv.FormatInt(length, 10))
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	if _, err := io.Copy(w, body); err != nil {
+		if err == errChecksumMismatch {
+			s.Log.Error("object is corrupted", sglog.String("bucket", bucket), sglog.String("key", key), sglog.String("blob", meta.Blob))
+		}
+		// The status code was already sent, so the only way to tell the client the response is
+		// incomplete is to abort it.
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
+// parseRange parses a Range header with a single byte range, and returns the offset and length
+// of the range within an object of the given size.
+func parseRange(header string, size int64) (start, length int64, err error) {
+	spec := strings.TrimPrefix(header, "bytes=")
+	if spec == header || strings.Contains(spec, ",") {
+		return 0, 0, errInvalidRange
+	}
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, errInvalidRange
+	}
+
+	if first == "" {
+		// bytes=-N is the last N bytes.
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 || size == 0 {
+			return 0, 0, errInvalidRange
+		}
+		if n > size {
+			n = size
+		}
+		return size - n, n, nil
+	}
+
+	start, err = strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, errInvalidRange
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, errInvalidRange
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end - start + 1, nil
+}
+
+func quoteETag(etag string) string {
+	return `"` + etag + `"`
+}
+
+// copySource returns the bucket and key of the x-amz-copy-source header of a request.
+func copySource(r *http.Request) (bucket, key string, err error) {
+	source, err := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+	if err != nil {
+		return "", "", errInvalidArgument
+	}
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(source, "/"), "/")
+	if !ok || key == "" || !bucketNamePattern.MatchString(bucket) {
+		return "", "", errInvalidArgument
+	}
+	if strings.Contains(key, "?versionId=") {
+		return "", "", errNotImplemented
+	}
+	return bucket, key, nil
+}
+
+// copyToTemp copies the object named by the x-amz-copy-source header of a request to a
+// temporary file. If withRange is true, the x-amz-copy-source-range header is respected.
+// Callers hold the read lock of destBucket.
+func (s *Service) copyToTemp(r *http.Request, destBucket string, withRange bool) (*tempFile, *objectMeta, error) {
+	bucket, key, err := copySource(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tmp *tempFile
+	var meta *objectMeta
+	withBucket := s.withBucket
+	if bucket == destBucket {
+		// Read-locking the bucket again could deadlock with a pending writer.
+		withBucket = func(_ string, fn func() error) error { return fn() }
+	}
+	err = withBucket(bucket, func() error {
+		var f *os.File
+		meta, f, err = s.openObject(bucket, key)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		var src io.Reader = &verifyingReader{r: f, hash: sha256.New(), want: meta.SHA256}
+		if rangeHeader := r.Header.Get("X-Amz-Copy-Source-Range"); withRange && rangeHeader != "" {
+			start, n, err := parseRange(rangeHeader, meta.Size)
+			if err != nil {
+				return err
+			}
+			if _, err := f.Seek(start, io.SeekStart); err != nil {
+				return errors.Wrap(err, "Seek")
+			}
+			src = io.LimitReader(f, n)
+		}
+		tmp, err = s.writeTemp(src)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tmp, meta, nil
+}
+
+func (s *Service) serveCopyObject(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	tmp, source, err := s.copyToTemp(r, bucket, false)
+	if err != nil {
+		return err
+	}
+	// The entity tag of a copy is the one of its source.
+	meta, err := s.putObject(bucket, key, tmp, source.ETag)
+	if err != nil {
+		os.Remove(tmp.path)
+		return errors.Wrap(err, "putObject")
+	}
+	s.writeXML(w, http.StatusOK, copyObjectResult{Xmlns: s3Namespace, ETag: quoteETag(meta.ETag), LastModified: s3Time(meta.LastModified)})
+	return nil
+}
+
+func (s *Service) serveUploadPart(w http.ResponseWriter, r *http.Request, bucket, key, uploadID string) error {
+	partNumber, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > maxPartNumber {
+		return errInvalidArgument
+	}
+
+	isCopy := r.Header.Get("X-Amz-Copy-Source") != ""
+	var tmp *tempFile
+	if isCopy {
+		tmp, _, err = s.copyToTemp(r, bucket, true)
+	} else {
+		tmp, err = s.receive(r)
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.uploadPart(bucket, key, uploadID, partNumber, tmp); err != nil {
+		os.Remove(tmp.path)
+		return err
+	}
+
+	if isCopy {
+		s.writeXML(w, http.StatusOK, copyPartResult{Xmlns: s3Namespace, ETag: quoteETag(tmp.etag()), LastModified: s3Time(time.Now())})
+		return nil
+	}
+	w.Header().Set("ETag", quoteETag(tmp.etag()))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// maxXMLRequestSize bounds the size of XML request bodies. A DeleteObjects request for 1000
+// keys of maximum length fits.
+const maxXMLRequestSize = 2 * 1024 * 1024
+
+// readXMLRequest decodes the XML body of a request into v, verifying its Content-MD5 header.
+func readXMLRequest(r *http.Request, v any) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxXMLRequestSize))
+	if err != nil {
+		return errors.Wrap(err, "reading request")
+	}
+	sum := md5.Sum(body)
+	if err := verifyChecksums(checksums{md5: sum[:], sha256: sha256Sum(body)}, r.Header.Get("Content-Md5"), r.Header.Get("X-Amz-Content-Sha256")); err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(body, v); err != nil {
+		return errMalformedXML
+	}
+	return nil
+}
+
+func sha256Sum(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
+
+func (s *Service) serveCompleteMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key, uploadID string) error {
+	var req completeMultipartUploadRequest
+	if err := readXMLRequest(r, &req); err != nil {
+		return err
+	}
+	parts := make([]completedPart, 0, len(req.Parts))
+	for _, p := range req.Parts {
+		parts = append(parts, completedPart{number: p.PartNumber, etag: p.ETag})
+	}
+
+	meta, err := s.completeMultipartUpload(bucket, key, uploadID, parts)
+	if err != nil {
+		return err
+	}
+	s.writeXML(w, http.StatusOK, completeMultipartUploadResult{Xmlns: s3Namespace, Bucket: bucket, Key: key, ETag: quoteETag(meta.ETag)})
+	return nil
+}
+
+// maxDeleteObjects is the maximum number of keys in a DeleteObjects request.
+const maxDeleteObjects = 1000
+
+func (s *Service) serveDeleteObjects(w http.ResponseWriter, r *http.Request, bucket string) error {
+	var req deleteRequest
+	if err := readXMLRequest(r, &req); err != nil {
+		return err
+	}
+	if len(req.Objects) == 0 || len(req.Objects) > maxDeleteObjects {
+		return errMalformedXML
+	}
+
+	result := deleteResult{Xmlns: s3Namespace}
+	for _, object := range req.Objects {
+		if err := s.deleteObject(bucket, object.Key); err != nil {
+			s.Log.Error("deleting object", sglog.String("bucket", bucket), sglog.String("key", object.Key), sglog.Error(err))
+			result.Errors = append(result.Errors, deleteError{Key: object.Key, Code: "InternalError", Message: "We encountered an internal error. Please try again."})
+			continue
+		}
+		if !req.Quiet {
+			result.Deleted = append(result.Deleted, deletedObject{Key: object.Key})
+		}
+	}
+	s.writeXML(w, http.StatusOK, result)
+	return nil
+}
+
+// maxListKeys is the maximum number of keys returned by a ListObjectsV2 request.
+const maxListKeys = 1000
+
+func (s *Service) serveListObjectsV2(w http.ResponseWriter, bucket string, query url.Values) error {
+	prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+	maxKeys := maxListKeys
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return errInvalidArgument
+		}
+		if n < maxKeys {
+			maxKeys = n
+		}
+	}
+
+	// Continuation tokens are the last key or common prefix returned by the previous page.
+	marker := query.Get("start-after")
+	token := query.Get("continuation-token")
+	if token != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return errInvalidArgument
+		}
+		marker = string(decoded)
+	}
+
+	entries, truncated, err := s.listPage(bucket, prefix, delimiter, marker, maxKeys)
+	if err != nil {
+		return errors.Wrap(err, "listPage")
+	}
+
+	result := listBucketResult{
+		Xmlns:             s3Namespace,
+		Name:              bucket,
+		Prefix:            prefix,
+		Delimiter:         delimiter,
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: token,
+		MaxKeys:           maxKeys,
+	}
+	last := ""
+	for _, entry := range entries {
+		last = entry.name
+		if entry.isPrefix {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: entry.name})
+			result.KeyCount++
+			continue
+		}
+
+		object, err := s.readMeta(bucket, entry.name)
+		if err == errNoSuchKey {
+			// Deleted since the page was listed.
+			continue
+		} else if err != nil {
+			return err
+		}
+		result.Contents = append(result.Contents, listObject{
+			Key:          object.Key,
+			LastModified: s3Time(object.LastModified),
+			ETag:         quoteETag(object.ETag),
+			Size:         object.Size,
+			StorageClass: "STANDARD",
+		})
+		result.KeyCount++
+	}
+	if truncated {
+		result.IsTruncated = true
+		result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(last))
+	}
+	s.writeXML(w, http.StatusOK, result)
+	return nil
 }
 
 var (
@@ -99,7 +617,6 @@ var (
 
 func (s *Service) createBucket(ctx context.Context, name string) error {
 	_ = ctx
-	defer s.Log.Info("created bucket", sglog.String("name", name))
 
 	// Lock the bucket so nobody can read or write to the same bucket while we create it.
 	bucketLock := s.bucketLock(name)
@@ -111,9 +628,12 @@ func (s *Service) createBucket(ctx context.Context, name string) error {
 	if _, err := os.Stat(bucketDir); err == nil {
 		return ErrBucketAlreadyExists
 	}
-	if err := os.Mkdir(bucketDir, os.ModePerm); err != nil {
-		return errors.Wrap(err, "MkdirAll")
+	for _, dir := range []string{"objects", "blobs", "uploads"} {
+		if err := os.MkdirAll(filepath.Join(bucketDir, dir), os.ModePerm); err != nil {
+			return errors.Wrap(err, "MkdirAll")
+		}
 	}
+	s.Log.Info("created bucket", sglog.String("name", name))
 	return nil
 }
 
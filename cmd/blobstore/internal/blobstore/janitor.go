@@ -0,0 +1,358 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// BucketLifecycle are the lifecycle rules of a bucket, enforced by the janitor.
+type BucketLifecycle struct {
+	// Rules expire objects by age.
+	Rules []LifecycleRule `json:"rules"`
+	// MaxTotalBytes is the maximum total size of the objects in the bucket. When the bucket is
+	// larger, the least recently used objects are evicted. Zero means no limit.
+	MaxTotalBytes int64 `json:"maxTotalBytes"`
+}
+
+// LifecycleRule expires the objects whose key starts with Prefix once they are older than
+// ExpireAfterDays days.
+type LifecycleRule struct {
+	Prefix          string `json:"prefix"`
+	ExpireAfterDays int    `json:"expireAfterDays"`
+}
+
+// ParseLifecycle parses a JSON object mapping bucket names to their lifecycle rules, for
+// example:
+//
+//	{"lsif-uploads": {"rules": [{"prefix": "upload-", "expireAfterDays": 7}], "maxTotalBytes": 10737418240}}
+func ParseLifecycle(s string) (map[string]BucketLifecycle, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var lifecycle map[string]BucketLifecycle
+	if err := json.Unmarshal([]byte(s), &lifecycle); err != nil {
+		return nil, errors.Wrap(err, "invalid lifecycle rules")
+	}
+	for bucket, l := range lifecycle {
+		if !bucketNamePattern.MatchString(bucket) {
+			return nil, errors.Newf("invalid lifecycle rules: invalid bucket name %q", bucket)
+		}
+		if l.MaxTotalBytes < 0 {
+			return nil, errors.Newf("invalid lifecycle rules for bucket %q: maxTotalBytes must not be negative", bucket)
+		}
+		for _, rule := range l.Rules {
+			if rule.ExpireAfterDays <= 0 {
+				return nil, errors.Newf("invalid lifecycle rules for bucket %q: expireAfterDays must be positive", bucket)
+			}
+		}
+	}
+	return lifecycle, nil
+}
+
+const (
+	// orphanedBlobAge is the age after which blobs that are not referenced by any object are
+	// removed. Blobs are written before the objects referencing them, so recent blobs may
+	// become referenced.
+	orphanedBlobAge = time.Hour
+
+	// abandonedUploadAge is the age after which multipart uploads that were neither completed
+	// nor aborted are removed.
+	abandonedUploadAge = 24 * time.Hour
+
+	// accessTimeResolution is how often the last access time of an object is updated when it
+	// is read, see touchBlob.
+	accessTimeResolution = time.Minute
+)
+
+// touchBlob records that a blob was read. The last access time of an object is the
+// modification time of its blob, which is otherwise never modified.
+func (s *Service) touchBlob(bucket string, f *os.File) {
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(info.ModTime()) < accessTimeResolution {
+		return
+	}
+	if err := os.Chtimes(f.Name(), now, now); err != nil && !os.IsNotExist(err) {
+		s.Log.Warn("updating access time", sglog.String("bucket", bucket), sglog.Error(err))
+	}
+}
+
+type janitor struct {
+	service *Service
+	now     func() time.Time
+}
+
+var (
+	_ goroutine.Handler      = &janitor{}
+	_ goroutine.ErrorHandler = &janitor{}
+)
+
+// NewJanitor returns a background routine which enforces the lifecycle rules of the buckets,
+// removes leftovers of failed writes and abandoned multipart uploads, and reports the disk
+// usage of the buckets.
+func (s *Service) NewJanitor(interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), "blobstore.janitor", "enforces blobstore bucket lifecycle rules",
+		interval, &janitor{service: s, now: time.Now},
+	)
+}
+
+func (j *janitor) Handle(ctx context.Context) error {
+	j.service.init()
+
+	entries, err := os.ReadDir(filepath.Join(j.service.DataDir, "buckets"))
+	if err != nil {
+		return errors.Wrap(err, "listing buckets")
+	}
+	var errs error
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !entry.IsDir() {
+			continue
+		}
+		if err := j.cleanBucket(entry.Name()); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "bucket %q", entry.Name()))
+		}
+	}
+	return errs
+}
+
+func (j *janitor) HandleError(err error) {
+	metricJanitorErrors.Inc()
+	j.service.Log.Error("cleaning buckets", sglog.Error(err))
+}
+
+func (j *janitor) cleanBucket(bucket string) error {
+	s := j.service
+	lock := s.bucketLock(bucket)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	objects, err := s.listObjects(bucket, "")
+	if err != nil {
+		return errors.Wrap(err, "listObjects")
+	}
+	referenced := make(map[string]struct{}, len(objects))
+	for _, object := range objects {
+		referenced[object.Blob] = struct{}{}
+	}
+	if err := j.removeOrphanedBlobs(bucket, referenced); err != nil {
+		return err
+	}
+	if err := j.removeAbandonedUploads(bucket); err != nil {
+		return err
+	}
+
+	lifecycle := s.Lifecycle[bucket]
+	objects, err = j.expire(bucket, lifecycle.Rules, objects)
+	if err != nil {
+		return err
+	}
+	if lifecycle.MaxTotalBytes > 0 {
+		objects, err = j.evict(bucket, lifecycle.MaxTotalBytes, objects)
+		if err != nil {
+			return err
+		}
+	}
+
+	var size int64
+	for _, object := range objects {
+		size += object.Size
+	}
+	metricBucketObjects.WithLabelValues(bucket).Set(float64(len(objects)))
+	metricBucketSizeBytes.WithLabelValues(bucket).Set(float64(size))
+	return nil
+}
+
+// expire removes the objects matching an expiration rule, and returns the remaining objects.
+func (j *janitor) expire(bucket string, rules []LifecycleRule, objects []*objectMeta) ([]*objectMeta, error) {
+	if len(rules) == 0 {
+		return objects, nil
+	}
+	now := j.now()
+	remaining := objects[:0]
+	for _, object := range objects {
+		if !isExpired(rules, object, now) {
+			remaining = append(remaining, object)
+			continue
+		}
+		if err := j.removeObject(bucket, object, "expired"); err != nil {
+			return nil, err
+		}
+	}
+	return remaining, nil
+}
+
+func isExpired(rules []LifecycleRule, object *objectMeta, now time.Time) bool {
+	for _, rule := range rules {
+		maxAge := time.Duration(rule.ExpireAfterDays) * 24 * time.Hour
+		if strings.HasPrefix(object.Key, rule.Prefix) && now.Sub(object.LastModified) >= maxAge {
+			return true
+		}
+	}
+	return false
+}
+
+// evict removes the least recently used objects until the total size of the remaining objects
+// is at most maxTotalBytes, and returns the remaining objects.
+func (j *janitor) evict(bucket string, maxTotalBytes int64, objects []*objectMeta) ([]*objectMeta, error) {
+	var size int64
+	for _, object := range objects {
+		size += object.Size
+	}
+	if size <= maxTotalBytes {
+		return objects, nil
+	}
+
+	lastAccess := make(map[*objectMeta]time.Time, len(objects))
+	for _, object := range objects {
+		lastAccess[object] = object.LastModified
+		if info, err := os.Stat(j.service.blobPath(bucket, object.Blob)); err == nil {
+			lastAccess[object] = info.ModTime()
+		}
+	}
+	byLastAccess := append([]*objectMeta(nil), objects...)
+	sort.SliceStable(byLastAccess, func(i, k int) bool {
+		return lastAccess[byLastAccess[i]].Before(lastAccess[byLastAccess[k]])
+	})
+
+	evicted := map[*objectMeta]struct{}{}
+	for _, object := range byLastAccess {
+		if size <= maxTotalBytes {
+			break
+		}
+		if err := j.removeObject(bucket, object, "evicted"); err != nil {
+			return nil, err
+		}
+		evicted[object] = struct{}{}
+		size -= object.Size
+	}
+
+	remaining := objects[:0]
+	for _, object := range objects {
+		if _, ok := evicted[object]; !ok {
+			remaining = append(remaining, object)
+		}
+	}
+	return remaining, nil
+}
+
+// removeObject removes an object, unless it was replaced since it was listed.
+func (j *janitor) removeObject(bucket string, object *objectMeta, reason string) error {
+	removed, err := j.service.deleteObjectVersion(bucket, object.Key, object.Blob)
+	if err != nil {
+		return errors.Wrapf(err, "deleting %q", object.Key)
+	}
+	if removed {
+		metricJanitorRemovedObjects.WithLabelValues(bucket, reason).Inc()
+		metricJanitorRemovedBytes.WithLabelValues(bucket, reason).Add(float64(object.Size))
+	}
+	return nil
+}
+
+// removeOrphanedBlobs removes the blobs not referenced by any object. Such blobs are left
+// behind when the service stops between writing a blob and committing its object.
+func (j *janitor) removeOrphanedBlobs(bucket string, referenced map[string]struct{}) error {
+	entries, err := os.ReadDir(filepath.Join(j.service.bucketDir(bucket), "blobs"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "listing blobs")
+	}
+	now := j.now()
+	for _, entry := range entries {
+		if _, ok := referenced[entry.Name()]; ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < orphanedBlobAge {
+			continue
+		}
+		// Objects committed after listing reference recent blobs, so this blob is orphaned.
+		if err := os.Remove(j.service.blobPath(bucket, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "removing orphaned blob")
+		}
+		metricJanitorRemovedBytes.WithLabelValues(bucket, "orphaned").Add(float64(info.Size()))
+	}
+	return nil
+}
+
+// removeAbandonedUploads removes the multipart uploads initiated more than abandonedUploadAge
+// ago.
+func (j *janitor) removeAbandonedUploads(bucket string) error {
+	uploadsDir := filepath.Join(j.service.bucketDir(bucket), "uploads")
+	entries, err := os.ReadDir(uploadsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "listing uploads")
+	}
+	now := j.now()
+	for _, entry := range entries {
+		dir := filepath.Join(uploadsDir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(dir, "upload.json"))
+		if err != nil {
+			continue
+		}
+		var upload uploadMeta
+		if err := json.Unmarshal(data, &upload); err != nil || now.Sub(upload.Initiated) < abandonedUploadAge {
+			continue
+		}
+
+		var size int64
+		_ = filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				if info, err := d.Info(); err == nil {
+					size += info.Size()
+				}
+			}
+			return nil
+		})
+		if err := os.RemoveAll(dir); err != nil {
+			return errors.Wrap(err, "removing abandoned upload")
+		}
+		metricJanitorRemovedBytes.WithLabelValues(bucket, "abandoned_upload").Add(float64(size))
+	}
+	return nil
+}
+
+var (
+	metricBucketObjects = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blobstore_bucket_objects",
+		Help: "Number of objects in a blobstore bucket.",
+	}, []string{"bucket"})
+	metricBucketSizeBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blobstore_bucket_size_bytes",
+		Help: "Total size of the objects in a blobstore bucket.",
+	}, []string{"bucket"})
+	metricJanitorRemovedObjects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "blobstore_janitor_removed_objects_total",
+		Help: "Number of objects removed by the blobstore janitor, by reason (expired or evicted).",
+	}, []string{"bucket", "reason"})
+	metricJanitorRemovedBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "blobstore_janitor_removed_bytes_total",
+		Help: "Number of bytes removed by the blobstore janitor, by reason (expired, evicted, orphaned or abandoned_upload).",
+	}, []string{"bucket", "reason"})
+	metricJanitorErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "blobstore_janitor_errors_total",
+		Help: "Number of errors while enforcing blobstore lifecycle rules.",
+	})
+)
@@ -0,0 +1,189 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+)
+
+func TestParseLifecycle(t *testing.T) {
+	lifecycle, err := ParseLifecycle(`{"lsif-uploads": {"rules": [{"prefix": "upload-", "expireAfterDays": 7}], "maxTotalBytes": 1024}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]BucketLifecycle{
+		"lsif-uploads": {Rules: []LifecycleRule{{Prefix: "upload-", ExpireAfterDays: 7}}, MaxTotalBytes: 1024},
+	}
+	if diff := cmp.Diff(want, lifecycle); diff != "" {
+		t.Fatalf("unexpected lifecycle (-want +got):\n%s", diff)
+	}
+
+	for _, invalid := range []string{
+		`{"lsif-uploads": {"rules": [{"expireAfterDays": 0}]}}`,
+		`{"lsif-uploads": {"maxTotalBytes": -1}}`,
+		`{"../etc": {}}`,
+		`[]`,
+	} {
+		if _, err := ParseLifecycle(invalid); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestJanitorExpire(t *testing.T) {
+	now := time.Now()
+	s := newTestService(t, map[string]BucketLifecycle{
+		"bucket": {Rules: []LifecycleRule{{Prefix: "upload-", ExpireAfterDays: 7}}},
+	})
+	putTestObject(t, s, "upload-old", "content", now.Add(-8*24*time.Hour))
+	putTestObject(t, s, "upload-new", "content", now.Add(-6*24*time.Hour))
+	putTestObject(t, s, "other-old", "content", now.Add(-8*24*time.Hour))
+
+	j := &janitor{service: s, now: func() time.Time { return now }}
+	if err := j.Handle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"other-old", "upload-new"}, listTestKeys(t, s)); diff != "" {
+		t.Fatalf("unexpected objects (-want +got):\n%s", diff)
+	}
+	if blobs := listTestBlobs(t, s); len(blobs) != 2 {
+		t.Fatalf("expected the blob of the expired object to be removed, got %v", blobs)
+	}
+}
+
+func TestJanitorEvict(t *testing.T) {
+	now := time.Now()
+	s := newTestService(t, map[string]BucketLifecycle{
+		"bucket": {MaxTotalBytes: 10},
+	})
+	// Objects are evicted by last access, not by last modification.
+	putTestObject(t, s, "a", "12345", now.Add(-3*time.Hour))
+	putTestObject(t, s, "b", "12345", now.Add(-2*time.Hour))
+	putTestObject(t, s, "c", "12345", now.Add(-1*time.Hour))
+	meta, err := s.readMeta("bucket", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(s.blobPath("bucket", meta.Blob), now, now); err != nil {
+		t.Fatal(err)
+	}
+
+	j := &janitor{service: s, now: func() time.Time { return now }}
+	if err := j.Handle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a", "c"}, listTestKeys(t, s)); diff != "" {
+		t.Fatalf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+func TestJanitorOrphans(t *testing.T) {
+	now := time.Now()
+	s := newTestService(t, nil)
+	putTestObject(t, s, "a", "content", now.Add(-48*time.Hour))
+
+	// A blob whose object was never committed, and one that may still be.
+	for name, mtime := range map[string]time.Time{"orphaned": now.Add(-2 * time.Hour), "recent": now.Add(-time.Minute)} {
+		path := s.blobPath("bucket", name)
+		if err := os.WriteFile(path, []byte("content"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// An upload that was neither completed nor aborted.
+	uploadID, err := s.createMultipartUpload("bucket", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(uploadMeta{Key: "upload", Initiated: now.Add(-25 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(s.uploadDir("bucket", uploadID), "upload.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	j := &janitor{service: s, now: func() time.Time { return now }}
+	if err := j.Handle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a"}, listTestKeys(t, s)); diff != "" {
+		t.Fatalf("unexpected objects (-want +got):\n%s", diff)
+	}
+	blobs := listTestBlobs(t, s)
+	if len(blobs) != 2 || blobs[0] == "orphaned" || blobs[1] == "orphaned" {
+		t.Fatalf("expected the orphaned blob to be removed, got %v", blobs)
+	}
+	if _, err := os.Stat(s.uploadDir("bucket", uploadID)); !os.IsNotExist(err) {
+		t.Fatalf("expected the abandoned upload to be removed, got %v", err)
+	}
+}
+
+func newTestService(t *testing.T, lifecycle map[string]BucketLifecycle) *Service {
+	s := &Service{
+		DataDir:   t.TempDir(),
+		Log:       logtest.Scoped(t),
+		Lifecycle: lifecycle,
+	}
+	s.init()
+	if err := s.createBucket(context.Background(), "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func putTestObject(t *testing.T, s *Service, key, content string, lastModified time.Time) {
+	t.Helper()
+	tmp, err := s.writeTemp(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, err := s.putObject("bucket", key, tmp, tmp.etag())
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.LastModified = lastModified
+	if err := s.commitObject("bucket", meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(s.blobPath("bucket", meta.Blob), lastModified, lastModified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func listTestKeys(t *testing.T, s *Service) []string {
+	t.Helper()
+	objects, err := s.listObjects("bucket", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	return keys
+}
+
+func listTestBlobs(t *testing.T, s *Service) []string {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Join(s.bucketDir("bucket"), "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
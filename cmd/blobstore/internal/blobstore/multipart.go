@@ -0,0 +1,209 @@
+package blobstore
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Multipart uploads are stored in their own directory until they are completed or aborted:
+//
+//	buckets/<bucket>/uploads/<upload ID>/upload.json         see uploadMeta
+//	buckets/<bucket>/uploads/<upload ID>/part-<number>.json  see partMeta
+//	buckets/<bucket>/uploads/<upload ID>/<blob>              the content of a part
+//
+// Completing an upload concatenates its parts into a new object, which is committed like any
+// other object.
+
+// maxPartNumber is the largest part number accepted by S3.
+const maxPartNumber = 10000
+
+type uploadMeta struct {
+	Key       string    `json:"key"`
+	Initiated time.Time `json:"initiated"`
+}
+
+type partMeta struct {
+	Blob string `json:"blob"`
+	Size int64  `json:"size"`
+	// MD5 is the hex MD5 digest of the part, which is also its entity tag.
+	MD5 string `json:"md5"`
+}
+
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+func (s *Service) uploadDir(bucket, uploadID string) string {
+	return filepath.Join(s.bucketDir(bucket), "uploads", uploadID)
+}
+
+func partMetaPath(uploadDir string, partNumber int) string {
+	return filepath.Join(uploadDir, fmt.Sprintf("part-%05d.json", partNumber))
+}
+
+func (s *Service) createMultipartUpload(bucket, key string) (string, error) {
+	uploadID, err := randomID()
+	if err != nil {
+		return "", err
+	}
+	dir := s.uploadDir(bucket, uploadID)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", errors.Wrap(err, "MkdirAll")
+	}
+	data, err := json.Marshal(uploadMeta{Key: key, Initiated: time.Now().UTC()})
+	if err != nil {
+		return "", err
+	}
+	if err := s.writeFileAtomic(filepath.Join(dir, "upload.json"), data); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return uploadID, nil
+}
+
+// readUpload returns the upload with the given ID, which must be an upload of key.
+func (s *Service) readUpload(bucket, key, uploadID string) (*uploadMeta, error) {
+	if !uploadIDPattern.MatchString(uploadID) {
+		return nil, errNoSuchUpload
+	}
+	data, err := os.ReadFile(filepath.Join(s.uploadDir(bucket, uploadID), "upload.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNoSuchUpload
+		}
+		return nil, err
+	}
+	var upload uploadMeta
+	if err := json.Unmarshal(data, &upload); err != nil {
+		return nil, errors.Wrapf(err, "decoding upload %s", uploadID)
+	}
+	if upload.Key != key {
+		return nil, errNoSuchUpload
+	}
+	return &upload, nil
+}
+
+// uploadPart stores a temporary file as a part of an upload, replacing any part previously
+// uploaded with the same number.
+func (s *Service) uploadPart(bucket, key, uploadID string, partNumber int, tmp *tempFile) error {
+	if _, err := s.readUpload(bucket, key, uploadID); err != nil {
+		return err
+	}
+	dir := s.uploadDir(bucket, uploadID)
+	blob, err := randomID()
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.path, filepath.Join(dir, blob)); err != nil {
+		if os.IsNotExist(err) {
+			// The upload was completed or aborted concurrently.
+			return errNoSuchUpload
+		}
+		return errors.Wrap(err, "Rename")
+	}
+
+	path := partMetaPath(dir, partNumber)
+	previous, _ := readPart(path)
+	data, err := json.Marshal(partMeta{Blob: blob, Size: tmp.size, MD5: tmp.etag()})
+	if err != nil {
+		return err
+	}
+	if err := s.writeFileAtomic(path, data); err != nil {
+		os.Remove(filepath.Join(dir, blob))
+		return err
+	}
+	if previous != nil {
+		os.Remove(filepath.Join(dir, previous.Blob))
+	}
+	return nil
+}
+
+func readPart(path string) (*partMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var part partMeta
+	if err := json.Unmarshal(data, &part); err != nil {
+		return nil, errors.Wrapf(err, "decoding %s", path)
+	}
+	return &part, nil
+}
+
+// completedPart is a part listed in a CompleteMultipartUpload request.
+type completedPart struct {
+	number int
+	etag   string
+}
+
+// completeMultipartUpload concatenates the given parts of an upload into the object key, and
+// removes the upload.
+func (s *Service) completeMultipartUpload(bucket, key, uploadID string, parts []completedPart) (*objectMeta, error) {
+	if _, err := s.readUpload(bucket, key, uploadID); err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, errMalformedXML
+	}
+
+	dir := s.uploadDir(bucket, uploadID)
+	readers := make([]io.Reader, 0, len(parts))
+	digests := md5.New()
+	for i, p := range parts {
+		if i > 0 && p.number <= parts[i-1].number {
+			return nil, errInvalidPartOrder
+		}
+		part, err := readPart(partMetaPath(dir, p.number))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, errInvalidPart
+			}
+			return nil, err
+		}
+		if strings.Trim(p.etag, `"`) != part.MD5 {
+			return nil, errInvalidPart
+		}
+		sum, err := hex.DecodeString(part.MD5)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding part digest")
+		}
+		digests.Write(sum)
+
+		f, err := os.Open(filepath.Join(dir, part.Blob))
+		if err != nil {
+			return nil, errors.Wrap(err, "opening part")
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+
+	tmp, err := s.writeTemp(io.MultiReader(readers...))
+	if err != nil {
+		return nil, err
+	}
+	etag := fmt.Sprintf("%s-%d", hex.EncodeToString(digests.Sum(nil)), len(parts))
+	meta, err := s.putObject(bucket, key, tmp, etag)
+	if err != nil {
+		os.Remove(tmp.path)
+		return nil, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, errors.Wrap(err, "removing upload")
+	}
+	return meta, nil
+}
+
+func (s *Service) abortMultipartUpload(bucket, key, uploadID string) error {
+	if _, err := s.readUpload(bucket, key, uploadID); err != nil {
+		return err
+	}
+	return errors.Wrap(os.RemoveAll(s.uploadDir(bucket, uploadID)), "removing upload")
+}
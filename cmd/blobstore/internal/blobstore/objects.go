@@ -0,0 +1,544 @@
+package blobstore
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Objects are stored on disk in two parts:
+//
+//	buckets/<bucket>/blobs/<blob>                    the content of an object
+//	buckets/<bucket>/objects/<hh>/<hash of key>.json the metadata of an object, see objectMeta
+//
+// Blobs are written to a temporary file first and are never modified once they are moved to
+// the blobs directory. Writing the metadata file, which is also done through a rename, is what
+// commits an object: readers either see the previous object or the new one, never a partially
+// written one.
+
+// maxKeyLength is the maximum length of an object key, see
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-keys.html
+const maxKeyLength = 1024
+
+// objectMeta is the metadata of an object.
+type objectMeta struct {
+	Key string `json:"key"`
+	// Blob is the name of the file storing the content of the object.
+	Blob string `json:"blob"`
+	Size int64  `json:"size"`
+	// ETag is the entity tag of the object, without quotes. It is the hex MD5 digest of the
+	// object content, or for objects created by multipart uploads the digest of the part
+	// digests followed by the number of parts, like S3.
+	ETag string `json:"etag"`
+	// SHA256 is the hex SHA-256 digest of the content of the object. It is verified when the
+	// object is read.
+	SHA256       string    `json:"sha256"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+func (s *Service) bucketDir(bucket string) string {
+	return filepath.Join(s.DataDir, "buckets", bucket)
+}
+
+func (s *Service) tmpDir() string {
+	return filepath.Join(s.DataDir, "tmp")
+}
+
+func (s *Service) blobPath(bucket, blob string) string {
+	return filepath.Join(s.bucketDir(bucket), "blobs", blob)
+}
+
+// metaPath returns the path of the metadata file of key. Keys are hashed, so that any key
+// maps to a valid file name, and sharded to keep directories small.
+func (s *Service) metaPath(bucket, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(s.bucketDir(bucket), "objects", name[:2], name+".json")
+}
+
+// checkBucket returns errNoSuchBucket if the bucket does not exist. Callers must hold the
+// bucket lock.
+func (s *Service) checkBucket(bucket string) error {
+	if _, err := os.Stat(s.bucketDir(bucket)); err != nil {
+		if os.IsNotExist(err) {
+			return errNoSuchBucket
+		}
+		return err
+	}
+	return nil
+}
+
+// checksums are the digests of some content written to disk.
+type checksums struct {
+	size   int64
+	md5    []byte
+	sha256 []byte
+}
+
+func (c checksums) etag() string {
+	return hex.EncodeToString(c.md5)
+}
+
+// tempFile is a file in the temporary directory, written with writeTemp.
+type tempFile struct {
+	path string
+	checksums
+}
+
+// writeTemp writes the content of r to a new temporary file, computing its checksums on the
+// way. The file is synced to disk before writeTemp returns.
+func (s *Service) writeTemp(r io.Reader) (_ *tempFile, err error) {
+	f, err := os.CreateTemp(s.tmpDir(), "blob-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateTemp")
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	md5Hash, sha256Hash := md5.New(), sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, md5Hash, sha256Hash), r)
+	if err != nil {
+		return nil, errors.Wrap(err, "writing content")
+	}
+	if err := f.Sync(); err != nil {
+		return nil, errors.Wrap(err, "Sync")
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.Wrap(err, "Close")
+	}
+	return &tempFile{
+		path:      f.Name(),
+		checksums: checksums{size: n, md5: md5Hash.Sum(nil), sha256: sha256Hash.Sum(nil)},
+	}, nil
+}
+
+// verifyChecksums compares the checksums of content received in a request with the
+// Content-MD5 and x-amz-content-sha256 headers sent by the client, if any.
+func verifyChecksums(c checksums, contentMD5, contentSHA256 string) error {
+	if contentMD5 != "" {
+		want, err := base64.StdEncoding.DecodeString(contentMD5)
+		if err != nil {
+			return errInvalidDigest
+		}
+		if string(want) != string(c.md5) {
+			return errBadDigest
+		}
+	}
+	// The header is either the hex digest of the payload, or a marker such as UNSIGNED-PAYLOAD.
+	if want, err := hex.DecodeString(contentSHA256); err == nil && len(want) == sha256.Size {
+		if string(want) != string(c.sha256) {
+			return errSHA256Mismatch
+		}
+	}
+	return nil
+}
+
+// commitBlob moves a temporary file to the blobs of bucket and returns the name of the blob.
+func (s *Service) commitBlob(bucket string, tmp *tempFile) (string, error) {
+	blob, err := randomID()
+	if err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.path, s.blobPath(bucket, blob)); err != nil {
+		return "", errors.Wrap(err, "Rename")
+	}
+	return blob, nil
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generating ID")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// writeFileAtomic writes data to path through a temporary file and a rename, so that readers
+// see either the previous content of path or data.
+func (s *Service) writeFileAtomic(path string, data []byte) (err error) {
+	f, err := os.CreateTemp(s.tmpDir(), "meta-*")
+	if err != nil {
+		return errors.Wrap(err, "CreateTemp")
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err := f.Write(data); err != nil {
+		return errors.Wrap(err, "Write")
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Wrap(err, "Sync")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "Close")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.Wrap(err, "MkdirAll")
+	}
+	return errors.Wrap(os.Rename(f.Name(), path), "Rename")
+}
+
+func (s *Service) readMeta(bucket, key string) (*objectMeta, error) {
+	data, err := os.ReadFile(s.metaPath(bucket, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNoSuchKey
+		}
+		return nil, err
+	}
+	var meta objectMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Wrapf(err, "decoding metadata of %q", key)
+	}
+	return &meta, nil
+}
+
+// keyLocks serializes the commits and deletions of a key. The lock is only held while the
+// metadata file is replaced, not while content is written.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+func (l *keyLocks) lock(bucket, key string) (unlock func()) {
+	name := bucket + "/" + key
+
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*keyLock{}
+	}
+	lock, ok := l.locks[name]
+	if !ok {
+		lock = &keyLock{}
+		l.locks[name] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		l.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(l.locks, name)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// commitObject makes meta the current version of its key, and removes the blob of the
+// previous version if there is one.
+func (s *Service) commitObject(bucket string, meta *objectMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	unlock := s.keyLocks.lock(bucket, meta.Key)
+	previous, err := s.readMeta(bucket, meta.Key)
+	if err != nil && err != errNoSuchKey {
+		unlock()
+		return err
+	}
+	err = s.writeFileAtomic(s.metaPath(bucket, meta.Key), data)
+	if err == nil {
+		s.keyIndex(bucket).add(meta.Key)
+	}
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	if previous != nil && previous.Blob != meta.Blob {
+		s.removeBlob(bucket, previous.Blob)
+	}
+	return nil
+}
+
+// putObject stores the content of a temporary file as the object key.
+func (s *Service) putObject(bucket, key string, tmp *tempFile, etag string) (*objectMeta, error) {
+	blob, err := s.commitBlob(bucket, tmp)
+	if err != nil {
+		return nil, err
+	}
+	meta := &objectMeta{
+		Key:          key,
+		Blob:         blob,
+		Size:         tmp.size,
+		ETag:         etag,
+		SHA256:       hex.EncodeToString(tmp.sha256),
+		LastModified: time.Now().UTC(),
+	}
+	if err := s.commitObject(bucket, meta); err != nil {
+		s.removeBlob(bucket, blob)
+		return nil, err
+	}
+	return meta, nil
+}
+
+// deleteObject deletes an object. Deleting an object that does not exist is not an error.
+func (s *Service) deleteObject(bucket, key string) error {
+	_, err := s.deleteObjectVersion(bucket, key, "")
+	return err
+}
+
+// deleteObjectVersion deletes an object if its content is stored in the given blob, or
+// regardless of its content if blob is empty. It returns whether the object was deleted.
+func (s *Service) deleteObjectVersion(bucket, key, blob string) (bool, error) {
+	unlock := s.keyLocks.lock(bucket, key)
+	meta, err := s.readMeta(bucket, key)
+	if err == errNoSuchKey {
+		unlock()
+		return false, nil
+	} else if err != nil {
+		unlock()
+		return false, err
+	}
+	if blob != "" && meta.Blob != blob {
+		unlock()
+		return false, nil
+	}
+	err = os.Remove(s.metaPath(bucket, key))
+	if err == nil || os.IsNotExist(err) {
+		s.keyIndex(bucket).remove(key)
+	}
+	unlock()
+	if err != nil && !os.IsNotExist(err) {
+		return false, errors.Wrap(err, "Remove")
+	}
+
+	s.removeBlob(bucket, meta.Blob)
+	return true, nil
+}
+
+// removeBlob removes a blob that is no longer referenced. Readers that already opened the blob
+// can keep reading it.
+func (s *Service) removeBlob(bucket, blob string) {
+	if err := os.Remove(s.blobPath(bucket, blob)); err != nil && !os.IsNotExist(err) {
+		s.Log.Warn("removing blob", sglog.String("bucket", bucket), sglog.String("blob", blob), sglog.Error(err))
+	}
+}
+
+// openObject returns the metadata of an object and its content.
+func (s *Service) openObject(bucket, key string) (*objectMeta, *os.File, error) {
+	// The blob of an object is removed when the object is replaced, which can happen between
+	// reading the metadata and opening the blob. In that case the metadata of the new version
+	// is read again.
+	for attempt := 0; ; attempt++ {
+		meta, err := s.readMeta(bucket, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		f, err := os.Open(s.blobPath(bucket, meta.Blob))
+		if err == nil {
+			return meta, f, nil
+		}
+		if !os.IsNotExist(err) || attempt == 2 {
+			return nil, nil, errors.Wrap(err, "opening blob")
+		}
+	}
+}
+
+// keyIndex is the sorted set of the keys of a bucket. Metadata files are named after the hash
+// of their key, so without it listing a page of keys would read the metadata of every object.
+// It is loaded from the metadata files the first time the bucket is listed, and kept up to
+// date by commits and deletions while they hold the lock of their key, so that the updates
+// of a key are applied in order.
+type keyIndex struct {
+	mu     sync.Mutex
+	loaded bool
+	keys   []string
+}
+
+func (s *Service) keyIndex(bucket string) *keyIndex {
+	s.keyIndexesMu.Lock()
+	defer s.keyIndexesMu.Unlock()
+	if s.keyIndexes == nil {
+		s.keyIndexes = map[string]*keyIndex{}
+	}
+	index, ok := s.keyIndexes[bucket]
+	if !ok {
+		index = &keyIndex{}
+		s.keyIndexes[bucket] = index
+	}
+	return index
+}
+
+// add records that the metadata file of key was written.
+func (x *keyIndex) add(key string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if !x.loaded {
+		// Loading the index reads the metadata file.
+		return
+	}
+	i := sort.SearchStrings(x.keys, key)
+	if i < len(x.keys) && x.keys[i] == key {
+		return
+	}
+	x.keys = append(x.keys, "")
+	copy(x.keys[i+1:], x.keys[i:])
+	x.keys[i] = key
+}
+
+// remove records that the metadata file of key was removed.
+func (x *keyIndex) remove(key string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	i := sort.SearchStrings(x.keys, key)
+	if i < len(x.keys) && x.keys[i] == key {
+		x.keys = append(x.keys[:i], x.keys[i+1:]...)
+	}
+}
+
+// skipPrefix returns the index of the first key after i that doesn't start with prefix. The
+// key at i must not be before the first key that starts with prefix.
+func (x *keyIndex) skipPrefix(i int, prefix string) int {
+	return i + sort.Search(len(x.keys)-i, func(j int) bool { return !strings.HasPrefix(x.keys[i+j], prefix) })
+}
+
+// listEntry is a key or a common prefix of a page of listed objects.
+type listEntry struct {
+	name     string
+	isPrefix bool
+}
+
+// listPage returns the first limit keys of bucket that start with prefix and come after
+// marker, and whether there are more. With a delimiter, the keys that contain it after the
+// prefix are grouped under a single common prefix that ends with it. A marker that ends with
+// the delimiter is a common prefix returned by a previous page, whose keys are skipped.
+func (s *Service) listPage(bucket, prefix, delimiter, marker string, limit int) (_ []listEntry, truncated bool, _ error) {
+	x := s.keyIndex(bucket)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if !x.loaded {
+		objects, err := s.listObjects(bucket, "")
+		if err != nil {
+			return nil, false, errors.Wrap(err, "loading key index")
+		}
+		x.keys = make([]string, 0, len(objects))
+		for _, object := range objects {
+			x.keys = append(x.keys, object.Key)
+		}
+		x.loaded = true
+	}
+
+	start := prefix
+	if marker > start {
+		start = marker
+	}
+	i := sort.SearchStrings(x.keys, start)
+	if i < len(x.keys) && x.keys[i] == marker {
+		i++
+	}
+	if delimiter != "" && strings.HasSuffix(marker, delimiter) {
+		i = x.skipPrefix(i, marker)
+	}
+
+	var entries []listEntry
+	for i < len(x.keys) && strings.HasPrefix(x.keys[i], prefix) {
+		if len(entries) == limit {
+			return entries, true, nil
+		}
+		key := x.keys[i]
+		if delimiter != "" {
+			if j := strings.Index(key[len(prefix):], delimiter); j >= 0 {
+				name := key[:len(prefix)+j+len(delimiter)]
+				entries = append(entries, listEntry{name: name, isPrefix: true})
+				i = x.skipPrefix(i, name)
+				continue
+			}
+		}
+		entries = append(entries, listEntry{name: key})
+		i++
+	}
+	return entries, false, nil
+}
+
+// listObjects returns the metadata of the objects of bucket with the given prefix, ordered by
+// key. It reads the metadata of every object.
+func (s *Service) listObjects(bucket, prefix string) ([]*objectMeta, error) {
+	var objects []*objectMeta
+	root := filepath.Join(s.bucketDir(bucket), "objects")
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Deleted while listing.
+				return nil
+			}
+			return err
+		}
+		var meta objectMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return errors.Wrapf(err, "decoding %s", path)
+		}
+		if strings.HasPrefix(meta.Key, prefix) {
+			objects = append(objects, &meta)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// verifyingReader reads the content of an object and fails at the end of the content if its
+// SHA-256 digest does not match the one recorded when the object was written.
+type verifyingReader struct {
+	r    io.Reader
+	hash hash.Hash
+	want string
+}
+
+var errChecksumMismatch = errors.New("object content does not match its checksum")
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	n, err := v.r.Read(p)
+	v.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(v.hash.Sum(nil)) != v.want {
+		return n, errChecksumMismatch
+	}
+	return n, err
+}
@@ -31,9 +31,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
 	"github.com/sourcegraph/sourcegraph/internal/version"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-var dataDir = env.Get("BLOBSTORE_DATA_DIR", "/data", "directory to store blobstore buckets and objects.")
+var (
+	dataDir         = env.Get("BLOBSTORE_DATA_DIR", "/data", "directory to store blobstore buckets and objects.")
+	lifecycle       = env.Get("BLOBSTORE_LIFECYCLE", "", `JSON object mapping bucket names to lifecycle rules, e.g. {"lsif-uploads": {"rules": [{"prefix": "upload-", "expireAfterDays": 7}], "maxTotalBytes": 10737418240}}.`)
+	janitorInterval = env.MustGetDuration("BLOBSTORE_JANITOR_INTERVAL", 10*time.Minute, "interval between runs of the janitor enforcing bucket lifecycle rules.")
+)
 
 const port = "9000"
 
@@ -69,12 +74,22 @@ func run(logger log.Logger) error {
 	close(ready)
 	go debugserver.NewServerRoutine(ready).Start()
 
+	bucketLifecycle, err := blobstore.ParseLifecycle(lifecycle)
+	if err != nil {
+		return errors.Wrap(err, "BLOBSTORE_LIFECYCLE")
+	}
+
 	service := &blobstore.Service{
 		DataDir:        dataDir,
 		Log:            logger,
 		ObservationCtx: observation.NewContext(logger),
+		Lifecycle:      bucketLifecycle,
 	}
 
+	janitor := service.NewJanitor(janitorInterval)
+	go janitor.Start()
+	defer janitor.Stop()
+
 	// Set up handler middleware
 	handler := actor.HTTPMiddleware(logger, service)
 	handler = trace.HTTPMiddleware(logger, handler, conf.DefaultClient())
@@ -3,11 +3,20 @@ package backend
 import (
 	"bytes"
 	"context"
+	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 
 	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
 )
@@ -17,17 +26,70 @@ import (
 type OwnService interface {
 	// OwnersFile returns a CODEOWNERS file from a given repository at given commit ID.
 	// In the case the file cannot be found, `nil` `*codeownerspb.File` and `nil` `error` is returned.
+	// The file is parsed leniently: Lines that cannot be parsed do not fail
+	// ownership resolution, but are reported as diagnostics on the returned file.
 	OwnersFile(context.Context, api.RepoName, api.CommitID) (*codeownerspb.File, error)
+
+	// ResolveOwners returns the owners of given path as per given CODEOWNERS file,
+	// each mapped to Sourcegraph users or organizations if possible.
+	// If no rule within the file matches the path, nil is returned.
+	ResolveOwners(ctx context.Context, file *codeownerspb.File, path string) ([]*ResolvedOwner, error)
+
+	// Resolve maps given owners to Sourcegraph users or organizations if possible.
+	// Owners are resolved in bulk, and returned in the same order without a Rule.
+	Resolve(ctx context.Context, owners []*codeownerspb.Owner) ([]*ResolvedOwner, error)
 }
 
 var _ OwnService = ownService{}
 
-func NewOwnService(g gitserver.Client) OwnService {
-	return ownService{gitserverClient: g}
+func NewOwnService(g gitserver.Client, db database.DB) OwnService {
+	return ownService{gitserverClient: g, db: db}
 }
 
 type ownService struct {
 	gitserverClient gitserver.Client
+	db              database.DB
+}
+
+// OwnerResolution tells how an owner from a CODEOWNERS file maps
+// to Sourcegraph users and organizations.
+type OwnerResolution int
+
+const (
+	// OwnerUnresolved means no user or organization matches the owner.
+	OwnerUnresolved OwnerResolution = iota
+	// OwnerResolved means exactly one user or organization matches the owner.
+	OwnerResolved
+	// OwnerAmbiguous means more than one user or organization matches the owner,
+	// for instance because a handle is a GitHub login of one user
+	// and a GitLab username of another.
+	OwnerAmbiguous
+)
+
+func (r OwnerResolution) String() string {
+	switch r {
+	case OwnerResolved:
+		return "resolved"
+	case OwnerAmbiguous:
+		return "ambiguous"
+	default:
+		return "unresolved"
+	}
+}
+
+// ResolvedOwner is an owner as it appears in a CODEOWNERS file,
+// along with Sourcegraph users and organization it refers to.
+type ResolvedOwner struct {
+	// Owner is the owner as given in the CODEOWNERS file.
+	Owner *codeownerspb.Owner
+	// Rule is the CODEOWNERS rule that the owner comes from.
+	Rule *codeownerspb.Rule
+	// Resolution tells whether Users and Org identify the owner unambiguously.
+	Resolution OwnerResolution
+	// Users lists all the users matching the owner.
+	Users []*types.User
+	// Org is the organization matching the owner, if any.
+	Org *types.Org
 }
 
 // codeownersLocations contains the locations where CODEOWNERS file
@@ -54,8 +116,267 @@ func (s ownService) OwnersFile(ctx context.Context, repoName api.RepoName, commi
 			path,
 		)
 		if content != nil && err == nil {
-			return codeowners.Parse(bytes.NewReader(content))
+			return codeowners.ParseLenient(bytes.NewReader(content))
 		}
 	}
 	return nil, nil
 }
+
+// ResolveOwners finds the rule matching given path, and resolves its owners
+// as Resolve does.
+func (s ownService) ResolveOwners(ctx context.Context, file *codeownerspb.File, path string) ([]*ResolvedOwner, error) {
+	rule := file.FindRule(path)
+	if rule == nil {
+		return nil, nil
+	}
+	owners, err := s.Resolve(ctx, rule.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	for _, owner := range owners {
+		owner.Rule = rule
+	}
+	return owners, nil
+}
+
+// Resolve resolves owners in bulk:
+//   - E-mails are matched against verified e-mails of users.
+//   - Handles are matched against Sourcegraph usernames, logins of external
+//     accounts from GitHub, GitLab, Bitbucket Server and Bitbucket Cloud,
+//     and organization names. For nested handles like `org/team`, the last
+//     part is matched against organization names as well.
+func (s ownService) Resolve(ctx context.Context, owners []*codeownerspb.Owner) ([]*ResolvedOwner, error) {
+	var emails, handles []string
+	for _, o := range owners {
+		if email := o.GetEmail(); email != "" {
+			emails = append(emails, email)
+		}
+		if handle := o.GetHandle(); handle != "" {
+			handles = append(handles, handle)
+		}
+	}
+	userIDsByEmail, err := s.userIDsByEmail(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	userIDsByHandle, err := s.userIDsByHandle(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs []int32
+	for _, ids := range userIDsByEmail {
+		userIDs = append(userIDs, ids...)
+	}
+	for _, ids := range userIDsByHandle {
+		userIDs = append(userIDs, ids...)
+	}
+	usersByID, err := s.usersByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	orgsByHandle, err := s.orgsByHandle(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]*ResolvedOwner, 0, len(owners))
+	for _, o := range owners {
+		owner := &ResolvedOwner{Owner: o}
+		var ids []int32
+		if email := o.GetEmail(); email != "" {
+			ids = append(ids, userIDsByEmail[strings.ToLower(email)]...)
+		}
+		if handle := o.GetHandle(); handle != "" {
+			ids = append(ids, userIDsByHandle[strings.ToLower(handle)]...)
+			owner.Org = orgsByHandle[handle]
+		}
+		seen := map[int32]struct{}{}
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			// Users missing from usersByID have been deleted.
+			if user, ok := usersByID[id]; ok {
+				owner.Users = append(owner.Users, user)
+			}
+		}
+		matches := len(owner.Users)
+		if owner.Org != nil {
+			matches++
+		}
+		switch {
+		case matches == 1:
+			owner.Resolution = OwnerResolved
+		case matches > 1:
+			owner.Resolution = OwnerAmbiguous
+		}
+		resolved = append(resolved, owner)
+	}
+	return resolved, nil
+}
+
+// codeHostLoginServiceTypes are the types of external accounts,
+// which logins are matched against CODEOWNERS handles.
+var codeHostLoginServiceTypes = []string{
+	extsvc.TypeGitHub,
+	extsvc.TypeGitLab,
+	extsvc.TypeBitbucketServer,
+	extsvc.TypeBitbucketCloud,
+}
+
+// userIDsByEmail returns IDs of users with given verified e-mails,
+// keyed by lowercase e-mail.
+func (s ownService) userIDsByEmail(ctx context.Context, emails []string) (map[string][]int32, error) {
+	ids := map[string][]int32{}
+	if len(emails) == 0 {
+		return ids, nil
+	}
+	verified, err := s.db.UserEmails().GetVerifiedEmails(ctx, emails...)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range verified {
+		email := strings.ToLower(e.Email)
+		ids[email] = append(ids[email], e.UserID)
+	}
+	return ids, nil
+}
+
+// userIDsByHandle returns IDs of users whose username or code host login
+// matches one of given handles, keyed by lowercase handle.
+func (s ownService) userIDsByHandle(ctx context.Context, handles []string) (map[string][]int32, error) {
+	ids := map[string][]int32{}
+	if len(handles) == 0 {
+		return ids, nil
+	}
+	users, err := s.db.Users().GetByUsernames(ctx, handles...)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		username := strings.ToLower(u.Username)
+		ids[username] = append(ids[username], u.ID)
+	}
+
+	wanted := make(map[string]struct{}, len(handles))
+	for _, h := range handles {
+		wanted[strings.ToLower(h)] = struct{}{}
+	}
+	for _, serviceType := range codeHostLoginServiceTypes {
+		accounts, err := s.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+			ServiceType:    serviceType,
+			Logins:         handles,
+			ExcludeExpired: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range accounts {
+			login, err := externalAccountLogin(ctx, account)
+			if err != nil {
+				return nil, err
+			}
+			login = strings.ToLower(login)
+			if _, ok := wanted[login]; !ok {
+				continue
+			}
+			ids[login] = append(ids[login], account.UserID)
+		}
+	}
+	return ids, nil
+}
+
+// usersByID loads the users with given IDs at once.
+func (s ownService) usersByID(ctx context.Context, ids []int32) (map[int32]*types.User, error) {
+	users := map[int32]*types.User{}
+	if len(ids) == 0 {
+		return users, nil
+	}
+	list, err := s.db.Users().List(ctx, &database.UsersListOptions{UserIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range list {
+		users[u.ID] = u
+	}
+	return users, nil
+}
+
+// orgsByHandle returns the organizations named like given handles, keyed by handle.
+// Nested handles like `org/team` are looked up by the name of the team
+// if no organization is named like the whole handle.
+func (s ownService) orgsByHandle(ctx context.Context, handles []string) (map[string]*types.Org, error) {
+	orgs := map[string]*types.Org{}
+	if len(handles) == 0 {
+		return orgs, nil
+	}
+	var names []string
+	for _, handle := range handles {
+		names = append(names, orgNamesForHandle(handle)...)
+	}
+	list, err := s.db.Orgs().List(ctx, &database.OrgsListOptions{Names: names})
+	if err != nil {
+		return nil, err
+	}
+	// Organization names are case-insensitive.
+	byName := make(map[string]*types.Org, len(list))
+	for _, org := range list {
+		byName[strings.ToLower(org.Name)] = org
+	}
+	for _, handle := range handles {
+		for _, name := range orgNamesForHandle(handle) {
+			if org, ok := byName[strings.ToLower(name)]; ok {
+				orgs[handle] = org
+				break
+			}
+		}
+	}
+	return orgs, nil
+}
+
+// orgNamesForHandle returns the organization names given handle may refer to,
+// in order of preference.
+func orgNamesForHandle(handle string) []string {
+	names := []string{handle}
+	if i := strings.LastIndex(handle, "/"); i >= 0 && i < len(handle)-1 {
+		names = append(names, handle[i+1:])
+	}
+	return names
+}
+
+// externalAccountLogin returns the login of given code host account,
+// or an empty string if the account does not carry one.
+func externalAccountLogin(ctx context.Context, account *extsvc.Account) (string, error) {
+	if account.Data == nil {
+		return "", nil
+	}
+	switch account.ServiceType {
+	case extsvc.TypeGitHub:
+		user, _, err := github.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil || user == nil {
+			return "", err
+		}
+		return user.GetLogin(), nil
+	case extsvc.TypeGitLab:
+		user, _, err := gitlab.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil || user == nil {
+			return "", err
+		}
+		return user.Username, nil
+	case extsvc.TypeBitbucketServer:
+		user, err := encryption.DecryptJSON[bitbucketserver.User](ctx, account.Data)
+		if err != nil {
+			return "", err
+		}
+		return user.Slug, nil
+	case extsvc.TypeBitbucketCloud:
+		user, err := encryption.DecryptJSON[bitbucketcloud.Account](ctx, account.Data)
+		if err != nil {
+			return "", err
+		}
+		return user.Username, nil
+	}
+	return "", nil
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"testing"
 
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
@@ -50,7 +54,7 @@ func TestOwnersServesFilesAtVariousLocations(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			git := gitserver.NewMockClient()
 			git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
-			got, err := backend.NewOwnService(git).OwnersFile(context.Background(), "repo", "SHA")
+			got, err := backend.NewOwnService(git, database.NewMockDB()).OwnersFile(context.Background(), "repo", "SHA")
 			require.NoError(t, err)
 			assert.Equal(t, codeownersText, got.Repr())
 		})
@@ -71,7 +75,150 @@ func TestOwnersCannotFindFile(t *testing.T) {
 	}
 	git := gitserver.NewMockClient()
 	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
-	got, err := backend.NewOwnService(git).OwnersFile(context.Background(), "repo", "SHA")
+	got, err := backend.NewOwnService(git, database.NewMockDB()).OwnersFile(context.Background(), "repo", "SHA")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestOwnersFileReportsDiagnostics(t *testing.T) {
+	repo := repoFiles{
+		{"repo", "SHA", "CODEOWNERS"}: "README.md @readme-owner\n/src//main.go @main-owner\n",
+	}
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
+	got, err := backend.NewOwnService(git, database.NewMockDB()).OwnersFile(context.Background(), "repo", "SHA")
+	require.NoError(t, err)
+	assert.Equal(t, "README.md @readme-owner\n", got.Repr())
+	require.Len(t, got.GetDiagnostic(), 1)
+	assert.Equal(t, codeownerspb.Diagnostic_INVALID_PATTERN, got.GetDiagnostic()[0].GetKind())
+	assert.Equal(t, int32(2), got.GetDiagnostic()[0].GetLineNumber())
+}
+
+func TestResolveOwners(t *testing.T) {
+	users := map[int32]*types.User{
+		1: {ID: 1, Username: "alice"},
+		2: {ID: 2, Username: "bob"},
+		3: {ID: 3, Username: "carol"},
+	}
+	usersStore := database.NewMockUserStore()
+	usersStore.ListFunc.SetDefaultHook(func(_ context.Context, opts *database.UsersListOptions) ([]*types.User, error) {
+		var list []*types.User
+		for _, id := range opts.UserIDs {
+			if u, ok := users[id]; ok {
+				list = append(list, u)
+			}
+		}
+		return list, nil
+	})
+	usersStore.GetByUsernamesFunc.SetDefaultHook(func(_ context.Context, usernames ...string) ([]*types.User, error) {
+		var list []*types.User
+		for _, username := range usernames {
+			for _, u := range users {
+				if u.Username == username {
+					list = append(list, u)
+				}
+			}
+		}
+		return list, nil
+	})
+	emailsStore := database.NewMockUserEmailsStore()
+	emailsStore.GetVerifiedEmailsFunc.SetDefaultHook(func(_ context.Context, emails ...string) ([]*database.UserEmail, error) {
+		var verified []*database.UserEmail
+		for _, e := range emails {
+			if e == "bob@example.com" {
+				verified = append(verified, &database.UserEmail{UserID: 2, Email: e})
+			}
+		}
+		return verified, nil
+	})
+	accountsStore := database.NewMockUserExternalAccountsStore()
+	accountsStore.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		// Only accounts with the handles of the rule as logins are needed.
+		assert.ElementsMatch(t, []string{"alice-gh", "bobby", "bob", "octo-org/octocats", "nobody"}, opts.Logins)
+		switch opts.ServiceType {
+		case extsvc.TypeGitHub:
+			return []*extsvc.Account{
+				{
+					UserID:      1,
+					AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitHub},
+					AccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData([]byte(`{"login":"Alice-GH"}`))},
+				},
+				{
+					UserID:      3,
+					AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitHub},
+					AccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData([]byte(`{"login":"bob"}`))},
+				},
+			}, nil
+		case extsvc.TypeGitLab:
+			return []*extsvc.Account{
+				{
+					UserID:      2,
+					AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitLab},
+					AccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData([]byte(`{"username":"bobby"}`))},
+				},
+			}, nil
+		}
+		return nil, nil
+	})
+	orgsStore := database.NewMockOrgStore()
+	orgsStore.ListFunc.SetDefaultHook(func(_ context.Context, opts *database.OrgsListOptions) ([]*types.Org, error) {
+		// Nested handles are also looked up by the name of the team.
+		assert.ElementsMatch(t, []string{"alice-gh", "bobby", "bob", "octo-org/octocats", "octocats", "nobody"}, opts.Names)
+		return []*types.Org{{ID: 10, Name: "OctoCats"}}, nil
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(usersStore)
+	db.UserEmailsFunc.SetDefaultReturn(emailsStore)
+	db.UserExternalAccountsFunc.SetDefaultReturn(accountsStore)
+	db.OrgsFunc.SetDefaultReturn(orgsStore)
+
+	rule := &codeownerspb.Rule{
+		Pattern: "/src/",
+		Owner: []*codeownerspb.Owner{
+			{Handle: "alice-gh"},
+			{Email: "bob@example.com"},
+			{Handle: "bobby"},
+			{Handle: "bob"},
+			{Handle: "octo-org/octocats"},
+			{Handle: "nobody"},
+		},
+	}
+	file := &codeownerspb.File{Rule: []*codeownerspb.Rule{rule}}
+	got, err := backend.NewOwnService(gitserver.NewMockClient(), db).ResolveOwners(context.Background(), file, "/src/main.go")
+	require.NoError(t, err)
+	// Users and organizations are loaded at once.
+	mockassert.CalledOnce(t, usersStore.ListFunc)
+	mockassert.CalledOnce(t, orgsStore.ListFunc)
+
+	type resolution struct {
+		Resolution string
+		Users      []string
+		Org        string
+	}
+	var gotResolutions []resolution
+	for _, o := range got {
+		assert.Same(t, rule, o.Rule)
+		r := resolution{Resolution: o.Resolution.String()}
+		for _, u := range o.Users {
+			r.Users = append(r.Users, u.Username)
+		}
+		if o.Org != nil {
+			r.Org = o.Org.Name
+		}
+		gotResolutions = append(gotResolutions, r)
+	}
+	want := []resolution{
+		{Resolution: "resolved", Users: []string{"alice"}},
+		{Resolution: "resolved", Users: []string{"bob"}},
+		{Resolution: "resolved", Users: []string{"bob"}},
+		// Username of bob, and GitHub login of carol.
+		{Resolution: "ambiguous", Users: []string{"bob", "carol"}},
+		{Resolution: "resolved", Org: "OctoCats"},
+		{Resolution: "unresolved"},
+	}
+	assert.Equal(t, want, gotResolutions)
+
+	got, err = backend.NewOwnService(gitserver.NewMockClient(), db).ResolveOwners(context.Background(), file, "/docs/index.md")
 	require.NoError(t, err)
 	assert.Nil(t, got)
 }
@@ -23,6 +23,8 @@ type CodeMonitorsResolver interface {
 	TriggerTestEmailAction(ctx context.Context, args *TriggerTestEmailActionArgs) (*EmptyResponse, error)
 	TriggerTestWebhookAction(ctx context.Context, args *TriggerTestWebhookActionArgs) (*EmptyResponse, error)
 	TriggerTestSlackWebhookAction(ctx context.Context, args *TriggerTestSlackWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestTeamsWebhookAction(ctx context.Context, args *TriggerTestTeamsWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestChatWebhookAction(ctx context.Context, args *TriggerTestChatWebhookActionArgs) (*EmptyResponse, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -81,6 +83,8 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorTeamsWebhook() (MonitorTeamsWebhookResolver, bool)
+	ToMonitorChatWebhook() (MonitorChatWebhookResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -89,6 +93,7 @@ type MonitorEmailResolver interface {
 	IncludeResults() bool
 	Priority() string
 	Header() string
+	DigestInterval() *string
 	Recipients(ctx context.Context, args *ListRecipientsArgs) (MonitorActionEmailRecipientsConnectionResolver, error)
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
@@ -97,7 +102,10 @@ type MonitorWebhookResolver interface {
 	ID() graphql.ID
 	Enabled() bool
 	IncludeResults() bool
-	URL() string
+	URL(ctx context.Context) (string, error)
+	PayloadTemplate() *string
+	HeaderNames(ctx context.Context) ([]string, error)
+	HasSecret() bool
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
@@ -105,7 +113,25 @@ type MonitorSlackWebhookResolver interface {
 	ID() graphql.ID
 	Enabled() bool
 	IncludeResults() bool
-	URL() string
+	URL(ctx context.Context) (string, error)
+	DigestInterval() *string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorTeamsWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	URL(ctx context.Context) (string, error)
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorChatWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	Service() string
+	URL(ctx context.Context) (string, error)
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
@@ -166,6 +192,8 @@ type CreateActionArgs struct {
 	Email        *CreateActionEmailArgs
 	Webhook      *CreateActionWebhookArgs
 	SlackWebhook *CreateActionSlackWebhookArgs
+	TeamsWebhook *CreateActionTeamsWebhookArgs
+	ChatWebhook  *CreateActionChatWebhookArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -174,20 +202,43 @@ type CreateActionEmailArgs struct {
 	Priority       string
 	Recipients     []graphql.ID
 	Header         string
+	DigestInterval *string
 }
 
 type CreateActionWebhookArgs struct {
+	Enabled         bool
+	IncludeResults  bool
+	URL             string
+	PayloadTemplate *string
+	Headers         *[]MonitorWebhookHeaderInput
+	Secret          *string
+}
+
+type MonitorWebhookHeaderInput struct {
+	Name  string
+	Value string
+}
+
+type CreateActionSlackWebhookArgs struct {
 	Enabled        bool
 	IncludeResults bool
 	URL            string
+	DigestInterval *string
 }
 
-type CreateActionSlackWebhookArgs struct {
+type CreateActionTeamsWebhookArgs struct {
 	Enabled        bool
 	IncludeResults bool
 	URL            string
 }
 
+type CreateActionChatWebhookArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	Service        string
+	URL            string
+}
+
 type ToggleCodeMonitorArgs struct {
 	Id      graphql.ID
 	Enabled bool
@@ -219,6 +270,18 @@ type TriggerTestSlackWebhookActionArgs struct {
 	SlackWebhook *CreateActionSlackWebhookArgs
 }
 
+type TriggerTestTeamsWebhookActionArgs struct {
+	Namespace    graphql.ID
+	Description  string
+	TeamsWebhook *CreateActionTeamsWebhookArgs
+}
+
+type TriggerTestChatWebhookActionArgs struct {
+	Namespace   graphql.ID
+	Description string
+	ChatWebhook *CreateActionChatWebhookArgs
+}
+
 type CreateMonitorArgs struct {
 	Namespace   graphql.ID
 	Description string
@@ -240,10 +303,22 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionTeamsWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionTeamsWebhookArgs
+}
+
+type EditActionChatWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionChatWebhookArgs
+}
+
 type EditActionArgs struct {
 	Email        *EditActionEmailArgs
 	Webhook      *EditActionWebhookArgs
 	SlackWebhook *EditActionSlackWebhookArgs
+	TeamsWebhook *EditActionTeamsWebhookArgs
+	ChatWebhook  *EditActionChatWebhookArgs
 }
 
 type EditTriggerArgs struct {
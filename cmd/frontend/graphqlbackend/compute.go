@@ -10,6 +10,7 @@ type ComputeArgs struct {
 
 type ComputeResolver interface {
 	Compute(ctx context.Context, args *ComputeArgs) ([]ComputeResultResolver, error)
+	ComputeReplaceBatchSpec(ctx context.Context, args *ComputeArgs) (string, error)
 }
 
 type ComputeResultResolver interface {
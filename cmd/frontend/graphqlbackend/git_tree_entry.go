@@ -11,11 +11,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/grafana/regexp"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/externallink"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/highlight"
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/cloneurls"
@@ -23,6 +26,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -326,6 +331,184 @@ func (r *GitTreeEntryResolver) SymbolInfo(ctx context.Context, args *symbolInfoA
 	return &symbolInfoResolver{symbolInfo: result}, nil
 }
 
+// maxSymbolReferenceCandidates limits the number of word search matches that squirrel checks when
+// finding references.
+const maxSymbolReferenceCandidates = 1000
+
+// symbolReferencesTimeout bounds the time spent searching for and checking reference candidates.
+const symbolReferencesTimeout = 30 * time.Second
+
+func (r *GitTreeEntryResolver) SymbolReferences(ctx context.Context, args *symbolInfoArgs) (*symbolReferencesResolver, error) {
+	if args == nil {
+		return nil, errors.New("expected arguments to symbolReferences")
+	}
+
+	repo, err := r.commit.repoResolver.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := r.Content(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := identifierAt(content, int(args.Line), int(args.Character))
+	if name == "" {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, symbolReferencesTimeout)
+	defer cancel()
+
+	start := types.RepoCommitPathPoint{
+		RepoCommitPath: types.RepoCommitPath{
+			Repo:   string(repo.Name),
+			Commit: string(r.commit.oid),
+			Path:   r.Path(),
+		},
+		Point: types.Point{
+			Row:    int(args.Line),
+			Column: int(args.Character),
+		},
+	}
+
+	// Find every occurrence of the name in the repository at this commit, then let squirrel keep
+	// the ones that resolve to the same definition.
+	candidates := []types.RepoCommitPathRange{}
+	onMatches := func(fms []*protocol.FileMatch) {
+		for _, fm := range fms {
+			for _, cm := range fm.ChunkMatches {
+				for _, rr := range cm.Ranges {
+					if len(candidates) >= maxSymbolReferenceCandidates {
+						return
+					}
+					// Searcher columns count runes, but squirrel columns count bytes.
+					before := cm.Content[:rr.Start.Offset-cm.ContentStart.Offset]
+					column := len(before) - (strings.LastIndexByte(before, '\n') + 1)
+					candidates = append(candidates, types.RepoCommitPathRange{
+						RepoCommitPath: types.RepoCommitPath{
+							Repo:   start.Repo,
+							Commit: start.Commit,
+							Path:   fm.Path,
+						},
+						Range: types.Range{
+							Row:    int(rr.Start.Line),
+							Column: column,
+							Length: int(rr.End.Offset - rr.Start.Offset),
+						},
+					})
+				}
+			}
+		}
+	}
+	_, err = searcher.Search(
+		ctx,
+		search.SearcherURLs(),
+		repo.Name,
+		repo.ID,
+		"", // not using zoekt, don't need branch
+		api.CommitID(r.commit.oid),
+		false, // not using zoekt, don't need indexing
+		&search.TextPatternInfo{
+			Pattern:               `\b` + regexp.QuoteMeta(name) + `\b`,
+			IsRegExp:              true,
+			IsCaseSensitive:       true,
+			FileMatchLimit:        maxSymbolReferenceCandidates,
+			PatternMatchesContent: true,
+		},
+		symbolReferencesTimeout,
+		search.Features{}, // not using any search features
+		onMatches,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Searcher doesn't check sub-repo permissions, so drop the candidates in files the user can't
+	// read before squirrel looks at them.
+	candidates, err = filterSymbolReferenceCandidates(ctx, repo.Name, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := symbols.DefaultClient.SymbolReferences(ctx, types.SymbolReferencesArgs{
+		RepoCommitPathPoint: start,
+		Candidates:          candidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	if result.Definition.Repo == string(repo.Name) {
+		ok, err := authz.FilterActorPath(ctx, authz.DefaultSubRepoPermsChecker, actor.FromContext(ctx), repo.Name, result.Definition.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	return &symbolReferencesResolver{symbolReferences: result}, nil
+}
+
+// filterSymbolReferenceCandidates returns the candidates in files of repo that the actor can read.
+func filterSymbolReferenceCandidates(ctx context.Context, repo api.RepoName, candidates []types.RepoCommitPathRange) ([]types.RepoCommitPathRange, error) {
+	if !authz.SubRepoEnabled(authz.DefaultSubRepoPermsChecker) {
+		return candidates, nil
+	}
+
+	var paths []string
+	seen := map[string]struct{}{}
+	for _, c := range candidates {
+		if _, ok := seen[c.Path]; !ok {
+			seen[c.Path] = struct{}{}
+			paths = append(paths, c.Path)
+		}
+	}
+	allowedPaths, err := authz.FilterActorPaths(ctx, authz.DefaultSubRepoPermsChecker, actor.FromContext(ctx), repo, paths)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[string]struct{}, len(allowedPaths))
+	for _, p := range allowedPaths {
+		allowed[p] = struct{}{}
+	}
+
+	filtered := candidates[:0]
+	for _, c := range candidates {
+		if _, ok := allowed[c.Path]; ok {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+// identifierAt returns the identifier that spans the given byte offset in the given line.
+func identifierAt(content string, line, character int) string {
+	lines := strings.Split(content, "\n")
+	if line < 0 || line >= len(lines) || character < 0 || character > len(lines[line]) {
+		return ""
+	}
+	text := lines[line]
+
+	isIdentByte := func(b byte) bool {
+		return b == '_' || b >= 0x80 || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+	}
+	start, end := character, character
+	for start > 0 && isIdentByte(text[start-1]) {
+		start--
+	}
+	for end < len(text) && isIdentByte(text[end]) {
+		end++
+	}
+	return text[start:end]
+}
+
 func (r *GitTreeEntryResolver) LFS(ctx context.Context) (*lfsResolver, error) {
 	content, err := r.Content(ctx)
 	if err != nil {
@@ -349,6 +532,24 @@ func (r *symbolInfoResolver) Hover(ctx context.Context) (*string, error) {
 	return r.symbolInfo.Hover, nil
 }
 
+type symbolReferencesResolver struct{ symbolReferences *types.SymbolReferences }
+
+func (r *symbolReferencesResolver) Definition(ctx context.Context) (*symbolLocationResolver, error) {
+	return &symbolLocationResolver{location: r.symbolReferences.Definition}, nil
+}
+
+func (r *symbolReferencesResolver) References(ctx context.Context) ([]*symbolLocationResolver, error) {
+	resolvers := make([]*symbolLocationResolver, 0, len(r.symbolReferences.References))
+	for _, ref := range r.symbolReferences.References {
+		rnge := ref.Range
+		resolvers = append(resolvers, &symbolLocationResolver{location: types.RepoCommitPathMaybeRange{
+			RepoCommitPath: ref.RepoCommitPath,
+			Range:          &rnge,
+		}})
+	}
+	return resolvers, nil
+}
+
 type symbolLocationResolver struct {
 	location types.RepoCommitPathMaybeRange
 }
@@ -2,11 +2,14 @@ package graphqlbackend
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -66,3 +69,65 @@ func TestGitTreeEntry_Content(t *testing.T) {
 		t.Fatalf("wrong file size, want=%d have=%d", want, have)
 	}
 }
+
+func TestIdentifierAt(t *testing.T) {
+	content := "package main\n\nfunc fooBar(x int) {}\n"
+	tests := []struct {
+		line      int
+		character int
+		want      string
+	}{
+		{line: 2, character: 5, want: "fooBar"},
+		{line: 2, character: 8, want: "fooBar"},
+		{line: 2, character: 11, want: "fooBar"},
+		{line: 2, character: 12, want: "x"},
+		{line: 2, character: 19, want: ""},
+		{line: 1, character: 0, want: ""},
+		{line: 2, character: 100, want: ""},
+		{line: 10, character: 0, want: ""},
+	}
+	for _, test := range tests {
+		if got := identifierAt(content, test.line, test.character); got != test.want {
+			t.Errorf("identifierAt(%d, %d) = %q, want %q", test.line, test.character, got, test.want)
+		}
+	}
+}
+
+func TestFilterSymbolReferenceCandidates(t *testing.T) {
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.FilePermissionsFuncFunc.SetDefaultHook(func(context.Context, int32, api.RepoName) (authz.FilePermissionFunc, error) {
+		return func(path string) (authz.Perms, error) {
+			if strings.HasPrefix(path, "secret/") {
+				return authz.None, nil
+			}
+			return authz.Read, nil
+		}, nil
+	})
+	old := authz.DefaultSubRepoPermsChecker
+	t.Cleanup(func() { authz.DefaultSubRepoPermsChecker = old })
+	authz.DefaultSubRepoPermsChecker = checker
+
+	candidate := func(path string, row int) types.RepoCommitPathRange {
+		return types.RepoCommitPathRange{
+			RepoCommitPath: types.RepoCommitPath{Repo: "repo", Commit: "deadbeef", Path: path},
+			Range:          types.Range{Row: row},
+		}
+	}
+	candidates := []types.RepoCommitPathRange{
+		candidate("main.go", 1),
+		candidate("secret/key.go", 2),
+		candidate("main.go", 3),
+	}
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	got, err := filterSymbolReferenceCandidates(ctx, "repo", candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.RepoCommitPathRange{candidate("main.go", 1), candidate("main.go", 3)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected candidates (-want +got):\n%s", diff)
+	}
+	mockrequire.CalledOnce(t, checker.FilePermissionsFuncFunc)
+}
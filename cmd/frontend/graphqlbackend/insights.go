@@ -40,6 +40,11 @@ type InsightsResolver interface {
 	DeleteInsightView(ctx context.Context, args *DeleteInsightViewArgs) (*EmptyResponse, error)
 	SaveInsightAsNewView(ctx context.Context, args SaveInsightAsNewViewArgs) (InsightViewPayloadResolver, error)
 
+	AddInsightAnnotation(ctx context.Context, args *AddInsightAnnotationArgs) (InsightAnnotationResolver, error)
+	DeleteInsightAnnotation(ctx context.Context, args *DeleteInsightAnnotationArgs) (*EmptyResponse, error)
+	AddInsightAlert(ctx context.Context, args *AddInsightAlertArgs) (InsightAlertResolver, error)
+	DeleteInsightAlert(ctx context.Context, args *DeleteInsightAlertArgs) (*EmptyResponse, error)
+
 	// Admin Management
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
@@ -64,6 +69,7 @@ type SearchSeriesPreviewInput struct {
 	Label                      string
 	GeneratedFromCaptureGroups bool
 	GroupBy                    *string
+	Aggregation                *string
 }
 
 type SearchInsightLivePreviewInput struct {
@@ -73,6 +79,7 @@ type SearchInsightLivePreviewInput struct {
 	TimeScope                  TimeScopeInput
 	GeneratedFromCaptureGroups bool
 	GroupBy                    *string
+	Aggregation                *string
 }
 
 type InsightsArgs struct {
@@ -202,6 +209,8 @@ type InsightViewResolver interface {
 	SeriesCount(ctx context.Context) (*int32, error)
 	RepositoryDefinition(ctx context.Context) (InsightRepositoryDefinition, error)
 	TimeScope(ctx context.Context) (InsightTimeScope, error)
+	Annotations(ctx context.Context) ([]InsightAnnotationResolver, error)
+	Alerts(ctx context.Context) ([]InsightAlertResolver, error)
 }
 
 type InsightDataSeriesDefinition interface {
@@ -233,6 +242,7 @@ type SearchInsightDataSeriesDefinitionResolver interface {
 	GeneratedFromCaptureGroups() (bool, error)
 	IsCalculated() (bool, error)
 	GroupBy() (*string, error)
+	Aggregation() (*string, error)
 }
 
 type InsightPresentation interface {
@@ -421,6 +431,7 @@ type LineChartSearchInsightDataSeriesInput struct {
 	Options                    LineChartDataSeriesOptionsInput
 	GeneratedFromCaptureGroups *bool
 	GroupBy                    *string
+	Aggregation                *string
 }
 
 type LineChartDataSeriesOptionsInput struct {
@@ -512,3 +523,60 @@ type RepositoryPreviewPayloadResolver interface {
 	Query(ctx context.Context) string
 	NumberOfRepositories(ctx context.Context) *int32
 }
+
+type InsightAnnotationResolver interface {
+	ID() graphql.ID
+	SeriesId() *string
+	Time() gqlutil.DateTime
+	Label() string
+	CreatedBy(ctx context.Context) (*UserResolver, error)
+}
+
+type AddInsightAnnotationArgs struct {
+	Input AddInsightAnnotationInput
+}
+
+type AddInsightAnnotationInput struct {
+	InsightViewId graphql.ID
+	SeriesId      *string
+	Time          gqlutil.DateTime
+	Label         string
+}
+
+type DeleteInsightAnnotationArgs struct {
+	InsightViewId graphql.ID
+	Id            graphql.ID
+}
+
+type InsightAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Condition() string
+	Threshold() float64
+	Email() bool
+	SlackWebhookURL(ctx context.Context) (*string, error)
+	WebhookURL(ctx context.Context) (*string, error)
+	Enabled() bool
+	Breached() bool
+	LastTriggeredAt() *gqlutil.DateTime
+	CreatedAt() gqlutil.DateTime
+}
+
+type AddInsightAlertArgs struct {
+	Input AddInsightAlertInput
+}
+
+type AddInsightAlertInput struct {
+	InsightViewId   graphql.ID
+	SeriesId        string
+	Condition       string
+	Threshold       float64
+	Email           *bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteInsightAlertArgs struct {
+	InsightViewId graphql.ID
+	Id            graphql.ID
+}
@@ -0,0 +1,54 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// OwnerSearchResultResolver resolves an owner yielded by `select:file.owners`.
+type OwnerSearchResultResolver struct {
+	db           database.DB
+	owner        result.OwnerMatch
+	repoResolver *RepositoryResolver
+}
+
+func (r *OwnerSearchResultResolver) Handle() *string {
+	if r.owner.Handle == "" {
+		return nil
+	}
+	return &r.owner.Handle
+}
+
+func (r *OwnerSearchResultResolver) Email() *string {
+	if r.owner.Email == "" {
+		return nil
+	}
+	return &r.owner.Email
+}
+
+func (r *OwnerSearchResultResolver) User() *UserResolver {
+	if r.owner.User == nil {
+		return nil
+	}
+	return NewUserResolver(r.db, r.owner.User)
+}
+
+func (r *OwnerSearchResultResolver) Organization() *OrgResolver {
+	if r.owner.Org == nil {
+		return nil
+	}
+	return NewOrg(r.db, r.owner.Org)
+}
+
+func (r *OwnerSearchResultResolver) Repository() *RepositoryResolver {
+	return r.repoResolver
+}
+
+func (r *OwnerSearchResultResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
+func (r *OwnerSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)   { return nil, false }
+func (r *OwnerSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *OwnerSearchResultResolver) ToOwnerSearchResult() (*OwnerSearchResultResolver, bool) {
+	return r, true
+}
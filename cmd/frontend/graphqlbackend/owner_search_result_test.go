@@ -0,0 +1,43 @@
+package graphqlbackend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestMatchesToResolvers_OwnerMatch(t *testing.T) {
+	db := database.NewMockDB()
+	resolvers := matchesToResolvers(db, []result.Match{
+		&result.OwnerMatch{
+			Handle: "alice",
+			User:   &types.User{ID: 1, Username: "alice"},
+			Repo:   types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+		},
+		&result.OwnerMatch{
+			Email: "docs@example.com",
+			Repo:  types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+		},
+	})
+	require.Len(t, resolvers, 2)
+
+	owner, ok := resolvers[0].ToOwnerSearchResult()
+	require.True(t, ok)
+	assert.Equal(t, "alice", *owner.Handle())
+	assert.Nil(t, owner.Email())
+	require.NotNil(t, owner.User())
+	assert.Equal(t, "alice", owner.User().Username())
+	assert.Nil(t, owner.Organization())
+	assert.Equal(t, "github.com/sourcegraph/sourcegraph", owner.Repository().Name())
+
+	owner, ok = resolvers[1].ToOwnerSearchResult()
+	require.True(t, ok)
+	assert.Nil(t, owner.Handle())
+	assert.Equal(t, "docs@example.com", *owner.Email())
+	assert.Nil(t, owner.User())
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/search/smartsearch"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
@@ -157,6 +158,12 @@ func (r *schemaResolver) LogEvents(ctx context.Context, args *EventBatch) (*Empt
 			return nil, err
 		}
 
+		if args.Event == smartSearchResultClickedEvent {
+			if err := exportPrometheusSmartSearchResultClicked(argumentPayload); err != nil {
+				log15.Error("exportPrometheusSmartSearchResultClicked", "error", err)
+			}
+		}
+
 		publicArgumentPayload, err := decode(args.PublicArgument)
 		if err != nil {
 			return nil, err
@@ -247,3 +254,34 @@ func exportPrometheusSearchRanking(payload json.RawMessage) error {
 	searchRankingResultClicked.WithLabelValues(v.Type).Observe(v.Index)
 	return nil
 }
+
+// smartSearchResultClickedEvent is logged when a user clicks a result of a
+// query generated by Smart Search. Besides being logged like any other event,
+// it counts the clicks per rule that generated the query.
+const smartSearchResultClickedEvent = "SmartSearchResultClicked"
+
+var searchSmartSearchRuleResultClicked = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_search_smart_search_rule_result_clicked_total",
+	Help: "the number of clicked search results generated by each Smart Search rule",
+}, []string{"rule"})
+
+func exportPrometheusSmartSearchResultClicked(payload json.RawMessage) error {
+	if payload == nil {
+		return nil
+	}
+	var v struct {
+		Rules []string `json:"rules"`
+	}
+	if err := json.Unmarshal([]byte(payload), &v); err != nil {
+		return err
+	}
+	for _, rule := range v.Rules {
+		// Rule names come from clients, bound the label values to the
+		// configured rules.
+		if !smartsearch.IsRuleName(rule) {
+			rule = "other"
+		}
+		searchSmartSearchRuleResultClicked.WithLabelValues(rule).Inc()
+	}
+	return nil
+}
@@ -273,6 +273,7 @@ func Main(enterpriseSetupHook func(database.DB, conftypes.UnifiedWatchable) ente
 	if internalAPI != nil {
 		routines = append(routines, internalAPI)
 	}
+	routines = append(routines, gitserver.ClientCacheRoutines()...)
 
 	oce.GlobalExporter = oce.NewDataExporter(db, logger)
 
@@ -311,6 +312,7 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 			BatchesChangesFileGetHandler:    enterprise.BatchesChangesFileGetHandler,
 			BatchesChangesFileExistsHandler: enterprise.BatchesChangesFileExistsHandler,
 			BatchesChangesFileUploadHandler: enterprise.BatchesChangesFileUploadHandler,
+			InsightsExportHandler:           enterprise.InsightsExportHandler,
 			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
 			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
 		},
@@ -294,11 +294,31 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
 }
 
+func fromOwner(o *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	event := &streamhttp.EventOwnerMatch{
+		Type:         streamhttp.OwnerMatchType,
+		Handle:       o.Handle,
+		Email:        o.Email,
+		Repository:   string(o.Repo.Name),
+		RepositoryID: int32(o.Repo.ID),
+		Commit:       string(o.CommitID),
+	}
+	if o.User != nil {
+		event.User = o.User.Username
+	}
+	if o.Org != nil {
+		event.Org = o.Org.Name
+	}
+	return event
+}
+
 func fromFileMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.SearchedRepo, enableChunkMatches bool) streamhttp.EventMatch {
 	if len(fm.Symbols) > 0 {
 		return fromSymbolMatch(fm, repoCache)
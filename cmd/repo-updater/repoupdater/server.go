@@ -35,8 +35,8 @@ type Server struct {
 	ObservationCtx        *observation.Context
 	SourcegraphDotComMode bool
 	Scheduler             interface {
-		UpdateOnce(id api.RepoID, name api.RepoName)
-		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
+		UpdateOnce(ctx context.Context, id api.RepoID, name api.RepoName)
+		ScheduleInfo(ctx context.Context, id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
 	}
 	ChangesetSyncRegistry batches.ChangesetSyncRegistry
 	RateLimitSyncer       interface {
@@ -74,7 +74,7 @@ func (s *Server) handleRepoUpdateSchedulerInfo(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	result := s.Scheduler.ScheduleInfo(args.ID)
+	result := s.Scheduler.ScheduleInfo(r.Context(), args.ID)
 	s.respond(w, http.StatusOK, result)
 }
 
@@ -144,7 +144,7 @@ func (s *Server) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdate
 
 	repo := rs[0]
 
-	s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+	s.Scheduler.UpdateOnce(ctx, repo.ID, repo.Name)
 
 	return &protocol.RepoUpdateResponse{
 		ID:   repo.ID,
@@ -344,7 +344,7 @@ func (s *Server) repoLookup(ctx context.Context, args protocol.RepoLookupArgs) (
 
 	if s.Scheduler != nil && args.Update {
 		// Enqueue a high priority update for this repo.
-		s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+		s.Scheduler.UpdateOnce(ctx, repo.ID, repo.Name)
 	}
 
 	repoInfo := protocol.NewRepoInfo(repo)
@@ -34,6 +34,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -253,7 +254,8 @@ func Main(enterpriseInit EnterpriseInit) {
 		Handler: instrumentation.HTTPMiddleware("",
 			trace.HTTPMiddleware(logger, authzBypass(handler), conf.DefaultClient())),
 	})
-	goroutine.MonitorBackgroundRoutines(ctx, httpSrv)
+	routines := append([]goroutine.BackgroundRoutine{httpSrv}, gitserver.ClientCacheRoutines()...)
+	goroutine.MonitorBackgroundRoutines(ctx, routines...)
 }
 
 func createDebugServerRoutine(ready chan struct{}, debugserverEndpoints *LazyDebugserverEndpoint) goroutine.BackgroundRoutine {
@@ -481,7 +483,7 @@ func watchSyncer(
 			return
 		case diff := <-syncer.Synced:
 			if !conf.Get().DisableAutoGitUpdates {
-				sched.UpdateFromDiff(diff)
+				sched.UpdateFromDiff(ctx, diff)
 			}
 
 			// PermsSyncer is only available in enterprise mode.
@@ -547,7 +549,7 @@ func syncScheduler(ctx context.Context, logger log.Logger, sched *repos.UpdateSc
 				return
 			}
 			// Ensure that uncloned indexable repos are known to the scheduler
-			sched.EnsureScheduled(indexable)
+			sched.EnsureScheduled(ctx, indexable)
 		}
 
 		// Next, move any repos managed by the scheduler that are uncloned to the front
@@ -560,7 +562,7 @@ func syncScheduler(ctx context.Context, logger log.Logger, sched *repos.UpdateSc
 			return
 		}
 
-		sched.PrioritiseUncloned(uncloned)
+		sched.PrioritiseUncloned(ctx, uncloned)
 	}
 
 	for ctx.Err() == nil {
@@ -66,7 +66,7 @@ func frontendDB(observationCtx *observation.Context) (database.DB, error) {
 	return database.NewDB(logger, sqlDB), nil
 }
 
-func shutdownOnSignal(ctx context.Context, server *http.Server) error {
+func shutdownOnSignal(ctx context.Context, server *http.Server, routines goroutine.BackgroundRoutine) error {
 	// Listen for shutdown signals. When we receive one attempt to clean up,
 	// but do an insta-shutdown if we receive more than one signal.
 	c := make(chan os.Signal, 2)
@@ -88,8 +88,10 @@ func shutdownOnSignal(ctx context.Context, server *http.Server) error {
 	// Wait for at most for the configured shutdown timeout.
 	ctx, cancel := context.WithTimeout(ctx, goroutine.GracefulShutdownTimeout)
 	defer cancel()
-	// Stop accepting requests.
-	return server.Shutdown(ctx)
+	// Stop accepting requests, then stop the background routines.
+	err := server.Shutdown(ctx)
+	routines.Stop()
+	return err
 }
 
 // setupTmpDir sets up a temporary directory on the same volume as the
@@ -202,6 +204,14 @@ func run(logger log.Logger) error {
 	defer cancel()
 	g, ctx := errgroup.WithContext(ctx)
 
+	// Background routines are stopped by shutdownOnSignal, rather than by
+	// installing a signal handler of their own.
+	routines := goroutine.CombinedRoutine(gitserver.ClientCacheRoutines())
+	g.Go(func() error {
+		routines.Start()
+		return nil
+	})
+
 	host := ""
 	if env.InsecureDev {
 		host = "127.0.0.1"
@@ -236,7 +246,7 @@ func run(logger log.Logger) error {
 
 	// Shutdown
 	g.Go(func() error {
-		return shutdownOnSignal(ctx, server)
+		return shutdownOnSignal(ctx, server, routines)
 	})
 
 	return g.Wait()
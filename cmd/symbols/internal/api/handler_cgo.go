@@ -20,4 +20,5 @@ func addHandlers(
 	mux.HandleFunc("/localCodeIntel", squirrel.LocalCodeIntelHandler(readFileFunc))
 	mux.HandleFunc("/debugLocalCodeIntel", squirrel.DebugLocalCodeIntelHandler)
 	mux.HandleFunc("/symbolInfo", squirrel.NewSymbolInfoHandler(searchFunc, readFileFunc))
+	mux.HandleFunc("/symbolReferences", squirrel.NewSymbolReferencesHandler(searchFunc, readFileFunc))
 }
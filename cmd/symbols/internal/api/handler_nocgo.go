@@ -27,6 +27,7 @@ func addHandlers(
 	mux.HandleFunc("/localCodeIntel", jsonResponseHandler(internaltypes.LocalCodeIntelPayload{Symbols: []internaltypes.Symbol{}}))
 	mux.HandleFunc("/debugLocalCodeIntel", notEnabledHandler)
 	mux.HandleFunc("/symbolInfo", jsonResponseHandler(internaltypes.SymbolInfo{}))
+	mux.HandleFunc("/symbolReferences", jsonResponseHandler(nil))
 }
 
 func notEnabledHandler(w http.ResponseWriter, r *http.Request) {
@@ -25,6 +25,7 @@ import (
 	connections "github.com/sourcegraph/sourcegraph/internal/database/connections/live"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	internalgitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
@@ -124,6 +125,7 @@ func Main(setup SetupFunc) {
 		Handler:      handler,
 	})
 	routines = append(routines, server)
+	routines = append(routines, internalgitserver.ClientCacheRoutines()...)
 
 	// Mark health server as ready and go!
 	close(ready)
@@ -0,0 +1,246 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	parent := node.Parent()
+
+	switch node.Type() {
+	case "identifier":
+		found, err := findLocalDef(node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+		return squirrel.getDefInPackageGo(ctx, node, node.Content(node.Contents))
+
+	case "type_identifier":
+		// pkg.Type
+		if parent != nil && parent.Type() == "qualified_type" {
+			pkg := parent.ChildByFieldName("package")
+			if pkg != nil {
+				return squirrel.getDefInImportedPackageGo(ctx, swapNode(node, pkg), node.Content(node.Contents))
+			}
+		}
+		return squirrel.getDefInPackageGo(ctx, node, node.Content(node.Contents))
+
+	case "field_identifier":
+		// pkg.Ident
+		if parent == nil || parent.Type() != "selector_expression" {
+			return nil, nil
+		}
+		operand := parent.ChildByFieldName("operand")
+		if operand == nil || operand.Type() != "identifier" {
+			return nil, nil
+		}
+		local, err := findLocalDef(swapNode(node, operand))
+		if err != nil {
+			return nil, err
+		}
+		if local != nil {
+			// Fields and methods of local variables require type information.
+			return nil, nil
+		}
+		return squirrel.getDefInImportedPackageGo(ctx, swapNode(node, operand), node.Content(node.Contents))
+
+	case "package_identifier":
+		return squirrel.getImportedPackageGo(ctx, node, node.Content(node.Contents))
+
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInPackageGo finds a package-level identifier, which is either declared in the same file,
+// imported in the same file, or declared in another file in the same directory.
+func (squirrel *SquirrelService) getDefInPackageGo(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(node.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	root := swapNode(node, getRoot(node.Node))
+
+	found, err := findTopLevelSymbol(root, ident)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	found, err = squirrel.getImportedPackageGo(ctx, node, ident)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	found, err = squirrel.symbolSearchOne(
+		ctx,
+		node.RepoCommitPath.Repo,
+		node.RepoCommitPath.Commit,
+		[]string{goDirPattern(filepath.Dir(node.RepoCommitPath.Path))},
+		ident,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Check packages imported with a dot.
+	for _, imp := range getImportsGo(root) {
+		if imp.name != "." {
+			continue
+		}
+		found, err := squirrel.getDefInImportPathGo(ctx, node, imp.path, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getImportedPackageGo returns the directory of the package imported with the given name.
+func (squirrel *SquirrelService) getImportedPackageGo(ctx context.Context, node Node, name string) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(node.Type()), String(name)}, lazyNodeStringer(&ret))()
+
+	for _, imp := range getImportsGo(swapNode(node, getRoot(node.Node))) {
+		if imp.name != name {
+			continue
+		}
+		dir, err := squirrel.findPackageDirGo(ctx, node, imp.path)
+		if err != nil {
+			return nil, err
+		}
+		if dir == nil {
+			return nil, nil
+		}
+		return &Node{
+			RepoCommitPath: *dir,
+			Node:           nil,
+			Contents:       node.Contents,
+			LangSpec:       node.LangSpec,
+		}, nil
+	}
+	return nil, nil
+}
+
+// getDefInImportedPackageGo finds a package-level identifier in the package imported with the
+// given package name.
+func (squirrel *SquirrelService) getDefInImportedPackageGo(ctx context.Context, pkg Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(pkg, &Tuple{String(pkg.Content(pkg.Contents)), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, imp := range getImportsGo(swapNode(pkg, getRoot(pkg.Node))) {
+		if imp.name != pkg.Content(pkg.Contents) {
+			continue
+		}
+		return squirrel.getDefInImportPathGo(ctx, pkg, imp.path, ident)
+	}
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getDefInImportPathGo(ctx context.Context, node Node, importPath string, ident string) (*Node, error) {
+	dir, err := squirrel.findPackageDirGo(ctx, node, importPath)
+	if err != nil {
+		return nil, err
+	}
+	if dir == nil {
+		return nil, nil
+	}
+	return squirrel.symbolSearchOne(ctx, dir.Repo, dir.Commit, []string{goDirPattern(dir.Path)}, ident)
+}
+
+// findPackageDirGo finds the directory in the repository that contains the package with the given
+// import path. Import paths start with the module path, which is unknown, so this returns the
+// directory that matches the longest suffix of the import path.
+func (squirrel *SquirrelService) findPackageDirGo(ctx context.Context, node Node, importPath string) (*types.RepoCommitPath, error) {
+	components := strings.Split(importPath, "/")
+	for i := range components {
+		dir := strings.Join(components[i:], "/")
+		symbols, err := squirrel.symbolSearch(ctx, search.SymbolsParameters{
+			Repo:            api.RepoName(node.RepoCommitPath.Repo),
+			CommitID:        api.CommitID(node.RepoCommitPath.Commit),
+			Query:           "",
+			IsRegExp:        true,
+			IsCaseSensitive: true,
+			IncludePatterns: []string{goDirPattern(dir)},
+			First:           1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(symbols) > 0 {
+			return &types.RepoCommitPath{
+				Repo:   node.RepoCommitPath.Repo,
+				Commit: node.RepoCommitPath.Commit,
+				Path:   dir,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// goDirPattern returns a pattern that matches the Go files directly inside the given directory.
+func goDirPattern(dir string) string {
+	if dir == "." || dir == "" {
+		return `^[^/]*\.go$`
+	}
+	return fmt.Sprintf(`^%s/[^/]*\.go$`, regexp.QuoteMeta(dir))
+}
+
+type importGo struct {
+	// name is the name the package is referred to by in the file, which is the explicit name of
+	// the import if there is one.
+	name string
+	path string
+}
+
+var goMajorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+func getImportsGo(root Node) []importGo {
+	imports := []importGo{}
+	captures, _ := allCaptures(`(import_spec) @spec`, root)
+	for _, spec := range captures {
+		pathNode := spec.ChildByFieldName("path")
+		if pathNode == nil {
+			continue
+		}
+		path := strings.Trim(pathNode.Content(root.Contents), "\"`")
+
+		var name string
+		if nameNode := spec.ChildByFieldName("name"); nameNode != nil {
+			name = nameNode.Content(root.Contents)
+		} else {
+			// By convention, the package name is the last component of the import path, not
+			// counting major version suffixes.
+			components := strings.Split(path, "/")
+			name = components[len(components)-1]
+			if goMajorVersionRegex.MatchString(name) && len(components) > 1 {
+				name = components[len(components)-2]
+			}
+		}
+
+		imports = append(imports, importGo{name: name, path: path})
+	}
+	return imports
+}
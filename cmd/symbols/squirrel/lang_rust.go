@@ -0,0 +1,502 @@
+package squirrel
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier":
+	default:
+		return nil, nil
+	}
+
+	path, inUse := pathAtRust(node)
+	if len(path) > 1 || inUse {
+		return squirrel.resolvePathRust(ctx, node, path, inUse)
+	}
+
+	if node.Type() == "identifier" {
+		found, err := findLocalDef(node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return squirrel.findInScopeRust(ctx, node, node.Content(node.Contents), true)
+}
+
+// moduleRust is a Rust module, which is either a file or an inline `mod m { ... }` block.
+type moduleRust struct {
+	// body is the source_file or declaration_list that contains the items of the module.
+	body Node
+	// dir is the directory that contains the files of the submodules of the module.
+	dir string
+}
+
+// resolvePathRust finds the item that a path like crate::a::b refers to.
+func (squirrel *SquirrelService) resolvePathRust(ctx context.Context, from Node, path []string, inUse bool) (ret *Node, err error) {
+	defer squirrel.onCall(from, String(strings.Join(path, "::")), lazyNodeStringer(&ret))()
+
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	var module *moduleRust
+	rest := path[1:]
+	switch path[0] {
+	case "crate":
+		module = squirrel.crateRootRust(ctx, from)
+	case "self":
+		module = moduleOfRust(from)
+	case "super":
+		module = squirrel.parentModuleRust(ctx, moduleOfRust(from))
+		for len(rest) > 0 && rest[0] == "super" && module != nil {
+			module = squirrel.parentModuleRust(ctx, module)
+			rest = rest[1:]
+		}
+	default:
+		// Paths in use declarations can't start with names imported by other use declarations,
+		// which avoids cycles.
+		first, err := squirrel.findInScopeRust(ctx, from, path[0], !inUse)
+		if err != nil || first == nil {
+			return nil, err
+		}
+		if len(rest) == 0 {
+			return first, nil
+		}
+		if !isModRust(first) {
+			if len(rest) == 1 {
+				return squirrel.getAssociatedRust(*first, rest[0]), nil
+			}
+			return nil, nil
+		}
+		module = squirrel.enterModRust(ctx, *first)
+	}
+
+	for i, name := range rest {
+		if module == nil {
+			return nil, nil
+		}
+		item, err := squirrel.findInModuleRust(ctx, module.body, name)
+		if err != nil || item == nil {
+			return nil, err
+		}
+		if i == len(rest)-1 {
+			return item, nil
+		}
+		if !isModRust(item) {
+			if i == len(rest)-2 {
+				return squirrel.getAssociatedRust(*item, rest[i+1]), nil
+			}
+			return nil, nil
+		}
+		module = squirrel.enterModRust(ctx, *item)
+	}
+
+	return nil, nil
+}
+
+// findInScopeRust finds an item or local use binding that is visible from the given node.
+func (squirrel *SquirrelService) findInScopeRust(ctx context.Context, node Node, ident string, withUses bool) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(node.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		isModule := cur.Type() == "source_file" || isModBodyRust(cur)
+		if cur.Type() != "block" && !isModule {
+			continue
+		}
+
+		if found := findItemRust(swapNode(node, cur), ident); found != nil {
+			return found, nil
+		}
+		if withUses {
+			found, err := squirrel.findUseRust(ctx, swapNode(node, cur), ident)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+
+		// Items of enclosing modules aren't visible.
+		if isModule {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findInModuleRust finds an item declared or re-exported in a module.
+func (squirrel *SquirrelService) findInModuleRust(ctx context.Context, body Node, ident string) (*Node, error) {
+	if found := findItemRust(body, ident); found != nil {
+		return found, nil
+	}
+	return squirrel.findUseRust(ctx, body, ident)
+}
+
+// findUseRust finds an item imported by a use declaration directly inside body.
+func (squirrel *SquirrelService) findUseRust(ctx context.Context, body Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(body, &Tuple{String(body.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, child := range children(body.Node) {
+		if child.Type() != "use_declaration" {
+			continue
+		}
+		argument := child.ChildByFieldName("argument")
+		if argument == nil {
+			continue
+		}
+		for _, binding := range useBindingsRust(swapNode(body, argument), nil) {
+			switch binding.name {
+			case ident:
+				return squirrel.resolvePathRust(ctx, swapNode(body, child), binding.path, true)
+			case "*":
+				found, err := squirrel.resolvePathRust(ctx, swapNode(body, child), appendPathRust(binding.path, ident), true)
+				if err != nil {
+					return nil, err
+				}
+				if found != nil {
+					return found, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// getAssociatedRust finds an enum variant, or an associated function or constant of a type
+// declared in the same module as the type.
+func (squirrel *SquirrelService) getAssociatedRust(def Node, name string) *Node {
+	item := def.Parent()
+	if item == nil {
+		return nil
+	}
+
+	findIn := func(body *sitter.Node, nodeTypes ...string) *Node {
+		if body == nil {
+			return nil
+		}
+		for _, child := range children(body) {
+			if !contains(nodeTypes, child.Type()) {
+				continue
+			}
+			if childName := child.ChildByFieldName("name"); childName != nil && childName.Content(def.Contents) == name {
+				return swapNodePtr(def, childName)
+			}
+		}
+		return nil
+	}
+
+	switch item.Type() {
+	case "enum_item":
+		if found := findIn(item.ChildByFieldName("body"), "enum_variant"); found != nil {
+			return found
+		}
+	case "trait_item":
+		return findIn(item.ChildByFieldName("body"), "function_item", "function_signature_item", "const_item")
+	}
+
+	module := moduleOfRust(swapNode(def, item))
+	for _, child := range children(module.body.Node) {
+		if child.Type() != "impl_item" {
+			continue
+		}
+		ty := child.ChildByFieldName("type")
+		if ty != nil && ty.Type() == "generic_type" {
+			ty = ty.ChildByFieldName("type")
+		}
+		if ty == nil || ty.Content(def.Contents) != def.Content(def.Contents) {
+			continue
+		}
+		if found := findIn(child.ChildByFieldName("body"), "function_item", "const_item"); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// enterModRust returns the module declared by the mod item with the given name node, which is
+// either inline or in a file next to the declaring module.
+func (squirrel *SquirrelService) enterModRust(ctx context.Context, name Node) *moduleRust {
+	item := name.Parent()
+	container := moduleOfRust(swapNode(name, item))
+	dir := filepath.Join(container.dir, name.Content(name.Contents))
+
+	if body := item.ChildByFieldName("body"); body != nil {
+		return &moduleRust{body: swapNode(name, body), dir: dir}
+	}
+
+	for _, candidate := range []string{dir + ".rs", filepath.Join(dir, "mod.rs")} {
+		file, _ := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   name.RepoCommitPath.Repo,
+			Commit: name.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if file != nil {
+			return &moduleRust{body: *file, dir: dir}
+		}
+	}
+	return nil
+}
+
+// parentModuleRust returns the module that declares the given module, or nil for the crate root.
+func (squirrel *SquirrelService) parentModuleRust(ctx context.Context, module *moduleRust) *moduleRust {
+	if module == nil {
+		return nil
+	}
+	if isModBodyRust(module.body.Node) {
+		return moduleOfRust(module.body)
+	}
+
+	switch filepath.Base(module.body.RepoCommitPath.Path) {
+	case "lib.rs", "main.rs":
+		return nil
+	}
+
+	// The parent module is the file that declares the submodules in its directory.
+	parentDir := filepath.Dir(module.dir)
+	candidates := []string{filepath.Join(parentDir, "mod.rs"), filepath.Join(parentDir, "lib.rs"), filepath.Join(parentDir, "main.rs")}
+	if parentDir != "." {
+		candidates = append(candidates, parentDir+".rs")
+	}
+	for _, candidate := range candidates {
+		file, _ := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   module.body.RepoCommitPath.Repo,
+			Commit: module.body.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if file != nil {
+			return &moduleRust{body: *file, dir: parentDir}
+		}
+	}
+	return nil
+}
+
+// crateRootRust returns the lib.rs or main.rs file of the crate that contains the given node.
+func (squirrel *SquirrelService) crateRootRust(ctx context.Context, node Node) *moduleRust {
+	for dir := filepath.Dir(node.RepoCommitPath.Path); ; dir = filepath.Dir(dir) {
+		for _, name := range []string{"lib.rs", "main.rs"} {
+			file, _ := squirrel.parse(ctx, types.RepoCommitPath{
+				Repo:   node.RepoCommitPath.Repo,
+				Commit: node.RepoCommitPath.Commit,
+				Path:   filepath.Join(dir, name),
+			})
+			if file != nil {
+				return &moduleRust{body: *file, dir: dir}
+			}
+		}
+		if dir == "." || dir == "/" {
+			return nil
+		}
+	}
+}
+
+// moduleOfRust returns the innermost module that contains the given node.
+func moduleOfRust(node Node) *moduleRust {
+	var body *sitter.Node
+	inline := []string{}
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		if !isModBodyRust(cur) {
+			continue
+		}
+		if body == nil {
+			body = cur
+		}
+		if name := cur.Parent().ChildByFieldName("name"); name != nil {
+			inline = append([]string{name.Content(node.Contents)}, inline...)
+		}
+	}
+	if body == nil {
+		body = getRoot(node.Node)
+	}
+
+	// Files named mod.rs, lib.rs and main.rs declare the submodules in their directory, and other
+	// files declare the submodules in the directory named after them.
+	path := node.RepoCommitPath.Path
+	dir := filepath.Dir(path)
+	switch filepath.Base(path) {
+	case "mod.rs", "lib.rs", "main.rs":
+	default:
+		dir = filepath.Join(dir, strings.TrimSuffix(filepath.Base(path), ".rs"))
+	}
+
+	return &moduleRust{
+		body: swapNode(node, body),
+		dir:  filepath.Join(append([]string{dir}, inline...)...),
+	}
+}
+
+func isModBodyRust(node *sitter.Node) bool {
+	return node.Type() == "declaration_list" && node.Parent() != nil && node.Parent().Type() == "mod_item"
+}
+
+func isModRust(name *Node) bool {
+	return name.Parent() != nil && name.Parent().Type() == "mod_item"
+}
+
+// findItemRust finds an item declared directly inside body.
+func findItemRust(body Node, ident string) *Node {
+	for _, child := range children(body.Node) {
+		switch child.Type() {
+		case "function_item",
+			"struct_item",
+			"enum_item",
+			"union_item",
+			"trait_item",
+			"type_item",
+			"const_item",
+			"static_item",
+			"mod_item",
+			"macro_definition":
+			if name := child.ChildByFieldName("name"); name != nil && name.Content(body.Contents) == ident {
+				return swapNodePtr(body, name)
+			}
+		}
+	}
+	return nil
+}
+
+// pathAtRust returns the path that ends at the given identifier, and whether it's in a use
+// declaration. For example, the path at b in a::b::c is [a, b].
+func pathAtRust(node Node) ([]string, bool) {
+	path := []string{node.Content(node.Contents)}
+	cur := node.Node
+	if parent := cur.Parent(); parent != nil && isScopedRust(parent) {
+		if name := parent.ChildByFieldName("name"); name != nil && nodeId(name) == nodeId(cur) {
+			path = pathSegmentsRust(swapNode(node, parent))
+			cur = parent
+		}
+	}
+
+	for parent := cur.Parent(); parent != nil; cur, parent = parent, parent.Parent() {
+		switch parent.Type() {
+		case "scoped_identifier", "scoped_type_identifier", "use_list", "use_wildcard":
+			continue
+		case "use_as_clause":
+			// Aliases refer to the path they're an alias of.
+			if alias := parent.ChildByFieldName("alias"); alias != nil && nodeId(alias) == nodeId(cur) {
+				if p := parent.ChildByFieldName("path"); p != nil {
+					path = pathSegmentsRust(swapNode(node, p))
+				}
+			}
+			continue
+		case "scoped_use_list":
+			// Paths in a use list are relative to the prefix of the list.
+			list := parent.ChildByFieldName("list")
+			prefix := parent.ChildByFieldName("path")
+			if list != nil && prefix != nil && nodeId(list) == nodeId(cur) {
+				path = appendPathRust(pathSegmentsRust(swapNode(node, prefix)), path...)
+			}
+			continue
+		case "use_declaration":
+			return path, true
+		default:
+			return path, false
+		}
+	}
+
+	return path, false
+}
+
+type useBindingRust struct {
+	// path is the path of the imported item.
+	path []string
+	// name is the name the item is imported as, or "*" for glob imports.
+	name string
+}
+
+// useBindingsRust returns the names bound by the argument of a use declaration.
+func useBindingsRust(node Node, prefix []string) []useBindingRust {
+	switch node.Type() {
+	case "identifier", "crate", "super", "self", "scoped_identifier":
+		path := appendPathRust(prefix, pathSegmentsRust(node)...)
+		if node.Type() == "self" && len(prefix) > 0 {
+			// use a::{self}
+			path = prefix
+		}
+		return []useBindingRust{{path: path, name: path[len(path)-1]}}
+
+	case "use_as_clause":
+		p := node.ChildByFieldName("path")
+		alias := node.ChildByFieldName("alias")
+		if p == nil || alias == nil {
+			return nil
+		}
+		return []useBindingRust{{
+			path: appendPathRust(prefix, pathSegmentsRust(swapNode(node, p))...),
+			name: alias.Content(node.Contents),
+		}}
+
+	case "use_list":
+		bindings := []useBindingRust{}
+		for _, child := range children(node.Node) {
+			if child.IsNamed() {
+				bindings = append(bindings, useBindingsRust(swapNode(node, child), prefix)...)
+			}
+		}
+		return bindings
+
+	case "scoped_use_list":
+		list := node.ChildByFieldName("list")
+		if list == nil {
+			return nil
+		}
+		if p := node.ChildByFieldName("path"); p != nil {
+			prefix = appendPathRust(prefix, pathSegmentsRust(swapNode(node, p))...)
+		}
+		return useBindingsRust(swapNode(node, list), prefix)
+
+	case "use_wildcard":
+		path := prefix
+		if node.NamedChildCount() > 0 {
+			path = appendPathRust(prefix, pathSegmentsRust(swapNode(node, node.NamedChild(0)))...)
+		}
+		return []useBindingRust{{path: path, name: "*"}}
+
+	default:
+		return nil
+	}
+}
+
+// pathSegmentsRust returns the segments of a path like a::b::c.
+func pathSegmentsRust(node Node) []string {
+	if !isScopedRust(node.Node) {
+		return []string{node.Content(node.Contents)}
+	}
+	name := node.ChildByFieldName("name")
+	if name == nil {
+		return nil
+	}
+	prefix := []string{}
+	if p := node.ChildByFieldName("path"); p != nil {
+		prefix = pathSegmentsRust(swapNode(node, p))
+	}
+	return appendPathRust(prefix, name.Content(node.Contents))
+}
+
+func isScopedRust(node *sitter.Node) bool {
+	return node.Type() == "scoped_identifier" || node.Type() == "scoped_type_identifier"
+}
+
+// appendPathRust appends segments to a copy of path.
+func appendPathRust(path []string, segments ...string) []string {
+	ret := make([]string, 0, len(path)+len(segments))
+	ret = append(ret, path...)
+	return append(ret, segments...)
+}
@@ -0,0 +1,384 @@
+package squirrel
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefTypescript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	root := swapNode(node, getRoot(node.Node))
+	parent := node.Parent()
+
+	switch node.Type() {
+	case "identifier":
+		// export { x as y } from "./x"
+		if parent != nil && parent.Type() == "export_specifier" && parent.Parent() != nil && parent.Parent().Parent() != nil {
+			local := parent.ChildByFieldName("name")
+			if local == nil {
+				return nil, nil
+			}
+			source := parent.Parent().Parent().ChildByFieldName("source")
+			if source == nil {
+				return squirrel.getDefInModuleTypescript(ctx, root, local.Content(node.Contents))
+			}
+			module := squirrel.resolveModuleTypescript(ctx, root, source)
+			if module == nil {
+				return nil, nil
+			}
+			return squirrel.getExportTypescript(ctx, *module, local.Content(node.Contents), map[string]struct{}{})
+		}
+
+		// import { x as y } from "./x"
+		if parent != nil && parent.Type() == "import_specifier" {
+			name := parent.ChildByFieldName("name")
+			var source *sitter.Node
+			for cur := parent.Parent(); cur != nil; cur = cur.Parent() {
+				if cur.Type() == "import_statement" {
+					source = importSourceTypescript(cur)
+					break
+				}
+			}
+			if name == nil || source == nil {
+				return nil, nil
+			}
+			module := squirrel.resolveModuleTypescript(ctx, root, source)
+			if module == nil {
+				return nil, nil
+			}
+			return squirrel.getExportTypescript(ctx, *module, name.Content(node.Contents), map[string]struct{}{})
+		}
+
+		found, err := findLocalDef(node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+		return squirrel.getDefInModuleTypescript(ctx, root, node.Content(node.Contents))
+
+	case "type_identifier":
+		// ns.Type
+		if parent != nil && parent.Type() == "nested_type_identifier" {
+			module := parent.ChildByFieldName("module")
+			if module == nil || module.Type() != "identifier" {
+				return nil, nil
+			}
+			return squirrel.getMemberTypescript(ctx, swapNode(node, module), node.Content(node.Contents))
+		}
+		return squirrel.getDefInModuleTypescript(ctx, root, node.Content(node.Contents))
+
+	case "property_identifier":
+		// ns.member
+		if parent == nil || parent.Type() != "member_expression" {
+			return nil, nil
+		}
+		object := parent.ChildByFieldName("object")
+		if object == nil || object.Type() != "identifier" {
+			return nil, nil
+		}
+		return squirrel.getMemberTypescript(ctx, swapNode(node, object), node.Content(node.Contents))
+
+	default:
+		return nil, nil
+	}
+}
+
+// getMemberTypescript finds a member of a namespace import, such as ns.member. Members of other
+// objects require type information, which isn't supported.
+func (squirrel *SquirrelService) getMemberTypescript(ctx context.Context, object Node, member string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Content(object.Contents)), String(member)}, lazyNodeStringer(&ret))()
+
+	local, err := findLocalDef(object)
+	if err != nil {
+		return nil, err
+	}
+	if local != nil {
+		return nil, nil
+	}
+
+	module, err := squirrel.getDefInModuleTypescript(ctx, swapNode(object, getRoot(object.Node)), object.Content(object.Contents))
+	if err != nil {
+		return nil, err
+	}
+	if module == nil || module.Type() != "program" {
+		return nil, nil
+	}
+	return squirrel.getExportTypescript(ctx, *module, member, map[string]struct{}{})
+}
+
+// getDefInModuleTypescript finds an identifier declared or imported at the top level of the module.
+// Namespace imports resolve to the root of the imported module.
+func (squirrel *SquirrelService) getDefInModuleTypescript(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(program, &Tuple{String(program.RepoCommitPath.Path), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(program.Node) {
+		for _, name := range declarationNamesTypescript(stmt) {
+			if name.Content(program.Contents) == ident {
+				return swapNodePtr(program, name), nil
+			}
+		}
+	}
+
+	for _, stmt := range children(program.Node) {
+		if stmt.Type() != "import_statement" {
+			continue
+		}
+		source := importSourceTypescript(stmt)
+		if source == nil {
+			continue
+		}
+		for _, clause := range children(stmt) {
+			if clause.Type() != "import_clause" {
+				continue
+			}
+			for _, child := range children(clause) {
+				switch child.Type() {
+				case "identifier":
+					// import x from "./x"
+					if child.Content(program.Contents) != ident {
+						continue
+					}
+					module := squirrel.resolveModuleTypescript(ctx, program, source)
+					if module == nil {
+						return nil, nil
+					}
+					return squirrel.getExportTypescript(ctx, *module, "default", map[string]struct{}{})
+
+				case "namespace_import":
+					// import * as x from "./x"
+					if child.NamedChildCount() == 0 || child.NamedChild(0).Content(program.Contents) != ident {
+						continue
+					}
+					return squirrel.resolveModuleTypescript(ctx, program, source), nil
+
+				case "named_imports":
+					// import { x, y as z } from "./x"
+					for _, specifier := range children(child) {
+						if specifier.Type() != "import_specifier" {
+							continue
+						}
+						name := specifier.ChildByFieldName("name")
+						if name == nil {
+							continue
+						}
+						bound := name
+						if alias := specifier.ChildByFieldName("alias"); alias != nil {
+							bound = alias
+						}
+						if bound.Content(program.Contents) != ident {
+							continue
+						}
+						module := squirrel.resolveModuleTypescript(ctx, program, source)
+						if module == nil {
+							return nil, nil
+						}
+						return squirrel.getExportTypescript(ctx, *module, name.Content(program.Contents), map[string]struct{}{})
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// getExportTypescript finds the declaration of the given export of the module, following
+// re-exports. The name of the default export is "default".
+func (squirrel *SquirrelService) getExportTypescript(ctx context.Context, program Node, name string, seen map[string]struct{}) (ret *Node, err error) {
+	defer squirrel.onCall(program, &Tuple{String(program.RepoCommitPath.Path), String(name)}, lazyNodeStringer(&ret))()
+
+	// Modules can re-export each other.
+	if _, ok := seen[program.RepoCommitPath.Path]; ok {
+		return nil, nil
+	}
+	seen[program.RepoCommitPath.Path] = struct{}{}
+
+	for _, stmt := range children(program.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+
+		isDefault := false
+		var clause *sitter.Node
+		for i := 0; i < int(stmt.ChildCount()); i++ {
+			switch child := stmt.Child(i); child.Type() {
+			case "default":
+				isDefault = true
+			case "export_clause":
+				clause = child
+			}
+		}
+		source := stmt.ChildByFieldName("source")
+
+		if isDefault {
+			if name != "default" {
+				continue
+			}
+			if names := declarationNamesTypescript(stmt); len(names) > 0 {
+				return swapNodePtr(program, names[0]), nil
+			}
+			value := stmt.ChildByFieldName("value")
+			if value == nil {
+				return nil, nil
+			}
+			if value.Type() == "identifier" {
+				// export default x
+				return squirrel.getDefInModuleTypescript(ctx, program, value.Content(program.Contents))
+			}
+			return swapNodePtr(program, value), nil
+		}
+
+		// export const x = ...
+		for _, declName := range declarationNamesTypescript(stmt) {
+			if declName.Content(program.Contents) == name {
+				return swapNodePtr(program, declName), nil
+			}
+		}
+
+		// export { x, y as z } from "./x"
+		if clause != nil {
+			for _, specifier := range children(clause) {
+				if specifier.Type() != "export_specifier" {
+					continue
+				}
+				local := specifier.ChildByFieldName("name")
+				if local == nil {
+					continue
+				}
+				exported := local
+				if alias := specifier.ChildByFieldName("alias"); alias != nil {
+					exported = alias
+				}
+				if exported.Content(program.Contents) != name {
+					continue
+				}
+				if source == nil {
+					return squirrel.getDefInModuleTypescript(ctx, program, local.Content(program.Contents))
+				}
+				module := squirrel.resolveModuleTypescript(ctx, program, source)
+				if module == nil {
+					return nil, nil
+				}
+				return squirrel.getExportTypescript(ctx, *module, local.Content(program.Contents), seen)
+			}
+			continue
+		}
+
+		// export * from "./x"
+		if source != nil && stmt.ChildByFieldName("declaration") == nil {
+			module := squirrel.resolveModuleTypescript(ctx, program, source)
+			if module == nil {
+				continue
+			}
+			found, err := squirrel.getExportTypescript(ctx, *module, name, seen)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveModuleTypescript parses the module imported with a relative import. Imports of packages
+// aren't supported.
+func (squirrel *SquirrelService) resolveModuleTypescript(ctx context.Context, program Node, source *sitter.Node) *Node {
+	specifier := strings.Trim(source.Content(program.Contents), "\"'`")
+	if !strings.HasPrefix(specifier, ".") {
+		return nil
+	}
+
+	path := filepath.Join(filepath.Dir(program.RepoCommitPath.Path), specifier)
+	if strings.HasPrefix(path, "..") {
+		return nil
+	}
+
+	var candidates []string
+	switch filepath.Ext(path) {
+	case ".ts", ".tsx":
+		candidates = []string{path}
+	case ".js", ".jsx":
+		// ES modules written in TypeScript import the compiled file.
+		base := strings.TrimSuffix(path, filepath.Ext(path))
+		candidates = []string{base + ".ts", base + ".tsx"}
+	default:
+		candidates = []string{path + ".ts", path + ".tsx", path + ".d.ts", path + "/index.ts", path + "/index.tsx"}
+	}
+
+	for _, candidate := range candidates {
+		module, _ := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   program.RepoCommitPath.Repo,
+			Commit: program.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if module != nil {
+			return module
+		}
+	}
+	return nil
+}
+
+// importSourceTypescript returns the module specifier of an import statement. The source field
+// isn't set by the grammar.
+func importSourceTypescript(stmt *sitter.Node) *sitter.Node {
+	for _, child := range children(stmt) {
+		if child.Type() == "string" {
+			return child
+		}
+	}
+	return nil
+}
+
+// declarationNamesTypescript returns the names declared by a top-level statement.
+func declarationNamesTypescript(stmt *sitter.Node) []*sitter.Node {
+	switch stmt.Type() {
+	case "export_statement":
+		if declaration := stmt.ChildByFieldName("declaration"); declaration != nil {
+			return declarationNamesTypescript(declaration)
+		}
+		if value := stmt.ChildByFieldName("value"); value != nil && value.Type() == "class" {
+			return declarationNamesTypescript(value)
+		}
+		return nil
+
+	case "lexical_declaration", "variable_declaration":
+		names := []*sitter.Node{}
+		for _, declarator := range children(stmt) {
+			if declarator.Type() != "variable_declarator" {
+				continue
+			}
+			if name := declarator.ChildByFieldName("name"); name != nil && name.Type() == "identifier" {
+				names = append(names, name)
+			}
+		}
+		return names
+
+	case "function_declaration",
+		"generator_function_declaration",
+		"function_signature",
+		"class_declaration",
+		"abstract_class_declaration",
+		"class",
+		"interface_declaration",
+		"type_alias_declaration",
+		"enum_declaration":
+		if name := stmt.ChildByFieldName("name"); name != nil {
+			return []*sitter.Node{name}
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/smacker/go-tree-sitter/javascript"
 	"github.com/smacker/go-tree-sitter/python"
 	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
 	"github.com/smacker/go-tree-sitter/typescript/tsx"
 )
 
@@ -124,6 +125,12 @@ var langToLangSpec = map[string]LangSpec{
 (short_var_declaration left: (expression_list (identifier) @definition)) ; x, y := ...
 (range_clause          left: (expression_list (identifier) @definition)) ; for i := range ... { ... }
 (receive_statement     left: (expression_list (identifier) @definition)) ; case x := <-ch: ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_declaration name: (identifier) @symbol))
+(source_file (type_declaration (type_spec name: (type_identifier) @symbol)))
+(source_file (var_declaration (var_spec name: (identifier) @symbol)))
+(source_file (const_declaration (const_spec name: (identifier) @symbol)))
 `,
 	},
 	"csharp": {
@@ -277,6 +284,28 @@ var langToLangSpec = map[string]LangSpec{
 (assignment           left: (identifier) @definition)    ; x = ...
 (left_assignment_list (identifier) @definition)          ; x, y = ...
 (for                  pattern: (identifier) @definition) ; for i in 1..5 ...
+`,
+	},
+	"rust": {
+		name:     "rust",
+		language: rust.GetLanguage(),
+		commentStyle: CommentStyle{
+			nodeTypes:     []string{"line_comment", "block_comment"},
+			stripRegex:    regexp.MustCompile(`^//[/!]?`),
+			codeFenceName: "rust",
+			skipNodeTypes: []string{"attribute_item"},
+		},
+		localsQuery: `
+(block)              @scope ; { ... }
+(function_item)      @scope ; fn f() { ... }
+(closure_expression) @scope ; |x| ...
+(for_expression)     @scope ; for x in xs { ... }
+
+(let_declaration pattern: (identifier) @definition)                 ; let x = ...
+(let_declaration pattern: (tuple_pattern (identifier) @definition)) ; let (x, y) = ...
+(parameter       pattern: (identifier) @definition)                 ; fn f(x: i32) { ... }
+(closure_parameters (identifier) @definition)                       ; |x| ...
+(for_expression  pattern: (identifier) @definition)                 ; for x in xs { ... }
 `,
 	},
 	"starlark": {
@@ -190,3 +190,58 @@ func prettyPrintLocalCodeIntelPayload(w io.Writer, payload types.LocalCodeIntelP
 		fmt.Fprintln(w)
 	}
 }
+
+// findLocalDef finds the definition of the identifier at node in the scopes that enclose it, as
+// described by the language's localsQuery. It returns nil if the identifier isn't defined locally.
+func findLocalDef(node Node) (*Node, error) {
+	ident := node.Content(node.Contents)
+	root := swapNode(node, getRoot(node.Node))
+
+	// Number the ancestors of the node, innermost first.
+	enclosing := map[NodeId]int{}
+	depth := 0
+	for cur := node.Node; cur != nil; cur = cur.Parent() {
+		if _, ok := enclosing[nodeId(cur)]; !ok {
+			enclosing[nodeId(cur)] = depth
+			depth++
+		}
+	}
+
+	// Collect scopes
+	scopes := map[NodeId]struct{}{}
+	err := forEachCapture(root.LangSpec.localsQuery, root, func(nameToNode map[string]Node) {
+		if scope, ok := nameToNode["scope"]; ok {
+			scopes[nodeId(scope.Node)] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the first def in the innermost enclosing scope.
+	var found *Node
+	foundDepth := depth
+	err = forEachCapture(root.LangSpec.localsQuery, root, func(nameToNode map[string]Node) {
+		for captureName, def := range nameToNode {
+			if !strings.HasPrefix(captureName, "definition") || def.Content(def.Contents) != ident {
+				continue
+			}
+			for cur := def.Node; cur != nil; cur = cur.Parent() {
+				if _, ok := scopes[nodeId(cur)]; !ok {
+					continue
+				}
+				if d, ok := enclosing[nodeId(cur)]; ok && d < foundDepth {
+					defCopy := def
+					found = &defCopy
+					foundDepth = d
+				}
+				break
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
@@ -54,36 +54,13 @@ func (squirrel *SquirrelService) Close() {
 // symbolInfo finds the symbol at the given point in a file.
 func (squirrel *SquirrelService) symbolInfo(ctx context.Context, point types.RepoCommitPathPoint) (*types.SymbolInfo, error) {
 	// First, find the definition.
-	var def *types.RepoCommitPathMaybeRange
-	{
-		// Parse the file and find the starting node.
-		root, err := squirrel.parse(ctx, point.RepoCommitPath)
-		if err != nil {
-			return nil, err
-		}
-		startNode := root.NamedDescendantForPointRange(
-			sitter.Point{Row: uint32(point.Row), Column: uint32(point.Column)},
-			sitter.Point{Row: uint32(point.Row), Column: uint32(point.Column)},
-		)
-		if startNode == nil {
-			return nil, errors.New("node is nil")
-		}
-
-		// Now find the definition.
-		found, err := squirrel.getDef(ctx, swapNode(*root, startNode))
-		if err != nil {
-			return nil, err
-		}
-		if found == nil {
-			return nil, nil
-		}
-		def = &types.RepoCommitPathMaybeRange{
-			RepoCommitPath: found.RepoCommitPath,
-		}
-		if found.Node != nil {
-			rnge := nodeToRange(found.Node)
-			def.Range = &rnge
-		}
+	root, err := squirrel.parse(ctx, point.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	def, err := squirrel.getDefAtPoint(ctx, *root, point.Point)
+	if err != nil {
+		return nil, err
 	}
 
 	if def == nil {
@@ -101,7 +78,7 @@ func (squirrel *SquirrelService) symbolInfo(ctx context.Context, point types.Rep
 	// Then get the hover if it exists.
 
 	// Parse the END file and find the end node.
-	root, err := squirrel.parse(ctx, def.RepoCommitPath)
+	root, err = squirrel.parse(ctx, def.RepoCommitPath)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +101,99 @@ func (squirrel *SquirrelService) symbolInfo(ctx context.Context, point types.Rep
 	}, nil
 }
 
+// symbolReferences finds the references to the symbol at the given point. The candidates are
+// usually the results of a word search for the name of the symbol, and only the ones that resolve
+// to the same definition are kept. The definition itself is included if it's a candidate.
+func (squirrel *SquirrelService) symbolReferences(ctx context.Context, args types.SymbolReferencesArgs) (*types.SymbolReferences, error) {
+	root, err := squirrel.parse(ctx, args.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	def, err := squirrel.getDefAtPoint(ctx, *root, args.Point)
+	if err != nil {
+		return nil, err
+	}
+	if def == nil {
+		return nil, nil
+	}
+
+	references := []types.RepoCommitPathRange{}
+	roots := map[types.RepoCommitPath]*Node{}
+	for _, candidate := range args.Candidates {
+		candidateRoot, ok := roots[candidate.RepoCommitPath]
+		if !ok {
+			candidateRoot, err = squirrel.parse(ctx, candidate.RepoCommitPath)
+			if err != nil {
+				if !errors.Is(err, unrecognizedFileExtensionError) && !errors.Is(err, unsupportedLanguageError) {
+					return nil, err
+				}
+				// Matches in other languages can't be references.
+				candidateRoot = nil
+			}
+			roots[candidate.RepoCommitPath] = candidateRoot
+		}
+		if candidateRoot == nil {
+			continue
+		}
+
+		if def.Range != nil && candidate.RepoCommitPath == def.RepoCommitPath && candidate.Row == def.Row && candidate.Column == def.Column {
+			references = append(references, candidate)
+			continue
+		}
+
+		candidateDef, err := squirrel.getDefAtPoint(ctx, *candidateRoot, types.Point{Row: candidate.Row, Column: candidate.Column})
+		if err != nil {
+			return nil, err
+		}
+		if candidateDef != nil && isSameDef(*def, *candidateDef) {
+			references = append(references, candidate)
+		}
+	}
+
+	return &types.SymbolReferences{
+		Definition: *def,
+		References: references,
+	}, nil
+}
+
+// getDefAtPoint finds the definition of the node at the given point in the file.
+func (squirrel *SquirrelService) getDefAtPoint(ctx context.Context, root Node, point types.Point) (*types.RepoCommitPathMaybeRange, error) {
+	startNode := root.NamedDescendantForPointRange(
+		sitter.Point{Row: uint32(point.Row), Column: uint32(point.Column)},
+		sitter.Point{Row: uint32(point.Row), Column: uint32(point.Column)},
+	)
+	if startNode == nil {
+		return nil, errors.New("node is nil")
+	}
+
+	found, err := squirrel.getDef(ctx, swapNode(root, startNode))
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, nil
+	}
+	def := &types.RepoCommitPathMaybeRange{
+		RepoCommitPath: found.RepoCommitPath,
+	}
+	if found.Node != nil {
+		rnge := nodeToRange(found.Node)
+		def.Range = &rnge
+	}
+	return def, nil
+}
+
+// isSameDef returns true if both definitions are at the same location. Directories have no range.
+func isSameDef(a, b types.RepoCommitPathMaybeRange) bool {
+	if a.RepoCommitPath != b.RepoCommitPath {
+		return false
+	}
+	if a.Range == nil || b.Range == nil {
+		return a.Range == nil && b.Range == nil
+	}
+	return *a.Range == *b.Range
+}
+
 // DirOrNode is a union type that can either be a directory or a node. It's returned by getDef().
 //
 // - It's usually   a Node, e.g. when finding the definition of an identifier
@@ -148,11 +218,15 @@ func (squirrel *SquirrelService) getDef(ctx context.Context, node Node) (*Node,
 		return squirrel.getDefStarlark(ctx, node)
 	case "python":
 		return squirrel.getDefPython(ctx, node)
-	// case "go":
+	case "go":
+		return squirrel.getDefGo(ctx, node)
+	case "typescript":
+		return squirrel.getDefTypescript(ctx, node)
+	case "rust":
+		return squirrel.getDefRust(ctx, node)
 	// case "csharp":
 	// case "python":
 	// case "javascript":
-	// case "typescript":
 	// case "cpp":
 	// case "ruby":
 	default:
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/fatih/color"
@@ -218,6 +219,77 @@ func TestNonLocalDefinition(t *testing.T) {
 		}
 	}
 
+	// Also test references. Like a word search, every annotated location in the repo with the same
+	// name is a candidate, and only the definition and the references of the symbol should be kept.
+	wordAt := func(point types.RepoCommitPathPoint) string {
+		contents, err := readFile(context.Background(), point.RepoCommitPath)
+		fatalIfErrorLabel(t, err, "reading a file")
+		line := strings.Split(string(contents), "\n")[point.Row]
+		return wordRegex.FindString(line[point.Column:])
+	}
+	for _, symbol := range symbols {
+		if solo != "" && symbol != solo {
+			continue
+		}
+		m := symbolToTagToAnnotations[symbol]
+		if len(m["def"]) != 1 || len(m["ref"]) == 0 {
+			continue
+		}
+		def := m["def"][0]
+		name := wordAt(def.repoCommitPathPoint)
+
+		candidates := []types.RepoCommitPathRange{}
+		for _, a := range annotations {
+			if a.repoCommitPathPoint.Repo != def.repoCommitPathPoint.Repo || contains(a.tags, "path") {
+				continue
+			}
+			if wordAt(a.repoCommitPathPoint) != name {
+				continue
+			}
+			candidates = append(candidates, types.RepoCommitPathRange{
+				RepoCommitPath: a.repoCommitPathPoint.RepoCommitPath,
+				Range:          types.Range{Row: a.repoCommitPathPoint.Row, Column: a.repoCommitPathPoint.Column, Length: len(name)},
+			})
+		}
+
+		want := []types.RepoCommitPathPoint{def.repoCommitPathPoint}
+		for _, ref := range m["ref"] {
+			if !contains(ref.tags, "nodef") && wordAt(ref.repoCommitPathPoint) == name {
+				want = append(want, ref.repoCommitPathPoint)
+			}
+		}
+
+		squirrel.breadcrumbs = Breadcrumbs{}
+		gotReferences, err := squirrel.symbolReferences(context.Background(), types.SymbolReferencesArgs{
+			RepoCommitPathPoint: m["ref"][0].repoCommitPathPoint,
+			Candidates:          candidates,
+		})
+		fatalIfErrorLabel(t, err, "symbolReferences")
+		if contains(m["ref"][0].tags, "nodef") {
+			continue
+		}
+		if gotReferences == nil {
+			squirrel.breadcrumbs.prettyPrint(squirrel.readFile)
+			t.Fatalf("no symbolReferences for symbol %s", symbol)
+		}
+
+		got := []types.RepoCommitPathPoint{}
+		for _, ref := range gotReferences.References {
+			got = append(got, types.RepoCommitPathPoint{
+				RepoCommitPath: ref.RepoCommitPath,
+				Point:          types.Point{Row: ref.Row, Column: ref.Column},
+			})
+		}
+
+		sortPoints(want)
+		sortPoints(got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong symbolReferences for %q (-want +got):\n%s", symbol, diff)
+		}
+
+		testCount += 1
+	}
+
 	t.Logf("%d tests in total", testCount)
 }
 
@@ -236,3 +308,18 @@ func groupBySymbolAndTag(annotations []annotation) map[string]map[string][]annot
 
 	return grouped
 }
+
+var wordRegex = regexp.MustCompile(`^\w+`)
+
+func sortPoints(points []types.RepoCommitPathPoint) {
+	sort.Slice(points, func(i, j int) bool {
+		a, b := points[i], points[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		return a.Column < b.Column
+	})
+}
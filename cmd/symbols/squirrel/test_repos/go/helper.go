@@ -0,0 +1,10 @@
+//go:build ignore
+
+package main
+
+func helper(s string) int { // < "helper" go.helper def < "s" go.helper.s def
+	//         v go.helper.s ref
+	return len(s)
+}
+
+var _ = helper // < "helper" go.helper ref
@@ -0,0 +1,21 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"example.com/app/util"
+	u2 "example.com/app/util"
+)
+
+func main() {
+	//   vvvv util path
+	//        vvvvvvv go.Reverse ref
+	s := util.Reverse("abc")
+	//          vvvvv go.Stack ref
+	var st util.Stack
+	//                    vvvvvvvv go.MaxDepth ref
+	//  vvvvvvv fmt.Println nodef
+	fmt.Println(s, st, u2.MaxDepth, helper(s))
+}
@@ -0,0 +1,13 @@
+//go:build ignore
+
+package util
+
+func Reverse(s string) string { // < "Reverse" go.Reverse def
+	runes := []rune(s)
+	//                                         vvvvvv go.swapAt ref
+	for i, j := 0, len(runes)-1; i < j; i, j = swapAt(runes, i, j) {
+	}
+	return string(runes)
+}
+
+var _ = MaxDepth // < "MaxDepth" go.MaxDepth ref
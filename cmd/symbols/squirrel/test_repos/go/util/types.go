@@ -0,0 +1,16 @@
+//go:build ignore
+
+package util
+
+type Stack struct { // < "Stack" go.Stack def
+	items []string
+}
+
+const (
+	MaxDepth = 10 // < "MaxDepth" go.MaxDepth def
+)
+
+func swapAt(runes []rune, i, j int) (int, int) { // < "swapAt" go.swapAt def
+	runes[i], runes[j] = runes[j], runes[i]
+	return i + 1, j - 1
+}
@@ -0,0 +1,38 @@
+//  vvvvvv rs.shapes def
+mod shapes;
+//      vvvv rs.util def
+pub mod util;
+
+//  vvvvvv rs.shapes ref
+//          vvvvvv rs.Circle ref
+use shapes::Circle;
+//         vvvv rs.util ref
+//                vvvvv rs.Color ref
+//                       vvvvvv rs.double ref
+use crate::util::{Color, double as twice};
+
+//  vvvvv rs.inner def
+mod inner {
+    //     vvvvv rs.inner.first def
+    pub fn first() -> u8 {
+        1
+    }
+}
+
+//     vvvvvvvvvv rs.total_area def
+pub fn total_area(radius: f64) -> f64 {
+    //              vvv rs.new ref
+    let c = Circle::new(radius); // < "c" rs.total_area.c def
+    //             vvv rs.Red ref
+    let _ = Color::Red;
+    //             vvvvv rs.inner.first ref
+    let _ = inner::first();
+    //                   vvvvv rs.inner.first ref
+    let _ = self::inner::first();
+    //      vvvvv rs.double ref
+    let _ = twice(c.radius);
+    //                   vvvvvv rs.double ref
+    let _ = crate::util::double(c.radius);
+    //            v rs.total_area.c ref
+    shapes::area(&c)
+}
@@ -0,0 +1,21 @@
+//               vvvvvv rs.double ref
+use super::util::double;
+
+//         vvvvvv rs.Circle def
+pub struct Circle {
+    pub radius: f64,
+}
+
+//   vvvvvv rs.Circle ref
+impl Circle {
+    //     vvv rs.new def
+    pub fn new(radius: f64) -> Self {
+        Circle { radius }
+    }
+}
+
+//     vvvv rs.area def
+pub fn area(c: &Circle) -> f64 {
+    //    vvvvvv rs.double ref
+    3.0 * double(c.radius)
+}
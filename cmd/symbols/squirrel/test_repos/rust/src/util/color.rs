@@ -0,0 +1,5 @@
+//       vvvvv rs.Color def
+pub enum Color {
+    Red, // < "Red" rs.Red def
+    Green,
+}
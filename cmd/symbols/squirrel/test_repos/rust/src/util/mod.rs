@@ -0,0 +1,11 @@
+//  vvvvv rs.color def
+mod color;
+
+//      vvvvv rs.color ref
+//             vvvvv rs.Color ref
+pub use color::Color;
+
+//     vvvvvv rs.double def
+pub fn double(x: f64) -> f64 {
+    x * 2.0
+}
@@ -0,0 +1,10 @@
+//              vvvvv ts.greet def
+export function greet(name: string): string {
+    return 'Hello, ' + name
+}
+
+//           vvvvvvv ts.Greeter def
+export class Greeter {}
+
+//                   vvvvvv ts.Logger def
+export default class Logger {}
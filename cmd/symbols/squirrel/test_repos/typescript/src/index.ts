@@ -0,0 +1,38 @@
+//       vvvvv ts.greet ref
+//              vvvvvvv ts.Greeter ref
+import { greet, Greeter as G } from './greeter'
+//     vvvvvv ts.Logger ref
+import Logger from './greeter'
+import * as shapes from './shapes'
+//       vvvvv ts.Color ref
+import { Color, Shade } from './util'
+import { readFileSync } from 'fs'
+
+//       vvvvvv ts.helper def
+//              v ts.helper.x def
+function helper(x: number): number {
+    //     v ts.helper.x ref
+    return x
+}
+
+//              vvvv ts.main def
+export function main() {
+    //              vvvvv ts.greet ref
+    const message = greet('world')
+    //                  v ts.Greeter ref
+    const greeter = new G()
+    //                 vvvvvv ts.Logger ref
+    const logger = new Logger()
+    return helper(shapes.area(2)) // < "helper" ts.helper ref < "area" ts.area ref
+}
+
+//                vvvvv ts.Shape ref
+let shape: shapes.Shape
+//          vvvvv ts.Color ref
+let color = Color.Red
+//         vvvvv ts.Shade ref
+let shade: Shade
+//           vvvvvvvvvvvv ts.readFileSync nodef
+const read = readFileSync
+//          vvvv ts.main ref
+const run = main
@@ -0,0 +1,5 @@
+//           vvvv ts.area def
+export const area = (r: number) => r * r
+
+//               vvvvv ts.Shape def
+export interface Shape {}
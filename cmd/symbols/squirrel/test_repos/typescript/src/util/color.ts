@@ -0,0 +1,7 @@
+//          vvvvv ts.Color def
+export enum Color {
+    Red,
+}
+
+//          vvvvv ts.Shade def
+export type Shade = Color
@@ -0,0 +1,3 @@
+//       vvvvv ts.Color ref
+export { Color } from './color'
+export * from './color'
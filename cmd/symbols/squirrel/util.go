@@ -176,6 +176,21 @@ func allCaptures(query string, node Node) ([]Node, error) {
 	return captures, nil
 }
 
+// findTopLevelSymbol finds a symbol with the given name in the file, using the language's
+// topLevelSymbolsQuery.
+func findTopLevelSymbol(root Node, ident string) (*Node, error) {
+	captures, err := allCaptures(root.LangSpec.topLevelSymbolsQuery, root)
+	if err != nil {
+		return nil, err
+	}
+	for _, capture := range captures {
+		if capture.Content(capture.Contents) == ident {
+			return &capture, nil
+		}
+	}
+	return nil, nil
+}
+
 func firstCapture(query string, node Node) (*Node, error) {
 	captures, err := allCaptures(query, node)
 	if err != nil {
@@ -9,9 +9,10 @@ import (
 type config struct {
 	env.BaseConfig
 
-	EncryptionInterval time.Duration
-	MetricsInterval    time.Duration
-	Decrypt            bool
+	EncryptionInterval        time.Duration
+	MetricsInterval           time.Duration
+	ReencryptionRetryInterval time.Duration
+	Decrypt                   bool
 }
 
 var ConfigInst = &config{}
@@ -19,5 +20,6 @@ var ConfigInst = &config{}
 func (c *config) Load() {
 	c.EncryptionInterval = c.GetInterval("RECORD_ENCRYPTER_INTERVAL", "1s", "How frequently to encrypt/decrypt a batch of records in the database.")
 	c.MetricsInterval = c.GetInterval("RECORD_ENCRYPTER_METRICS_INTERVAL", "10s", "How frequently to update progress metrics related to encryption/decryption.")
+	c.ReencryptionRetryInterval = c.GetInterval("RECORD_REENCRYPTER_RETRY_INTERVAL", "1h", "How long to wait before retrying to re-encrypt records that could not be decrypted with the current encryption key.")
 	c.Decrypt = c.GetBool("ALLOW_DECRYPTION", "false", "If true, encrypted records will be decrypted and stored in plaintext.")
 }
@@ -11,6 +11,7 @@ import (
 
 type recordCounter struct {
 	store   *database.RecordEncrypter
+	configs []database.EncryptionConfig
 	metrics *metrics
 	logger  log.Logger
 }
@@ -21,14 +22,27 @@ var (
 )
 
 func (c *recordCounter) Handle(ctx context.Context) (err error) {
-	for _, config := range database.EncryptionConfigs {
+	versions := keyVersions{}
+	for _, config := range c.configs {
 		numEncrypted, numUnencrypted, err := c.store.Count(ctx, config)
 		if err != nil {
 			return err
 		}
 
-		c.metrics.numEncryptedAtRest.WithLabelValues(config.TableName).Set(float64(numEncrypted))
-		c.metrics.numUnencryptedAtRest.WithLabelValues(config.TableName).Set(float64(numUnencrypted))
+		var numStale int
+		if key := config.Key(); key != nil {
+			version, err := versions.get(ctx, key)
+			if err != nil {
+				return err
+			}
+			if numStale, err = c.store.CountStale(ctx, config, version); err != nil {
+				return err
+			}
+		}
+
+		c.metrics.numEncryptedAtRest.WithLabelValues(config.Label()).Set(float64(numEncrypted))
+		c.metrics.numUnencryptedAtRest.WithLabelValues(config.Label()).Set(float64(numUnencrypted))
+		c.metrics.numStaleAtRest.WithLabelValues(config.Label()).Set(float64(numStale))
 	}
 
 	return err
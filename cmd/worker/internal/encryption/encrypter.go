@@ -3,19 +3,38 @@ package encryption
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type recordEncrypter struct {
 	store   *database.RecordEncrypter
+	configs []database.EncryptionConfig
 	decrypt bool
 	metrics *metrics
 	logger  log.Logger
+
+	// reencryptions tracks the progress of the re-encryption of stale records, by config label.
+	reencryptions map[string]*reencryption
+	// retryInterval is how long to wait before retrying records that failed to re-encrypt.
+	retryInterval time.Duration
+	now           func() time.Time
+}
+
+// reencryption is the progress of a pass over the stale records of a table.
+type reencryption struct {
+	// lastID is the ID of the last record handled in this pass.
+	lastID int
+	// failed is the number of records that failed to re-encrypt in this pass.
+	failed int
+	// nextPass is when the next pass starts, after a pass with failures.
+	nextPass time.Time
 }
 
 var (
@@ -24,8 +43,9 @@ var (
 )
 
 func (e *recordEncrypter) Handle(ctx context.Context) (err error) {
-	for _, config := range database.EncryptionConfigs {
-		if handleErr := e.handleBatch(ctx, config); handleErr != nil {
+	versions := keyVersions{}
+	for _, config := range e.configs {
+		if handleErr := e.handleBatch(ctx, config, versions); handleErr != nil {
 			err = errors.CombineErrors(err, handleErr)
 		}
 	}
@@ -33,12 +53,15 @@ func (e *recordEncrypter) Handle(ctx context.Context) (err error) {
 	return err
 }
 
-func (e *recordEncrypter) handleBatch(ctx context.Context, config database.EncryptionConfig) error {
+func (e *recordEncrypter) handleBatch(ctx context.Context, config database.EncryptionConfig, versions keyVersions) error {
 	if e.decrypt {
 		return e.handleDecryptBatch(ctx, config)
 	}
 
-	return e.handleEncryptBatch(ctx, config)
+	if err := e.handleEncryptBatch(ctx, config); err != nil {
+		return err
+	}
+	return e.handleReencryptBatch(ctx, config, versions)
 }
 
 func (e *recordEncrypter) handleEncryptBatch(ctx context.Context, config database.EncryptionConfig) error {
@@ -47,8 +70,8 @@ func (e *recordEncrypter) handleEncryptBatch(ctx context.Context, config databas
 		return err
 	}
 
-	e.metrics.numRecordsEncrypted.WithLabelValues(config.TableName).Add(float64(count))
-	e.logger.Debug("encrypted records", log.String("tableName", config.TableName), log.Int("count", count))
+	e.metrics.numRecordsEncrypted.WithLabelValues(config.Label()).Add(float64(count))
+	e.logger.Debug("encrypted records", log.String("tableName", config.Label()), log.Int("count", count))
 	return nil
 }
 
@@ -58,8 +81,55 @@ func (e *recordEncrypter) handleDecryptBatch(ctx context.Context, config databas
 		return err
 	}
 
-	e.metrics.numRecordsDecrypted.WithLabelValues(config.TableName).Add(float64(count))
-	e.logger.Debug("decrypted records", log.String("tableName", config.TableName), log.Int("count", count))
+	e.metrics.numRecordsDecrypted.WithLabelValues(config.Label()).Add(float64(count))
+	e.logger.Debug("decrypted records", log.String("tableName", config.Label()), log.Int("count", count))
+	return nil
+}
+
+// handleReencryptBatch re-encrypts a batch of records encrypted with a previous version of
+// their key. Records that cannot be decrypted are skipped until the next pass over the table.
+func (e *recordEncrypter) handleReencryptBatch(ctx context.Context, config database.EncryptionConfig, versions keyVersions) error {
+	key := config.Key()
+	if key == nil {
+		return nil
+	}
+	r, ok := e.reencryptions[config.Label()]
+	if !ok {
+		r = &reencryption{}
+		e.reencryptions[config.Label()] = r
+	}
+	if e.now().Before(r.nextPass) {
+		return nil
+	}
+
+	version, err := versions.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	batch, err := e.store.ReencryptBatch(ctx, config, version, r.lastID)
+	if err != nil {
+		return err
+	}
+
+	e.metrics.numRecordsReencrypted.WithLabelValues(config.Label()).Add(float64(batch.Reencrypted))
+	e.metrics.numReencryptionFailures.WithLabelValues(config.Label()).Add(float64(batch.Failed))
+	if batch.Reencrypted > 0 {
+		e.logger.Debug("re-encrypted records", log.String("tableName", config.Label()), log.Int("count", batch.Reencrypted))
+	}
+
+	r.failed += batch.Failed
+	r.lastID = batch.LastID
+	if batch.LastID == 0 {
+		// The pass is over, start the next one from the first stale record.
+		if r.failed > 0 {
+			e.logger.Warn("failed to decrypt records to re-encrypt them, they may be encrypted with a key that is not configured in encryption.keys.decryptionKeys",
+				log.String("tableName", config.Label()),
+				log.Int("count", r.failed),
+				log.Duration("retryIn", e.retryInterval))
+			r.nextPass = e.now().Add(e.retryInterval)
+		}
+		r.failed = 0
+	}
 	return nil
 }
 
@@ -72,3 +142,20 @@ func (m *recordEncrypter) HandleError(err error) {
 	m.metrics.numErrors.Add(1)
 	m.logger.Error(fmt.Sprintf("failed to %s batch of records", verb), log.Error(err))
 }
+
+// keyVersions caches the current versions of encryption keys for a single pass over the
+// tables. Resolving the version of a key is a remote call for most key types, so it is
+// resolved at most once per pass, and shared by the tables encrypted with the same key.
+type keyVersions map[encryption.Key]encryption.KeyVersion
+
+func (v keyVersions) get(ctx context.Context, key encryption.Key) (encryption.KeyVersion, error) {
+	if version, ok := v[key]; ok {
+		return version, nil
+	}
+	version, err := key.Version(ctx)
+	if err != nil {
+		return version, errors.Wrap(err, "failed to get encryption key version")
+	}
+	v[key] = version
+	return version, nil
+}
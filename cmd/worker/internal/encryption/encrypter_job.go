@@ -2,6 +2,9 @@ package encryption
 
 import (
 	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
@@ -34,23 +37,43 @@ func (j *recordEncrypterJob) Routines(startupCtx context.Context, observationCtx
 	if err != nil {
 		return nil, err
 	}
-	store := database.NewRecordEncrypter(db)
+	routines := newRoutines("encryption", database.NewRecordEncrypter(db), database.EncryptionConfigs, metrics, observationCtx.Logger)
+
+	// The tables of other databases are handled with their own connection. Failing to
+	// connect to one must not prevent the other tables from being handled.
+	for _, encryptedDB := range database.EncryptedDatabases() {
+		otherDB, err := encryptedDB.Open(observationCtx)
+		if err != nil {
+			observationCtx.Logger.Warn("failed to connect to database, its records will not be encrypted", log.String("database", encryptedDB.Name), log.Error(err))
+			continue
+		}
+		routines = append(routines, newRoutines("encryption."+encryptedDB.Name, database.NewRecordEncrypter(otherDB), encryptedDB.Configs, metrics, observationCtx.Logger)...)
+	}
+
+	return routines, nil
+}
 
+func newRoutines(namePrefix string, store *database.RecordEncrypter, configs []database.EncryptionConfig, metrics *metrics, logger log.Logger) []goroutine.BackgroundRoutine {
 	return []goroutine.BackgroundRoutine{
-		goroutine.NewPeriodicGoroutine(context.Background(), "encryption.record-encrypter", "encrypts/decrypts existing data when a key is provided/removed",
+		goroutine.NewPeriodicGoroutine(context.Background(), namePrefix+".record-encrypter", "encrypts/decrypts existing data when a key is provided/removed, and re-encrypts it when a key is rotated",
 			ConfigInst.EncryptionInterval, &recordEncrypter{
-				store:   store,
-				decrypt: ConfigInst.Decrypt,
-				metrics: metrics,
-				logger:  observationCtx.Logger,
+				store:         store,
+				configs:       configs,
+				decrypt:       ConfigInst.Decrypt,
+				metrics:       metrics,
+				logger:        logger,
+				reencryptions: map[string]*reencryption{},
+				retryInterval: ConfigInst.ReencryptionRetryInterval,
+				now:           time.Now,
 			},
 		),
-		goroutine.NewPeriodicGoroutine(context.Background(), "encryption.operation-metrics", "tracks number of encrypted vs unencrypted records",
+		goroutine.NewPeriodicGoroutine(context.Background(), namePrefix+".operation-metrics", "tracks number of encrypted vs unencrypted records",
 			ConfigInst.MetricsInterval, &recordCounter{
 				store:   store,
+				configs: configs,
 				metrics: metrics,
-				logger:  observationCtx.Logger,
+				logger:  logger,
 			},
 		),
-	}, nil
+	}
 }
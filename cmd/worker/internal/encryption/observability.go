@@ -11,11 +11,14 @@ type metrics struct {
 	// current state
 	numEncryptedAtRest   *prometheus.GaugeVec
 	numUnencryptedAtRest *prometheus.GaugeVec
+	numStaleAtRest       *prometheus.GaugeVec
 
 	// processing status
-	numRecordsEncrypted *prometheus.CounterVec
-	numRecordsDecrypted *prometheus.CounterVec
-	numErrors           prometheus.Counter
+	numRecordsEncrypted     *prometheus.CounterVec
+	numRecordsDecrypted     *prometheus.CounterVec
+	numRecordsReencrypted   *prometheus.CounterVec
+	numReencryptionFailures *prometheus.CounterVec
+	numErrors               prometheus.Counter
 }
 
 func newMetrics(observationCtx *observation.Context) *metrics {
@@ -57,6 +60,10 @@ func newMetrics(observationCtx *observation.Context) *metrics {
 		"src_records_unencrypted_at_rest_total",
 		"The number of database records unencrypted at rest.",
 	)
+	numStaleAtRest := gaugeVec(
+		"src_records_stale_encryption_key_total",
+		"The number of database records encrypted with a previous version of their encryption key.",
+	)
 	numRecordsEncrypted := counterVec(
 		"src_records_encrypted_total",
 		"The number of unencrypted database records that have been encrypted.",
@@ -65,22 +72,41 @@ func newMetrics(observationCtx *observation.Context) *metrics {
 		"src_records_decrypted_total",
 		"The number of encrypted database records that have been decrypted.",
 	)
+	numRecordsReencrypted := counterVec(
+		"src_records_reencrypted_total",
+		"The number of database records that have been re-encrypted with the current version of their encryption key.",
+	)
+	numReencryptionFailures := counterVec(
+		"src_record_reencryption_failures_total",
+		"The number of database records that could not be decrypted to be re-encrypted.",
+	)
 	numErrors := counter(
 		"src_record_encryption_errors_total",
 		"The number of errors that occur during record encryption/decryption.",
 	)
 
-	for _, config := range database.EncryptionConfigs {
-		// Initialize counters to zero
-		numRecordsEncrypted.WithLabelValues(config.TableName).Add(0)
-		numRecordsDecrypted.WithLabelValues(config.TableName).Add(0)
+	allConfigs := [][]database.EncryptionConfig{database.EncryptionConfigs}
+	for _, db := range database.EncryptedDatabases() {
+		allConfigs = append(allConfigs, db.Configs)
+	}
+	for _, configs := range allConfigs {
+		for _, config := range configs {
+			// Initialize counters to zero
+			numRecordsEncrypted.WithLabelValues(config.Label()).Add(0)
+			numRecordsDecrypted.WithLabelValues(config.Label()).Add(0)
+			numRecordsReencrypted.WithLabelValues(config.Label()).Add(0)
+			numReencryptionFailures.WithLabelValues(config.Label()).Add(0)
+		}
 	}
 
 	return &metrics{
-		numEncryptedAtRest:   numEncryptedAtRest,
-		numUnencryptedAtRest: numUnencryptedAtRest,
-		numRecordsEncrypted:  numRecordsEncrypted,
-		numRecordsDecrypted:  numRecordsDecrypted,
-		numErrors:            numErrors,
+		numEncryptedAtRest:      numEncryptedAtRest,
+		numUnencryptedAtRest:    numUnencryptedAtRest,
+		numStaleAtRest:          numStaleAtRest,
+		numRecordsEncrypted:     numRecordsEncrypted,
+		numRecordsDecrypted:     numRecordsDecrypted,
+		numRecordsReencrypted:   numRecordsReencrypted,
+		numReencryptionFailures: numReencryptionFailures,
+		numErrors:               numErrors,
 	}
 }
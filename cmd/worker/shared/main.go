@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	internalgitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/httpserver"
 	"github.com/sourcegraph/sourcegraph/internal/logging"
@@ -114,6 +115,7 @@ func Start(observationCtx *observation.Context, additionalJobs map[string]job.Jo
 		Handler:      httpserver.NewHandler(nil),
 	})
 	allRoutines = append(allRoutines, server)
+	allRoutines = append(allRoutines, internalgitserver.ClientCacheRoutines()...)
 
 	// We're all set up now
 	// Respond positively to ready checks
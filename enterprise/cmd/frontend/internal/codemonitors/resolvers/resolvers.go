@@ -3,6 +3,8 @@ package resolvers
 import (
 	"context"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -269,6 +271,7 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 				IncludeResults: a.Email.IncludeResults,
 				Priority:       a.Email.Priority,
 				Header:         a.Email.Header,
+				DigestInterval: toDigestInterval(a.Email.DigestInterval),
 			})
 			if err != nil {
 				return err
@@ -278,7 +281,11 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 				return err
 			}
 		case a.Webhook != nil:
-			_, err := r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, a.Webhook.Enabled, a.Webhook.IncludeResults, a.Webhook.URL)
+			webhookArgs, err := webhookActionArgs(ctx, a.Webhook, nil)
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, webhookArgs)
 			if err != nil {
 				return err
 			}
@@ -286,19 +293,36 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err := validateSlackURL(a.SlackWebhook.URL); err != nil {
 				return err
 			}
-			_, err := r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, a.SlackWebhook.URL)
+			_, err := r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, toDigestInterval(a.SlackWebhook.DigestInterval), a.SlackWebhook.URL)
+			if err != nil {
+				return err
+			}
+		case a.TeamsWebhook != nil:
+			if err := validateTeamsURL(a.TeamsWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTeamsWebhookAction(ctx, monitorID, a.TeamsWebhook.Enabled, a.TeamsWebhook.IncludeResults, a.TeamsWebhook.URL)
+			if err != nil {
+				return err
+			}
+		case a.ChatWebhook != nil:
+			service := edb.ChatWebhookService(a.ChatWebhook.Service)
+			if err := validateChatURL(service, a.ChatWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateChatWebhookAction(ctx, monitorID, a.ChatWebhook.Enabled, a.ChatWebhook.IncludeResults, service, a.ChatWebhook.URL)
 			if err != nil {
 				return err
 			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, TeamsWebhook, or ChatWebhook must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, teamsWebhook, chatWebhook []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -313,8 +337,12 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionTeamsWebhookKind:
+			teamsWebhook = append(teamsWebhook, intID)
+		case monitorActionChatWebhookKind:
+			chatWebhook = append(chatWebhook, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, teams webhook, or chat webhook")
 		}
 	}
 
@@ -330,6 +358,14 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteTeamsWebhookActions(ctx, monitorID, teamsWebhook...); err != nil {
+		return err
+	}
+
+	if err := r.db.CodeMonitors().DeleteChatWebhookActions(ctx, monitorID, chatWebhook...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -390,7 +426,19 @@ func (r *Resolver) TriggerTestWebhookAction(ctx context.Context, args *graphqlba
 		return nil, err
 	}
 
-	if err := background.SendTestWebhook(ctx, httpcli.ExternalDoer, args.Description, args.Webhook.URL); err != nil {
+	webhookArgs, err := webhookActionArgs(ctx, args.Webhook, nil)
+	if err != nil {
+		return nil, err
+	}
+	opts := background.WebhookOptions{Headers: webhookArgs.Headers}
+	if webhookArgs.PayloadTemplate != nil {
+		opts.PayloadTemplate = *webhookArgs.PayloadTemplate
+	}
+	if webhookArgs.Secret != nil {
+		opts.Secret = *webhookArgs.Secret
+	}
+
+	if err := background.SendTestWebhook(ctx, httpcli.ExternalDoer, args.Description, args.Webhook.URL, opts); err != nil {
 		return nil, err
 	}
 
@@ -410,6 +458,41 @@ func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *grap
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) TriggerTestTeamsWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTeamsWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTeamsURL(args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestTeamsWebhook(ctx, httpcli.ExternalDoer, args.Description, args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) TriggerTestChatWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestChatWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	service := edb.ChatWebhookService(args.ChatWebhook.Service)
+	if err := validateChatURL(service, args.ChatWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestChatWebhook(ctx, httpcli.ExternalDoer, service, args.Description, args.ChatWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func sendTestEmail(ctx context.Context, db database.DB, recipient graphql.ID, description string) error {
 	var (
 		userID int32
@@ -441,7 +524,15 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	teamsWebhookActions, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	chatWebhookActions, err := r.db.CodeMonitors().ListChatWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(teamsWebhookActions)+len(chatWebhookActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -451,6 +542,12 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, teamsWebhookAction := range teamsWebhookActions {
+		ids = append(ids, (&monitorTeamsWebhook{TeamsWebhookAction: teamsWebhookAction}).ID())
+	}
+	for _, chatWebhookAction := range chatWebhookActions {
+		ids = append(ids, (&monitorChatWebhook{ChatWebhookAction: chatWebhookAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -495,6 +592,26 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.TeamsWebhook != nil:
+			if a.TeamsWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TeamsWebhook: a.TeamsWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TeamsWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TeamsWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TeamsWebhook.Id)
+		case a.ChatWebhook != nil:
+			if a.ChatWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{ChatWebhook: a.ChatWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.ChatWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.ChatWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.ChatWebhook.Id)
 		}
 	}
 
@@ -579,8 +696,18 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.TeamsWebhook != nil:
+			if err := validateTeamsURL(action.TeamsWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateTeamsWebhookAction(ctx, *action.TeamsWebhook)
+		case action.ChatWebhook != nil:
+			if err := validateChatURL(edb.ChatWebhookService(action.ChatWebhook.Update.Service), action.ChatWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateChatWebhookAction(ctx, *action.ChatWebhook)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, teams webhook, or chat webhook")
 		}
 		if err != nil {
 			return nil, err
@@ -607,6 +734,7 @@ func (r *Resolver) updateEmailAction(ctx context.Context, args graphqlbackend.Ed
 		IncludeResults: args.Update.IncludeResults,
 		Priority:       args.Update.Priority,
 		Header:         args.Update.Header,
+		DigestInterval: toDigestInterval(args.Update.DigestInterval),
 	})
 	if err != nil {
 		return err
@@ -621,10 +749,65 @@ func (r *Resolver) updateWebhookAction(ctx context.Context, args graphqlbackend.
 		return err
 	}
 
-	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	existing, err := r.db.CodeMonitors().GetWebhookAction(ctx, id)
+	if err != nil {
+		return err
+	}
+	webhookArgs, err := webhookActionArgs(ctx, args.Update, existing)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, webhookArgs)
 	return err
 }
 
+// webhookActionArgs validates args and converts them to the arguments of the
+// store. Headers and secret that aren't set in args are taken from existing,
+// if not nil.
+func webhookActionArgs(ctx context.Context, args *graphqlbackend.CreateActionWebhookArgs, existing *edb.WebhookAction) (*edb.WebhookActionArgs, error) {
+	out := &edb.WebhookActionArgs{
+		Enabled:         args.Enabled,
+		IncludeResults:  args.IncludeResults,
+		URL:             args.URL,
+		PayloadTemplate: args.PayloadTemplate,
+	}
+
+	if args.PayloadTemplate != nil {
+		if err := background.ValidateWebhookPayloadTemplate(*args.PayloadTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	if args.Headers != nil {
+		out.Headers = make(map[string]string, len(*args.Headers))
+		for _, h := range *args.Headers {
+			if h.Name == "" {
+				return nil, errors.New("webhook header names must not be empty")
+			}
+			out.Headers[h.Name] = h.Value
+		}
+	} else if existing != nil && existing.Headers != nil {
+		headers, err := existing.Headers.Decrypt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out.Headers = headers
+	}
+
+	if args.Secret != nil {
+		out.Secret = args.Secret
+	} else if existing != nil && existing.Secret != nil {
+		secret, err := existing.Secret.Decrypt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out.Secret = &secret
+	}
+
+	return out, nil
+}
+
 func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbackend.EditActionSlackWebhookArgs) error {
 	var id int64
 	err := relay.UnmarshalSpec(*args.Id, &id)
@@ -632,7 +815,29 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 		return err
 	}
 
-	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, toDigestInterval(args.Update.DigestInterval), args.Update.URL)
+	return err
+}
+
+func (r *Resolver) updateTeamsWebhookAction(ctx context.Context, args graphqlbackend.EditActionTeamsWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateTeamsWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	return err
+}
+
+func (r *Resolver) updateChatWebhookAction(ctx context.Context, args graphqlbackend.EditActionChatWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateChatWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, edb.ChatWebhookService(args.Update.Service), args.Update.URL)
 	return err
 }
 
@@ -721,6 +926,8 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionTeamsWebhookKind      = "CodeMonitorActionTeamsWebhook"
+	monitorActionChatWebhookKind       = "CodeMonitorActionChatWebhook"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
@@ -816,7 +1023,17 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	tws, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	cws, err := r.db.CodeMonitors().ListChatWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(tws)+len(cws))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -844,6 +1061,24 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, tw := range tws {
+		actions = append(actions, &action{
+			teamsWebhook: &monitorTeamsWebhook{
+				Resolver:           r,
+				TeamsWebhookAction: tw,
+				triggerEventID:     triggerEventID,
+			},
+		})
+	}
+	for _, cw := range cws {
+		actions = append(actions, &action{
+			chatWebhook: &monitorChatWebhook{
+				Resolver:          r,
+				ChatWebhookAction: cw,
+				triggerEventID:    triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -1020,6 +1255,8 @@ type action struct {
 	email        graphqlbackend.MonitorEmailResolver
 	webhook      graphqlbackend.MonitorWebhookResolver
 	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	teamsWebhook graphqlbackend.MonitorTeamsWebhookResolver
+	chatWebhook  graphqlbackend.MonitorChatWebhookResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1030,6 +1267,10 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.teamsWebhook != nil:
+		return a.teamsWebhook.ID()
+	case a.chatWebhook != nil:
+		return a.chatWebhook.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1047,6 +1288,14 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorTeamsWebhook() (graphqlbackend.MonitorTeamsWebhookResolver, bool) {
+	return a.teamsWebhook, a.teamsWebhook != nil
+}
+
+func (a *action) ToMonitorChatWebhook() (graphqlbackend.MonitorChatWebhookResolver, bool) {
+	return a.chatWebhook, a.chatWebhook != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1116,6 +1365,10 @@ func (m *monitorEmail) Header() string {
 	return m.EmailAction.Header
 }
 
+func (m *monitorEmail) DigestInterval() *string {
+	return fromDigestInterval(m.EmailAction.DigestInterval)
+}
+
 func (m *monitorEmail) ID() graphql.ID {
 	return relay.MarshalID(monitorActionEmailKind, m.EmailAction.ID)
 }
@@ -1172,8 +1425,32 @@ func (m *monitorWebhook) IncludeResults() bool {
 	return m.WebhookAction.IncludeResults
 }
 
-func (m *monitorWebhook) URL() string {
-	return m.WebhookAction.URL
+func (m *monitorWebhook) URL(ctx context.Context) (string, error) {
+	return m.WebhookAction.URL.Decrypt(ctx)
+}
+
+func (m *monitorWebhook) PayloadTemplate() *string {
+	return m.WebhookAction.PayloadTemplate
+}
+
+func (m *monitorWebhook) HeaderNames(ctx context.Context) ([]string, error) {
+	if m.WebhookAction.Headers == nil {
+		return []string{}, nil
+	}
+	headers, err := m.WebhookAction.Headers.Decrypt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m *monitorWebhook) HasSecret() bool {
+	return m.WebhookAction.Secret != nil
 }
 
 func (m *monitorWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
@@ -1228,8 +1505,12 @@ func (m *monitorSlackWebhook) IncludeResults() bool {
 	return m.SlackWebhookAction.IncludeResults
 }
 
-func (m *monitorSlackWebhook) URL() string {
-	return m.SlackWebhookAction.URL
+func (m *monitorSlackWebhook) URL(ctx context.Context) (string, error) {
+	return m.SlackWebhookAction.URL.Decrypt(ctx)
+}
+
+func (m *monitorSlackWebhook) DigestInterval() *string {
+	return fromDigestInterval(m.SlackWebhookAction.DigestInterval)
 }
 
 func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
@@ -1262,6 +1543,122 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorTeamsWebhook struct {
+	*Resolver
+	*edb.TeamsWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTeamsWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTeamsWebhookKind, m.TeamsWebhookAction.ID)
+}
+
+func (m *monitorTeamsWebhook) Enabled() bool {
+	return m.TeamsWebhookAction.Enabled
+}
+
+func (m *monitorTeamsWebhook) IncludeResults() bool {
+	return m.TeamsWebhookAction.IncludeResults
+}
+
+func (m *monitorTeamsWebhook) URL(ctx context.Context) (string, error) {
+	return m.TeamsWebhookAction.URL.Decrypt(ctx)
+}
+
+func (m *monitorTeamsWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		TeamsWebhookID: intPtr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          intPtr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		TeamsWebhookID: intPtr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
+type monitorChatWebhook struct {
+	*Resolver
+	*edb.ChatWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorChatWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionChatWebhookKind, m.ChatWebhookAction.ID)
+}
+
+func (m *monitorChatWebhook) Enabled() bool {
+	return m.ChatWebhookAction.Enabled
+}
+
+func (m *monitorChatWebhook) IncludeResults() bool {
+	return m.ChatWebhookAction.IncludeResults
+}
+
+func (m *monitorChatWebhook) Service() string {
+	return string(m.ChatWebhookAction.Service)
+}
+
+func (m *monitorChatWebhook) URL(ctx context.Context) (string, error) {
+	return m.ChatWebhookAction.URL.Decrypt(ctx)
+}
+
+func (m *monitorChatWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		ChatWebhookID:  intPtr(int(m.ChatWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          intPtr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		ChatWebhookID:  intPtr(int(m.ChatWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtr(i int) *int { return &i }
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
@@ -1355,3 +1752,56 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+func validateTeamsURL(urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	// Restrict Teams webhooks to HTTPS URLs of incoming webhooks and of Power
+	// Automate workflows, which replace them.
+	if u.Scheme != "https" || !(strings.HasSuffix(u.Hostname(), ".webhook.office.com") || strings.HasSuffix(u.Hostname(), ".logic.azure.com")) {
+		return errors.New("webhook URL for Microsoft Teams must be an HTTPS URL on webhook.office.com or logic.azure.com")
+	}
+	return nil
+}
+
+func validateChatURL(service edb.ChatWebhookService, urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	switch service {
+	case edb.ChatWebhookServiceMattermost:
+		// Mattermost is usually self-hosted, so any host is allowed.
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return errors.New("webhook URL for Mattermost must be an HTTP or HTTPS URL")
+		}
+	case edb.ChatWebhookServiceDiscord:
+		if u.Scheme != "https" || (u.Host != "discord.com" && u.Host != "discordapp.com") || !strings.HasPrefix(u.Path, "/api/webhooks/") {
+			return errors.New("webhook URL for Discord must begin with 'https://discord.com/api/webhooks/'")
+		}
+	default:
+		return errors.Errorf("unknown chat service %q", service)
+	}
+	return nil
+}
+
+// toDigestInterval converts an optional MonitorDigestInterval to the interval
+// of an action, which sends a notification for every event if unset.
+func toDigestInterval(interval *string) edb.DigestInterval {
+	if interval == nil {
+		return edb.DigestIntervalNone
+	}
+	return edb.DigestInterval(*interval)
+}
+
+func fromDigestInterval(interval edb.DigestInterval) *string {
+	if interval == edb.DigestIntervalNone {
+		return nil
+	}
+	s := string(interval)
+	return &s
+}
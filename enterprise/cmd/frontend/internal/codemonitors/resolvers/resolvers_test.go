@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -1322,3 +1323,108 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestValidateTeamsURL(t *testing.T) {
+	valid := []string{
+		"https://contoso.webhook.office.com/webhookb2/8d8d8/IncomingWebhook/838383",
+		"https://prod-00.westus.logic.azure.com:443/workflows/8d8d8",
+	}
+
+	for _, url := range valid {
+		require.NoError(t, validateTeamsURL(url))
+	}
+
+	invalid := []string{
+		"http://contoso.webhook.office.com/webhookb2",
+		"https://webhook.office.com.internal/webhookb2",
+		"https://internal:8989",
+	}
+
+	for _, url := range invalid {
+		require.Error(t, validateTeamsURL(url))
+	}
+}
+
+func TestValidateChatURL(t *testing.T) {
+	valid := []struct {
+		service edb.ChatWebhookService
+		url     string
+	}{
+		{edb.ChatWebhookServiceMattermost, "https://mattermost.example.com/hooks/8d8d8"},
+		{edb.ChatWebhookServiceMattermost, "http://mattermost.internal:8065/hooks/8d8d8"},
+		{edb.ChatWebhookServiceDiscord, "https://discord.com/api/webhooks/8d8d8/838383"},
+	}
+
+	for _, tc := range valid {
+		require.NoError(t, validateChatURL(tc.service, tc.url))
+	}
+
+	invalid := []struct {
+		service edb.ChatWebhookService
+		url     string
+	}{
+		{edb.ChatWebhookServiceMattermost, "ftp://mattermost.example.com/hooks/8d8d8"},
+		{edb.ChatWebhookServiceDiscord, "http://discord.com/api/webhooks/8d8d8/838383"},
+		{edb.ChatWebhookServiceDiscord, "https://discord.com/channels/8d8d8"},
+		{edb.ChatWebhookServiceDiscord, "https://internal:8989/api/webhooks/8d8d8"},
+		{"IRC", "https://irc.example.com"},
+	}
+
+	for _, tc := range invalid {
+		require.Error(t, validateChatURL(tc.service, tc.url))
+	}
+}
+
+func TestWebhookActionArgs(t *testing.T) {
+	ctx := context.Background()
+	tmpl := `{"text": {{ json .MonitorDescription }}}`
+	secret := "hunter2"
+
+	t.Run("create", func(t *testing.T) {
+		got, err := webhookActionArgs(ctx, &graphqlbackend.CreateActionWebhookArgs{
+			Enabled:         true,
+			URL:             "https://example.com",
+			PayloadTemplate: &tmpl,
+			Headers:         &[]graphqlbackend.MonitorWebhookHeaderInput{{Name: "Authorization", Value: "Bearer token"}},
+			Secret:          &secret,
+		}, nil)
+		require.NoError(t, err)
+		require.Equal(t, &edb.WebhookActionArgs{
+			Enabled:         true,
+			URL:             "https://example.com",
+			PayloadTemplate: &tmpl,
+			Headers:         map[string]string{"Authorization": "Bearer token"},
+			Secret:          &secret,
+		}, got)
+	})
+
+	t.Run("update keeps existing headers and secret", func(t *testing.T) {
+		headers, err := encryption.NewUnencryptedJSON(map[string]string{"Authorization": "Bearer token"})
+		require.NoError(t, err)
+		existing := &edb.WebhookAction{
+			Headers: headers,
+			Secret:  encryption.NewUnencrypted(secret),
+		}
+
+		got, err := webhookActionArgs(ctx, &graphqlbackend.CreateActionWebhookArgs{URL: "https://example.com"}, existing)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"Authorization": "Bearer token"}, got.Headers)
+		require.Equal(t, &secret, got.Secret)
+
+		empty := ""
+		got, err = webhookActionArgs(ctx, &graphqlbackend.CreateActionWebhookArgs{
+			URL:     "https://example.com",
+			Headers: &[]graphqlbackend.MonitorWebhookHeaderInput{},
+			Secret:  &empty,
+		}, existing)
+		require.NoError(t, err)
+		require.Empty(t, got.Headers)
+		require.Equal(t, &empty, got.Secret)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		invalid := "{{ .DoesNotExist }}"
+		_, err := webhookActionArgs(ctx, &graphqlbackend.CreateActionWebhookArgs{PayloadTemplate: &invalid}, nil)
+		require.Error(t, err)
+	})
+}
@@ -2,7 +2,10 @@ package resolvers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/log"
@@ -11,10 +14,12 @@ import (
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func NewResolver(logger log.Logger, db database.DB) gql.ComputeResolver {
@@ -167,6 +172,17 @@ func toComputeResultResolver(result compute.Result, repoResolver *gql.Repository
 		return &computeResultResolver{result: toComputeMatchContextResolver(r, repoResolver, path, commit)}
 	case *compute.Text:
 		return &computeResultResolver{result: toComputeTextResolver(r, repoResolver, path, commit)}
+	case *compute.FileDiff:
+		return &computeResultResolver{result: toComputeTextResolver(&compute.Text{Value: r.Value, Kind: r.Kind}, repoResolver, path, commit)}
+	case *compute.Table:
+		// Tables have no dedicated GraphQL type, so rows are returned as
+		// JSON lines like the streaming endpoint's jsonl format.
+		var b strings.Builder
+		enc := json.NewEncoder(&b)
+		for _, row := range r.Rows {
+			_ = enc.Encode(row)
+		}
+		return &computeResultResolver{result: toComputeTextResolver(&compute.Text{Value: b.String(), Kind: "extract"}, repoResolver, path, commit)}
 	default:
 		panic(fmt.Sprintf("unsupported compute result %T", r))
 	}
@@ -220,33 +236,107 @@ func toResultResolverList(ctx context.Context, cmd compute.Command, matches []re
 	return results, nil
 }
 
-// NewBatchComputeImplementer is a function that abstracts away the need to have a
-// handle on (*schemaResolver) Compute.
-func NewBatchComputeImplementer(ctx context.Context, logger log.Logger, db database.DB, args *gql.ComputeArgs) ([]gql.ComputeResultResolver, error) {
-	computeQuery, err := compute.Parse(args.Query)
+// searchComputeQuery parses a compute query and returns it along with the
+// matches of its search query.
+func searchComputeQuery(ctx context.Context, logger log.Logger, db database.DB, query string) (*compute.Query, []result.Match, error) {
+	computeQuery, err := compute.Parse(query)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	searchQuery, err := computeQuery.ToSearchQuery()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	log15.Debug("compute", "search", searchQuery)
 
 	patternType := "regexp"
 	job, err := gql.NewBatchSearchImplementer(ctx, logger, db, &gql.SearchArgs{Query: searchQuery, PatternType: &patternType})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	results, err := job.Results(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return computeQuery, results.Matches, nil
+}
+
+// NewBatchComputeImplementer is a function that abstracts away the need to have a
+// handle on (*schemaResolver) Compute.
+func NewBatchComputeImplementer(ctx context.Context, logger log.Logger, db database.DB, args *gql.ComputeArgs) ([]gql.ComputeResultResolver, error) {
+	computeQuery, matches, err := searchComputeQuery(ctx, logger, db, args.Query)
 	if err != nil {
 		return nil, err
 	}
-	return toResultResolverList(ctx, computeQuery.Command, results.Matches, db)
+	return toResultResolverList(ctx, computeQuery.Command, matches, db)
 }
 
 func (r *Resolver) Compute(ctx context.Context, args *gql.ComputeArgs) ([]gql.ComputeResultResolver, error) {
 	return NewBatchComputeImplementer(ctx, r.logger, r.db, args)
 }
+
+func (r *Resolver) ComputeReplaceBatchSpec(ctx context.Context, args *gql.ComputeArgs) (string, error) {
+	computeQuery, matches, err := searchComputeQuery(ctx, r.logger, r.db, args.Query)
+	if err != nil {
+		return "", err
+	}
+
+	replace, ok := computeQuery.Command.(*compute.Replace)
+	if !ok {
+		return "", errors.Errorf("batch specs can only be created from replace queries, got: %s", computeQuery.Command)
+	}
+	// Regardless of how the query was written, we need diffs to apply.
+	cmd := *replace
+	cmd.Diff = true
+
+	// The batch spec applies the diffs on the default branch of each repository, so compute them
+	// against its HEAD rather than the commit the match was found at.
+	matches, err = fileMatchesAtHead(ctx, gitserver.NewClient(r.db), matches)
+	if err != nil {
+		return "", err
+	}
+
+	var diffs []*compute.FileDiff
+	for _, m := range matches {
+		computeResult, err := cmd.Run(ctx, r.db, m)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// The file was deleted since the search index was updated.
+				continue
+			}
+			return "", err
+		}
+		if diff, ok := computeResult.(*compute.FileDiff); ok {
+			diffs = append(diffs, diff)
+		}
+	}
+	return compute.NewReplaceBatchSpec(args.Query, diffs)
+}
+
+// fileMatchesAtHead returns the file matches in matches, moved to the commit HEAD of their
+// repository currently resolves to.
+func fileMatchesAtHead(ctx context.Context, gs gitserver.Client, matches []result.Match) ([]result.Match, error) {
+	heads := make(map[api.RepoName]api.CommitID)
+	atHead := make([]result.Match, 0, len(matches))
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		head, ok := heads[fm.Repo.Name]
+		if !ok {
+			var err error
+			head, err = gs.ResolveRevision(ctx, fm.Repo.Name, "HEAD", gitserver.ResolveRevisionOptions{})
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolving HEAD of %s", fm.Repo.Name)
+			}
+			heads[fm.Repo.Name] = head
+		}
+		moved := *fm
+		moved.CommitID = head
+		atHead = append(atHead, &moved)
+	}
+	return atHead, nil
+}
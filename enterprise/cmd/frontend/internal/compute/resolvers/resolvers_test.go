@@ -3,12 +3,18 @@ package resolvers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/hexops/autogold"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func TestToResultResolverList(t *testing.T) {
@@ -55,3 +61,34 @@ func TestToResultResolverList(t *testing.T) {
 	producesNilResult := []result.Match{&result.CommitMatch{}}
 	autogold.Want("resolver ignores nil compute result", "[]").Equal(t, test("a|b", producesNilResult))
 }
+
+func TestFileMatchesAtHead(t *testing.T) {
+	gs := gitserver.NewMockClient()
+	gs.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID("head-" + repo), nil
+	})
+
+	matches := []result.Match{
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "a"}, CommitID: "old", Path: "a.go"}},
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "a"}, CommitID: "old", Path: "b.go"}},
+		&result.RepoMatch{Name: "b"},
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "c"}, CommitID: "old", Path: "c.go"}},
+	}
+	got, err := fileMatchesAtHead(context.Background(), gs, matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var commits []string
+	for _, m := range got {
+		fm := m.(*result.FileMatch)
+		commits = append(commits, fmt.Sprintf("%s/%s@%s", fm.Repo.Name, fm.Path, fm.CommitID))
+	}
+	autogold.Want("file matches at head", []string{"a/a.go@head-a", "a/b.go@head-a", "c/c.go@head-c"}).Equal(t, commits)
+	mockrequire.CalledN(t, gs.ResolveRevisionFunc, 2)
+
+	// The input matches are left untouched
+	if commitID := matches[0].(*result.FileMatch).CommitID; commitID != "old" {
+		t.Fatalf("input match was modified: %s", commitID)
+	}
+}
@@ -58,19 +58,30 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		tr.Finish()
 	}()
 
-	eventWriter, err := streamhttp.NewWriter(w)
+	computeQuery, err := compute.Parse(args.Query)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	computeQuery, err := compute.Parse(args.Query)
+	searchQuery, err := computeQuery.ToSearchQuery()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	searchQuery, err := computeQuery.ToSearchQuery()
+	if args.Format != "" {
+		extract, ok := computeQuery.Command.(*compute.Extract)
+		if !ok {
+			err = errors.Errorf("format %q is only supported for extract queries", args.Format)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err = h.serveTable(ctx, w, searchQuery, extract, args.Format)
+		return
+	}
+
+	eventWriter, err := streamhttp.NewWriter(w)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -184,6 +195,9 @@ LOOP:
 type args struct {
 	Query   string
 	Display int
+	// Format is empty for the default event stream, or one of tableFormatJSONLines
+	// or tableFormatCSV to stream the rows of an extract query.
+	Format string
 }
 
 func parseURLQuery(q url.Values) (*args, error) {
@@ -209,6 +223,12 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.Errorf("display must be an integer, got %q: %w", display, err)
 	}
 
+	switch a.Format = get("format", ""); a.Format {
+	case "", tableFormatJSONLines, tableFormatCSV:
+	default:
+		return nil, errors.Errorf("format must be %q or %q, got %q", tableFormatJSONLines, tableFormatCSV, a.Format)
+	}
+
 	return &a, nil
 }
 
@@ -246,6 +266,7 @@ func batchEvents(source <-chan Event, delay time.Duration) <-chan Event {
 						return
 					}
 					event.Results = append(event.Results, newEvent.Results...)
+					event.Stats.Update(&newEvent.Stats)
 				case <-timer:
 					results <- event
 					continue OUTER
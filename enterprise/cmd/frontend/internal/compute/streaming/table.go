@@ -0,0 +1,177 @@
+package streaming
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	tableFormatJSONLines = "jsonl"
+	tableFormatCSV       = "csv"
+)
+
+// tableWriter writes the rows of extract results in a plain format that is
+// easier to consume from scripts than the event stream.
+type tableWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(columns []string, row compute.Row) error
+	WriteTrailer(trailer tableTrailer) error
+	Flush() error
+}
+
+// tableTrailer is written after the last row if the rows are incomplete, since
+// the status of the response can't change once rows have been written.
+type tableTrailer struct {
+	// Error is the error the search failed with, if any.
+	Error string `json:"error,omitempty"`
+	// LimitHit is true if the search stopped before finding every match, for
+	// example because it reached its result limit or timed out.
+	LimitHit bool `json:"limitHit,omitempty"`
+}
+
+type jsonLinesWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonLinesWriter) WriteHeader([]string) error { return nil }
+
+func (w *jsonLinesWriter) WriteRow(_ []string, row compute.Row) error {
+	return w.enc.Encode(row)
+}
+
+// WriteTrailer writes the trailer as a last line, which unlike rows has no
+// repository field.
+func (w *jsonLinesWriter) WriteTrailer(trailer tableTrailer) error {
+	return w.enc.Encode(trailer)
+}
+
+func (w *jsonLinesWriter) Flush() error { return nil }
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) WriteHeader(columns []string) error {
+	return w.w.Write(compute.TableHeader(columns))
+}
+
+func (w *csvWriter) WriteRow(columns []string, row compute.Row) error {
+	return w.w.Write(row.Record(columns))
+}
+
+// WriteTrailer writes the trailer as a last record of a single field starting
+// with "#", which CSV readers can be configured to treat as a comment.
+func (w *csvWriter) WriteTrailer(trailer tableTrailer) error {
+	if trailer.Error != "" {
+		return w.w.Write([]string{"# error: " + trailer.Error})
+	}
+	return w.w.Write([]string{"# limit hit: results are incomplete"})
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func newTableWriter(w http.ResponseWriter, format string) tableWriter {
+	switch format {
+	case tableFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		return &csvWriter{w: csv.NewWriter(w)}
+	default:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		return &jsonLinesWriter{enc: json.NewEncoder(w)}
+	}
+}
+
+// serveTable streams one row per match of an extract query. Searches that fail
+// before producing any event, for example because the query is invalid, are
+// reported with an error status. Once rows have been written, the status can't
+// change anymore, so a trailer is written after the last row if the search
+// failed or didn't find every match.
+func (h *streamHandler) serveTable(ctx context.Context, w http.ResponseWriter, searchQuery string, cmd *compute.Extract, format string) error {
+	events, getResults := NewComputeStream(ctx, h.logger, h.db, searchQuery, cmd)
+	events = batchEvents(events, 50*time.Millisecond)
+
+	first, ok := <-events
+	if !ok {
+		if _, err := getResults(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return err
+		}
+	}
+
+	tw := newTableWriter(w, format)
+	flush := func() error {
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+
+	if err := tw.WriteHeader(cmd.Columns); err != nil {
+		return err
+	}
+
+	limitHit := false
+	writeEvent := func(event Event) error {
+		limitHit = limitHit || event.Stats.IsLimitHit
+		for _, result := range event.Results {
+			table, ok := result.(*compute.Table)
+			if !ok {
+				continue
+			}
+			for _, row := range table.Rows {
+				if err := tw.WriteRow(cmd.Columns, row); err != nil {
+					return err
+				}
+			}
+		}
+		return flush()
+	}
+
+	var writeErr error
+	if ok {
+		writeErr = writeEvent(first)
+	}
+	for event := range events {
+		if writeErr != nil {
+			// Keep draining events so the stream can shut down.
+			continue
+		}
+		writeErr = writeEvent(event)
+	}
+	if writeErr != nil {
+		// The client most likely went away.
+		return writeErr
+	}
+
+	var trailer tableTrailer
+	_, err := getResults()
+	if err != nil {
+		h.logger.Error("compute extract stream failed", log.String("query", searchQuery), log.Error(err))
+		trailer.Error = err.Error()
+	} else if limitHit || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		trailer.LimitHit = true
+	}
+	if trailer != (tableTrailer{}) {
+		if writeErr := tw.WriteTrailer(trailer); writeErr != nil {
+			return writeErr
+		}
+		if writeErr := flush(); writeErr != nil {
+			return writeErr
+		}
+	}
+	return err
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
@@ -130,7 +131,8 @@ func Main() {
 	})
 
 	// Go!
-	goroutine.MonitorBackgroundRoutines(context.Background(), worker, server)
+	routines := append([]goroutine.BackgroundRoutine{worker, server}, gitserver.ClientCacheRoutines()...)
+	goroutine.MonitorBackgroundRoutines(context.Background(), routines...)
 }
 
 func mustInitializeDB(observationCtx *observation.Context) *sql.DB {
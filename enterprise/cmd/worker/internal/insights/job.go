@@ -7,6 +7,7 @@ import (
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -45,3 +46,23 @@ func (s *insightsJob) Routines(startupCtx context.Context, observationCtx *obser
 func NewInsightsJob() job.Job {
 	return &insightsJob{}
 }
+
+// RegisterEncryptedTables registers the encrypted tables of the codeinsights database
+// with the record encrypter of the worker, unless code insights is disabled.
+func RegisterEncryptedTables() {
+	if !insights.IsEnabled() {
+		return
+	}
+
+	database.RegisterEncryptedDatabase(database.EncryptedDatabase{
+		Name: "codeinsights",
+		Open: func(observationCtx *observation.Context) (database.DB, error) {
+			insightsDB, err := insights.InitializeCodeInsightsDB(observationCtx, "worker")
+			if err != nil {
+				return nil, err
+			}
+			return database.NewDBWith(observationCtx.Logger, insightsDB), nil
+		},
+		Configs: database.CodeInsightsEncryptionConfigs,
+	})
+}
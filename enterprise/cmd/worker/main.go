@@ -4,6 +4,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/shared"
+	workerinsights "github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/insights"
 	enterprise_shared "github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/shared"
 	srp "github.com/sourcegraph/sourcegraph/enterprise/internal/authz/subrepoperms"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
@@ -20,6 +21,8 @@ func getEnterpriseInit(logger log.Logger) func(database.DB) {
 	return func(ossDB database.DB) {
 		enterpriseDB := edb.NewEnterpriseDB(ossDB)
 
+		workerinsights.RegisterEncryptedTables()
+
 		var err error
 		authz.DefaultSubRepoPermsChecker, err = srp.NewSubRepoPermsClient(enterpriseDB.SubRepoPerms())
 		if err != nil {
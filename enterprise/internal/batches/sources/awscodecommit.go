@@ -0,0 +1,311 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"golang.org/x/net/http2"
+
+	awsbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AWSCodeCommitSource is a ChangesetSource for AWS CodeCommit.
+//
+// AWS CodeCommit uses separate credentials for its API and for Git over
+// HTTPS: API calls are always made with the access key of the external
+// service, while commits are pushed with the Git credentials of the
+// authenticator, which defaults to the gitCredentials of the external service.
+type AWSCodeCommitSource struct {
+	client *awscodecommit.Client
+	au     auth.Authenticator
+}
+
+var (
+	_ ChangesetSource = AWSCodeCommitSource{}
+)
+
+func NewAWSCodeCommitSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AWSCodeCommitSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AWSCodeCommitConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer(func(c *http.Client) error {
+		tr := awshttp.NewBuildableClient().GetTransport()
+		if err := http2.ConfigureTransport(tr); err != nil {
+			return err
+		}
+		c.Transport = tr
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(c.Region),
+		config.WithCredentialsProvider(
+			awscredentials.StaticCredentialsProvider{
+				Value: aws.Credentials{
+					AccessKeyID:     c.AccessKeyID,
+					SecretAccessKey: c.SecretAccessKey,
+					Source:          "sourcegraph-site-configuration",
+				},
+			},
+		),
+		config.WithHTTPClient(cli),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating AWS config")
+	}
+
+	return &AWSCodeCommitSource{
+		client: awscodecommit.NewClient(awsConfig),
+		au: &auth.BasicAuth{
+			Username: c.GitCredentials.Username,
+			Password: c.GitCredentials.Password,
+		},
+	}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AWSCodeCommitSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.au)
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host. The authenticator is only used to push commits.
+func (s AWSCodeCommitSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AWSCodeCommitSource", a)
+	}
+
+	return &AWSCodeCommitSource{client: s.client, au: a}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+//
+// AWS CodeCommit has no API to validate Git credentials, so this only checks
+// that a username and password are set.
+func (s AWSCodeCommitSource) ValidateAuthenticator(ctx context.Context) error {
+	var username, password string
+	switch a := s.au.(type) {
+	case *auth.BasicAuth:
+		username, password = a.Username, a.Password
+	case *auth.BasicAuthWithSSH:
+		username, password = a.Username, a.Password
+	}
+	if username == "" || password == "" {
+		return errors.New("AWS CodeCommit Git credentials require a username and password")
+	}
+	return nil
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AWSCodeCommitSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.GetPullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AWSCodeCommitSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	repo, err := awsCodeCommitRepository(cs.TargetRepo)
+	if err != nil {
+		return false, err
+	}
+	headRef := gitdomain.EnsureRefPrefix(cs.HeadRef)
+	baseRef := gitdomain.EnsureRefPrefix(cs.BaseRef)
+
+	// CodeCommit doesn't reject duplicate pull requests, so we need to look
+	// for an existing one ourselves.
+	existing, err := s.findOpenPullRequest(ctx, repo.Name, headRef, baseRef)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		return true, s.setChangesetMetadata(ctx, existing, cs)
+	}
+
+	pr, err := s.client.CreatePullRequest(ctx, awscodecommit.CreatePullRequestInput{
+		RepositoryName:       repo.Name,
+		Title:                cs.Title,
+		Description:          cs.Body,
+		SourceReference:      headRef,
+		DestinationReference: baseRef,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "creating pull request")
+	}
+
+	return false, s.setChangesetMetadata(ctx, pr, cs)
+}
+
+func (s AWSCodeCommitSource) findOpenPullRequest(ctx context.Context, repositoryName, headRef, baseRef string) (*awscodecommit.PullRequest, error) {
+	ids, err := s.client.ListOpenPullRequestIDs(ctx, repositoryName)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pull requests")
+	}
+
+	for _, id := range ids {
+		pr, err := s.client.GetPullRequest(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting pull request")
+		}
+		if pr.SourceReference == headRef && pr.DestinationReference == baseRef {
+			return pr, nil
+		}
+	}
+	return nil, nil
+}
+
+// CloseChangeset closes the pull request on the code host.
+func (s AWSCodeCommitSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*awsbatches.AnnotatedPullRequest)
+	if pr.Status != awscodecommit.PullRequestStatusOpen {
+		return nil
+	}
+
+	updated, err := s.client.ClosePullRequest(ctx, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "closing pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, updated, cs)
+}
+
+// UpdateChangeset updates the title and description of the pull request. New
+// commits are picked up by CodeCommit when the branch is pushed. The
+// destination branch of a pull request cannot be changed, so changing the
+// base ref of a changeset results in an error.
+func (s AWSCodeCommitSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*awsbatches.AnnotatedPullRequest)
+
+	if baseRef := gitdomain.EnsureRefPrefix(cs.BaseRef); baseRef != pr.DestinationReference {
+		return errors.Errorf("cannot change the destination branch of AWS CodeCommit pull request %s from %q to %q", pr.ID, pr.DestinationReference, baseRef)
+	}
+
+	updated := pr.PullRequest
+	var err error
+	if pr.Title != cs.Title {
+		if updated, err = s.client.UpdatePullRequestTitle(ctx, pr.ID, cs.Title); err != nil {
+			return errors.Wrap(err, "updating pull request title")
+		}
+	}
+	if pr.Description != cs.Body {
+		if updated, err = s.client.UpdatePullRequestDescription(ctx, pr.ID, cs.Body); err != nil {
+			return errors.Wrap(err, "updating pull request description")
+		}
+	}
+
+	return s.setChangesetMetadata(ctx, updated, cs)
+}
+
+// ReopenChangeset recreates the pull request, if it's closed. If not, it's a
+// noop.
+func (s AWSCodeCommitSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	// CodeCommit can't reopen a closed pull request, so, like for Bitbucket
+	// Cloud, we recreate it instead. If the pull request is still open,
+	// CreateChangeset finds it and this is effectively a noop.
+	_, err := s.CreateChangeset(ctx, cs)
+	return err
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AWSCodeCommitSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	pr := cs.Metadata.(*awsbatches.AnnotatedPullRequest)
+	return s.client.CreatePullRequestComment(ctx, pr.PullRequest, comment)
+}
+
+// MergeChangeset merges the pull request on the code host, if in a mergeable
+// state. If squash is true, a squash merge is performed, otherwise a
+// three-way merge. If the pull request cannot be merged, because of conflicts,
+// unsatisfied approval rules or a changed source branch,
+// ChangesetNotMergeableError is returned.
+func (s AWSCodeCommitSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	pr := cs.Metadata.(*awsbatches.AnnotatedPullRequest)
+
+	updated, err := s.client.MergePullRequest(ctx, pr.PullRequest, squash)
+	if err != nil {
+		if awscodecommit.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, updated, cs)
+}
+
+// setChangesetMetadata loads the approval state of the current revision of
+// the pull request, which isn't included in the pull request itself, and sets
+// the changeset metadata.
+func (s AWSCodeCommitSource) setChangesetMetadata(ctx context.Context, pr *awscodecommit.PullRequest, cs *Changeset) error {
+	approvals, err := s.client.GetPullRequestApprovals(ctx, pr.ID, pr.RevisionID)
+	if err != nil {
+		return errors.Wrap(err, "getting pull request approvals")
+	}
+	evaluation, err := s.client.EvaluatePullRequestApprovalRules(ctx, pr.ID, pr.RevisionID)
+	if err != nil {
+		return errors.Wrap(err, "evaluating pull request approval rules")
+	}
+
+	if err := cs.SetMetadata(&awsbatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Approvals:   approvals,
+		Evaluation:  evaluation,
+		Region:      s.client.Region(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// awsCodeCommitRepository returns the AWS CodeCommit repository of the given
+// repo.
+func awsCodeCommitRepository(repo *types.Repo) (*awscodecommit.Repository, error) {
+	r, ok := repo.Metadata.(*awscodecommit.Repository)
+	if !ok {
+		return nil, errors.Errorf("repo %q has no AWS CodeCommit repository metadata", repo.Name)
+	}
+	return r, nil
+}
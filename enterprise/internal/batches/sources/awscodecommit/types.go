@@ -0,0 +1,63 @@
+package awscodecommit
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+)
+
+// AnnotatedPullRequest adds metadata we need that lives outside the main
+// PullRequest type returned by the AWS CodeCommit API alongside the pull
+// request. This type is used as the primary metadata type for AWS CodeCommit
+// changesets.
+type AnnotatedPullRequest struct {
+	*awscodecommit.PullRequest
+	// Approvals are the approval states of the current revision of the pull
+	// request.
+	Approvals []*awscodecommit.Approval `json:"approvals"`
+	// Evaluation is the evaluation of the approval rules of the current
+	// revision of the pull request.
+	Evaluation *awscodecommit.ApprovalEvaluation `json:"evaluation"`
+	// Region is the AWS region of the repository, which is needed to link to
+	// the pull request, as the API doesn't return the URL of a pull request.
+	Region string `json:"region"`
+}
+
+// URL returns the URL of the pull request in the AWS console.
+func (pr *AnnotatedPullRequest) URL() string {
+	return "https://" + pr.Region + ".console.aws.amazon.com/codesuite/codecommit/repositories/" +
+		url.PathEscape(pr.RepositoryName) + "/pull-requests/" + url.PathEscape(pr.ID) +
+		"?region=" + url.QueryEscape(pr.Region)
+}
+
+// AuthorName returns the name of the IAM user or role that created the pull
+// request, which is the last segment of its ARN.
+func (pr *AnnotatedPullRequest) AuthorName() string {
+	if i := strings.LastIndex(pr.AuthorARN, "/"); i >= 0 {
+		return pr.AuthorARN[i+1:]
+	}
+	return pr.AuthorARN
+}
+
+// IsApproved returns true when the approval rules of the pull request were
+// overridden, or when they are satisfied and at least one user approved the
+// pull request. Without any approval rules, CodeCommit considers every pull
+// request as approved, so an explicit approval is required as well.
+func (pr *AnnotatedPullRequest) IsApproved() bool {
+	if pr.Evaluation == nil {
+		return false
+	}
+	if pr.Evaluation.Overridden {
+		return true
+	}
+	if !pr.Evaluation.Approved {
+		return false
+	}
+	for _, a := range pr.Approvals {
+		if a.State == awscodecommit.ApprovalStateApprove {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit. Gerrit has no concept of
+// pull requests, so changesets are published as changes that merge the
+// pushed branch into the base branch. The branch name is stored as the
+// topic of the change, which allows finding existing changes for a branch.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ DraftChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChange(ctx, cs, false)
+}
+
+// CreateDraftChangeset creates the given changeset as a work-in-progress
+// change on the code host.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChange(ctx, cs, true)
+}
+
+func (s GerritSource) createChange(ctx context.Context, cs *Changeset, draft bool) (bool, error) {
+	project, err := gerritProjectName(cs.TargetRepo)
+	if err != nil {
+		return false, err
+	}
+	baseBranch := gitdomain.AbbreviateRef(cs.BaseRef)
+	headBranch := gitdomain.AbbreviateRef(cs.HeadRef)
+
+	existing, err := s.client.QueryChanges(ctx, strings.Join([]string{
+		gerrit.ChangeQuery("project", project),
+		gerrit.ChangeQuery("branch", baseBranch),
+		gerrit.ChangeQuery("topic", headBranch),
+		"is:open",
+	}, " "))
+	if err != nil {
+		return false, errors.Wrap(err, "querying existing changes")
+	}
+	if len(existing) > 0 {
+		return true, s.setChangesetMetadata(existing[0], cs)
+	}
+
+	created, err := s.client.CreateChange(ctx, gerrit.ChangeInput{
+		Project:        project,
+		Branch:         baseBranch,
+		Subject:        gerritCommitMessage(cs),
+		Topic:          headBranch,
+		WorkInProgress: draft,
+		Merge:          &gerrit.MergeInput{Source: cs.HeadRef},
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "creating change")
+	}
+
+	return false, s.reloadChange(ctx, strconv.Itoa(created.Number), cs)
+}
+
+// UndraftChangeset marks a work-in-progress change as ready for review.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.SetReadyForReview(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "marking change as ready for review")
+	}
+
+	return s.reloadChange(ctx, cs.ExternalID, cs)
+}
+
+// CloseChangeset abandons the change on the code host.
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status != gerrit.ChangeStatusNew {
+		return nil
+	}
+
+	if _, err := s.client.AbandonChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.reloadChange(ctx, cs.ExternalID, cs)
+}
+
+// UpdateChangeset updates the change to merge the latest commit of the pushed
+// branch, and updates its commit message and destination branch.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if baseBranch := gitdomain.AbbreviateRef(cs.BaseRef); baseBranch != change.Branch {
+		if _, err := s.client.MoveChange(ctx, cs.ExternalID, baseBranch); err != nil {
+			return errors.Wrap(err, "moving change")
+		}
+	}
+
+	if _, err := s.client.CreateMergePatchSet(ctx, cs.ExternalID, gerrit.MergePatchSetInput{
+		Merge: gerrit.MergeInput{Source: cs.HeadRef},
+	}); err != nil {
+		return errors.Wrap(err, "creating patch set")
+	}
+
+	if change.Subject != cs.Title || change.Body() != cs.Body {
+		if err := s.client.SetCommitMessage(ctx, cs.ExternalID, gerritCommitMessage(cs)); err != nil {
+			return errors.Wrap(err, "setting commit message")
+		}
+	}
+
+	return s.reloadChange(ctx, cs.ExternalID, cs)
+}
+
+// ReopenChangeset restores the change, if it's abandoned. If not, it's a
+// noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+
+	if _, err := s.client.RestoreChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+
+	return s.reloadChange(ctx, cs.ExternalID, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.SetReview(ctx, cs.ExternalID, gerrit.ReviewInput{Message: comment})
+}
+
+// MergeChangeset submits the change on the code host, if in a submittable
+// state. Gerrit always submits a change as a single commit, so squash is
+// ignored. If the change cannot be submitted, ChangesetNotMergeableError is
+// returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	if _, err := s.client.SubmitChange(ctx, cs.ExternalID); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.reloadChange(ctx, cs.ExternalID, cs)
+}
+
+// reloadChange loads the given change with its labels and current revision,
+// which aren't included in the responses of mutating endpoints, and sets
+// the changeset metadata.
+func (s GerritSource) reloadChange(ctx context.Context, changeID string, cs *Changeset) error {
+	loaded, err := s.client.GetChange(ctx, changeID)
+	if err != nil {
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(loaded, cs)
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// gerritCommitMessage returns the commit message of the change for the given
+// changeset, consisting of its title and body.
+func gerritCommitMessage(cs *Changeset) string {
+	if cs.Body == "" {
+		return cs.Title
+	}
+	return cs.Title + "\n\n" + cs.Body
+}
+
+// gerritProjectName returns the name of the Gerrit project of the given repo.
+func gerritProjectName(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("repo %q has no Gerrit project metadata", repo.Name)
+	}
+	// The project ID is the URL-encoded project name.
+	name, err := url.PathUnescape(project.ID)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding Gerrit project ID")
+	}
+	return name, nil
+}
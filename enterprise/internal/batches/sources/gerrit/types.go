@@ -0,0 +1,67 @@
+package gerrit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// CodeHostURL is the base URL of the Gerrit instance, which is needed to
+	// link to the change, as the API doesn't return the URL of a change.
+	CodeHostURL string `json:"code_host_url"`
+}
+
+// URL returns the URL of the change in the Gerrit web UI.
+func (c *AnnotatedChange) URL() string {
+	return strings.TrimSuffix(c.CodeHostURL, "/") + "/c/" + c.Project + "/+/" + strconv.Itoa(c.Number)
+}
+
+// Body returns the commit message of the current revision of the change,
+// without its subject line and its Change-Id footer.
+func (c *AnnotatedChange) Body() string {
+	commit := c.CurrentCommit()
+	if commit == nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(commit.Message), "\n")
+	// Skip the subject, which is the title of the changeset.
+	lines = lines[1:]
+	// Drop the Change-Id footer that Gerrit adds to every change.
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "Change-Id: ") {
+			lines = append(lines[:i], lines[i+1:]...)
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// HeadRefOid returns the commit that was merged into the destination branch
+// by the change. Changes created by batch changes are merge commits of the
+// pushed branch, so the second parent is the head of the changeset.
+func (c *AnnotatedChange) HeadRefOid() string {
+	commit := c.CurrentCommit()
+	if commit == nil {
+		return ""
+	}
+	if len(commit.Parents) > 1 {
+		return commit.Parents[1].Commit
+	}
+	return c.CurrentRevision
+}
+
+// BaseRefOid returns the commit of the destination branch the current
+// revision of the change is based on.
+func (c *AnnotatedChange) BaseRefOid() string {
+	commit := c.CurrentCommit()
+	if commit == nil || len(commit.Parents) == 0 {
+		return ""
+	}
+	return commit.Parents[0].Commit
+}
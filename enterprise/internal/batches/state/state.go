@@ -11,15 +11,19 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	awsbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -112,6 +116,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritVerifiedState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -251,6 +258,28 @@ func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btyp
 	}
 }
 
+// computeGerritVerifiedState computes the check state of a Gerrit change from
+// the votes on its Verified label, which CI systems use to report build
+// results. Votes of 0 are ignored, as they are the default for every reviewer.
+func computeGerritVerifiedState(change *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	label, ok := change.Labels[gerrit.LabelVerified]
+	if !ok {
+		// The project doesn't use the Verified label.
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	state := btypes.ChangesetCheckStatePending
+	for _, vote := range label.All {
+		switch {
+		case vote.Value < 0:
+			return btypes.ChangesetCheckStateFailed
+		case vote.Value > 0:
+			state = btypes.ChangesetCheckStatePassed
+		}
+	}
+	return state
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -515,6 +544,35 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
+	case *awsbatches.AnnotatedPullRequest:
+		switch m.Status {
+		case awscodecommit.PullRequestStatusClosed:
+			// Merged pull requests are closed, too.
+			if m.IsMerged {
+				s = btypes.ChangesetExternalStateMerged
+			} else {
+				s = btypes.ChangesetExternalStateClosed
+			}
+		case awscodecommit.PullRequestStatusOpen:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown AWS CodeCommit pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -584,6 +642,28 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerritbatches.AnnotatedChange:
+		// Votes of -2 block submission and -1 asks for changes, while +2
+		// approves the change. A vote of +1 still requires someone else to
+		// approve, so it's considered pending like no vote at all.
+		states[btypes.ChangesetReviewStatePending] = true
+		for _, vote := range m.Labels[gerrit.LabelCodeReview].All {
+			switch {
+			case vote.Value < 0:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			case vote.Value >= 2:
+				states[btypes.ChangesetReviewStateApproved] = true
+			}
+		}
+
+	case *awsbatches.AnnotatedPullRequest:
+		// CodeCommit has no concept of requesting changes: a pull request is
+		// either approved according to its approval rules, or still pending.
+		if m.IsApproved() {
+			return btypes.ChangesetReviewStateApproved, nil
+		}
+		return btypes.ChangesetReviewStatePending, nil
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
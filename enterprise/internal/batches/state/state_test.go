@@ -7,9 +7,13 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	awsbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -867,6 +871,210 @@ func TestComputeLabels(t *testing.T) {
 	}
 }
 
+func TestComputeGerritStates(t *testing.T) {
+	t.Parallel()
+
+	votes := func(values ...int) gerrit.ChangeLabel {
+		label := gerrit.ChangeLabel{}
+		for i, v := range values {
+			label.All = append(label.All, gerrit.Approval{Account: gerrit.Account{ID: int32(i)}, Value: v})
+		}
+		return label
+	}
+
+	tests := []struct {
+		name         string
+		change       *gerrit.Change
+		wantExternal btypes.ChangesetExternalState
+		wantReview   btypes.ChangesetReviewState
+		wantCheck    btypes.ChangesetCheckState
+	}{
+		{
+			name:         "no labels",
+			change:       &gerrit.Change{Status: gerrit.ChangeStatusNew},
+			wantExternal: btypes.ChangesetExternalStateOpen,
+			wantReview:   btypes.ChangesetReviewStatePending,
+			wantCheck:    btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:         "work in progress",
+			change:       &gerrit.Change{Status: gerrit.ChangeStatusNew, WorkInProgress: true},
+			wantExternal: btypes.ChangesetExternalStateDraft,
+			wantReview:   btypes.ChangesetReviewStatePending,
+			wantCheck:    btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name: "approved and verified",
+			change: &gerrit.Change{Status: gerrit.ChangeStatusMerged, Labels: map[string]gerrit.ChangeLabel{
+				gerrit.LabelCodeReview: votes(0, 1, 2),
+				gerrit.LabelVerified:   votes(0, 1),
+			}},
+			wantExternal: btypes.ChangesetExternalStateMerged,
+			wantReview:   btypes.ChangesetReviewStateApproved,
+			wantCheck:    btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "looks good but not approved and not yet verified",
+			change: &gerrit.Change{Status: gerrit.ChangeStatusNew, Labels: map[string]gerrit.ChangeLabel{
+				gerrit.LabelCodeReview: votes(1),
+				gerrit.LabelVerified:   votes(0),
+			}},
+			wantExternal: btypes.ChangesetExternalStateOpen,
+			wantReview:   btypes.ChangesetReviewStatePending,
+			wantCheck:    btypes.ChangesetCheckStatePending,
+		},
+		{
+			name: "rejected and failed verification",
+			change: &gerrit.Change{Status: gerrit.ChangeStatusAbandoned, Labels: map[string]gerrit.ChangeLabel{
+				gerrit.LabelCodeReview: votes(2, -1),
+				gerrit.LabelVerified:   votes(1, -1),
+			}},
+			wantExternal: btypes.ChangesetExternalStateClosed,
+			wantReview:   btypes.ChangesetReviewStateChangesRequested,
+			wantCheck:    btypes.ChangesetCheckStateFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changeset := &btypes.Changeset{
+				ExternalServiceType: extsvc.TypeGerrit,
+				Metadata:            &gerritbatches.AnnotatedChange{Change: tc.change},
+			}
+
+			external, err := computeExternalState(changeset, nil, &types.Repo{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if external != tc.wantExternal {
+				t.Errorf("wrong external state. have=%s, want=%s", external, tc.wantExternal)
+			}
+
+			review, err := computeReviewState(changeset, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if review != tc.wantReview {
+				t.Errorf("wrong reviewstate. have=%s, want=%s", review, tc.wantReview)
+			}
+
+			if check := computeCheckState(changeset, nil); check != tc.wantCheck {
+				t.Errorf("unexpected check state: have %s; want %s", check, tc.wantCheck)
+			}
+		})
+	}
+}
+
+func TestComputeAWSCodeCommitStates(t *testing.T) {
+	t.Parallel()
+
+	approve := []*awscodecommit.Approval{{UserARN: "arn:aws:iam::123456789012:user/reviewer", State: awscodecommit.ApprovalStateApprove}}
+	revoke := []*awscodecommit.Approval{{UserARN: "arn:aws:iam::123456789012:user/reviewer", State: awscodecommit.ApprovalStateRevoke}}
+
+	tests := []struct {
+		name         string
+		pr           *awsbatches.AnnotatedPullRequest
+		wantExternal btypes.ChangesetExternalState
+		wantReview   btypes.ChangesetReviewState
+	}{
+		{
+			name: "open without approvals",
+			pr: &awsbatches.AnnotatedPullRequest{
+				PullRequest: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusOpen},
+				Evaluation:  &awscodecommit.ApprovalEvaluation{Approved: true},
+			},
+			wantExternal: btypes.ChangesetExternalStateOpen,
+			wantReview:   btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "open and approved",
+			pr: &awsbatches.AnnotatedPullRequest{
+				PullRequest: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusOpen},
+				Approvals:   approve,
+				Evaluation:  &awscodecommit.ApprovalEvaluation{Approved: true},
+			},
+			wantExternal: btypes.ChangesetExternalStateOpen,
+			wantReview:   btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "approved but rules not satisfied",
+			pr: &awsbatches.AnnotatedPullRequest{
+				PullRequest: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusOpen},
+				Approvals:   approve,
+				Evaluation:  &awscodecommit.ApprovalEvaluation{ApprovalRulesNotSatisfied: []string{"two approvers"}},
+			},
+			wantExternal: btypes.ChangesetExternalStateOpen,
+			wantReview:   btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "approval revoked",
+			pr: &awsbatches.AnnotatedPullRequest{
+				PullRequest: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusOpen},
+				Approvals:   revoke,
+				Evaluation:  &awscodecommit.ApprovalEvaluation{Approved: true},
+			},
+			wantExternal: btypes.ChangesetExternalStateOpen,
+			wantReview:   btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "rules overridden",
+			pr: &awsbatches.AnnotatedPullRequest{
+				PullRequest: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusOpen},
+				Evaluation:  &awscodecommit.ApprovalEvaluation{Overridden: true},
+			},
+			wantExternal: btypes.ChangesetExternalStateOpen,
+			wantReview:   btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "merged",
+			pr: &awsbatches.AnnotatedPullRequest{
+				PullRequest: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusClosed, IsMerged: true},
+				Approvals:   approve,
+				Evaluation:  &awscodecommit.ApprovalEvaluation{Approved: true},
+			},
+			wantExternal: btypes.ChangesetExternalStateMerged,
+			wantReview:   btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "closed",
+			pr: &awsbatches.AnnotatedPullRequest{
+				PullRequest: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusClosed},
+			},
+			wantExternal: btypes.ChangesetExternalStateClosed,
+			wantReview:   btypes.ChangesetReviewStatePending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changeset := &btypes.Changeset{
+				ExternalServiceType: extsvc.TypeAWSCodeCommit,
+				Metadata:            tc.pr,
+			}
+
+			external, err := computeExternalState(changeset, nil, &types.Repo{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if external != tc.wantExternal {
+				t.Errorf("wrong external state. have=%s, want=%s", external, tc.wantExternal)
+			}
+
+			review, err := computeReviewState(changeset, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if review != tc.wantReview {
+				t.Errorf("wrong reviewstate. have=%s, want=%s", review, tc.wantReview)
+			}
+
+			if check := computeCheckState(changeset, nil); check != btypes.ChangesetCheckStateUnknown {
+				t.Errorf("unexpected check state: have %s; want %s", check, btypes.ChangesetCheckStateUnknown)
+			}
+		})
+	}
+}
+
 func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypeBitbucketServer,
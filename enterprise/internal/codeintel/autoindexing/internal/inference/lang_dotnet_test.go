@@ -0,0 +1,107 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	expectedIndexerImage := "sourcegraph/scip-dotnet@sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	mockIndexerMap(t, map[string]string{"dotnet": expectedIndexerImage})
+
+	job := func(root string) config.IndexJob {
+		return config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    expectedIndexerImage,
+					Commands: []string{"dotnet restore"},
+				},
+			},
+			LocalSteps:       nil,
+			Root:             root,
+			Indexer:          expectedIndexerImage,
+			IndexerArgs:      []string{"scip-dotnet", "index"},
+			Outfile:          "index.scip",
+			RequestedEnvVars: []string{"NUGET_AUTH_TOKEN"},
+		}
+	}
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "solution with projects",
+			repositoryContents: map[string]string{
+				"App.sln":                          "",
+				"src/App/App.csproj":               "",
+				"src/App.Core/App.Core.csproj":     "",
+				"tests/App.Tests/App.Tests.csproj": "",
+			},
+			expected: []config.IndexJob{
+				job(""),
+			},
+		},
+		generatorTestCase{
+			description: "solutions in subdirectories and standalone projects",
+			repositoryContents: map[string]string{
+				"server/Server.sln":            "",
+				"server/Api/Api.csproj":        "",
+				"tools/Cli/Cli.csproj":         "",
+				"tools/Cli/bin/Debug/x.csproj": "",
+			},
+			expected: []config.IndexJob{
+				job("server"),
+				job("tools/Cli"),
+			},
+		},
+	)
+}
+
+func TestDotnetGeneratorWithoutIndexer(t *testing.T) {
+	// There is no default .NET indexer pinned to a digest, so .NET projects are only
+	// indexed if an indexer is configured.
+	testGenerators(t,
+		generatorTestCase{
+			description: "solution",
+			repositoryContents: map[string]string{
+				"App.sln":        "",
+				"src/App.csproj": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
+
+func TestDotnetHinter(t *testing.T) {
+	expectedIndexerImage := "sourcegraph/scip-dotnet@sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	mockIndexerMap(t, map[string]string{"dotnet": expectedIndexerImage})
+
+	testHinters(t,
+		hinterTestCase{
+			description: "basic hints",
+			repositoryContents: map[string]string{
+				"App.sln":            "",
+				"src/App.csproj":     "",
+				"src/Program.cs":     "",
+				"lib/Lib/Lib.csproj": "",
+			},
+			expected: []config.IndexJobHint{
+				{
+					Root:           "",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+				{
+					Root:           "lib/Lib",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+				{
+					Root:           "src",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,79 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestKotlinGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("java")
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "gradle kotlin dsl multi-project build",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts":           "",
+				"build.gradle.kts":              "",
+				"app/build.gradle.kts":          "",
+				"app/src/main/kotlin/App.kt":    "",
+				"tools/gen/build.gradle.kts":    "",
+				"tools/gen/settings.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "gradle kotlin dsl builds in subdirectories",
+			repositoryContents: map[string]string{
+				"server/settings.gradle.kts":  "",
+				"server/api/build.gradle.kts": "",
+				"android/build.gradle.kts":    "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "android",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "server",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "gradle kotlin dsl with lsif-java.json",
+			repositoryContents: map[string]string{
+				"lsif-java.json":   "",
+				"build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=scip"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
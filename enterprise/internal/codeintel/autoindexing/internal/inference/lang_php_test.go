@@ -0,0 +1,69 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	expectedIndexerImage := "davidrjenni/lsif-php@sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	mockIndexerMap(t, map[string]string{"php": expectedIndexerImage})
+
+	job := func(root string) config.IndexJob {
+		return config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    expectedIndexerImage,
+					Commands: []string{"composer install --no-interaction --no-progress --no-scripts --ignore-platform-reqs"},
+				},
+			},
+			LocalSteps:       nil,
+			Root:             root,
+			Indexer:          expectedIndexerImage,
+			IndexerArgs:      []string{"lsif-php"},
+			Outfile:          "dump.lsif",
+			RequestedEnvVars: []string{"COMPOSER_AUTH"},
+		}
+	}
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer projects",
+			repositoryContents: map[string]string{
+				"composer.json":                    "",
+				"src/Foo.php":                      "",
+				"packages/bar/composer.json":       "",
+				"vendor/acme/baz/composer.json":    "",
+				"tests/fixtures/app/composer.json": "",
+			},
+			expected: []config.IndexJob{
+				job(""),
+				job("packages/bar"),
+			},
+		},
+		generatorTestCase{
+			description: "php files without composer.json (no match)",
+			repositoryContents: map[string]string{
+				"index.php": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
+
+func TestPHPGeneratorWithoutIndexer(t *testing.T) {
+	// There is no default PHP indexer pinned to a digest, so PHP projects are only
+	// indexed if an indexer is configured.
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer project",
+			repositoryContents: map[string]string{
+				"composer.json": "",
+				"src/Foo.php":   "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
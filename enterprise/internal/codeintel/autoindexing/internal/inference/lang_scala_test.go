@@ -0,0 +1,61 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestScalaGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("java")
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "sbt builds",
+			repositoryContents: map[string]string{
+				"build.sbt":                      "",
+				"core/build.sbt":                 "",
+				"core/src/main/scala/Core.scala": "",
+				"docs/site/build.sbt":            "",
+				"examples/hello/build.sbt":       "",
+				"target/scala-2.13/build.sbt":    "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "sbt builds in subdirectories",
+			repositoryContents: map[string]string{
+				"backend/build.sbt":  "",
+				"frontend/build.sbt": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "backend",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "frontend",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
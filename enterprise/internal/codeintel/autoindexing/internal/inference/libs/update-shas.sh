@@ -5,16 +5,22 @@ set -u
 DOCKER_USER=${DOCKER_USER:?"No DOCKER_USER is set."}
 DOCKER_PASS=${DOCKER_PASS:?"No DOCKER_PASS is set."}
 
-for indexer in lsif-clang lsif-go lsif-rust scip-java scip-python scip-typescript scip-ruby; do
-  tag="latest"
-  if [[ "${indexer}" = "scip-python" ]] || [[ "${indexer}" = "scip-typescript" || "${indexer}" = "scip-ruby" ]]; then
-    tag="autoindex"
-  fi
+for image in \
+  sourcegraph/lsif-clang:latest \
+  sourcegraph/lsif-go:latest \
+  sourcegraph/lsif-rust:latest \
+  sourcegraph/scip-java:latest \
+  sourcegraph/scip-python:autoindex \
+  sourcegraph/scip-typescript:autoindex \
+  sourcegraph/scip-ruby:autoindex \
+  sourcegraph/scip-dotnet:latest \
+  davidrjenni/lsif-php:latest; do
+  indexer=${image%:*}
 
-  sha=$(docker manifest inspect sourcegraph/${indexer}:${tag} -v | jq -s .[0].Descriptor.digest)
+  sha=$(docker manifest inspect "${image}" -v | jq -s .[0].Descriptor.digest)
 
   sed -i.bak \
-    "s|\("'"'"sourcegraph/${indexer}"'"'":\).*|\1${sha},|g" \
+    "s|\("'"'"${indexer}"'"'":\).*|\1${sha},|g" \
     indexes.go
 
   echo "Updated tag for ${indexer}"
@@ -0,0 +1,118 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+-- The default .NET indexer is only used once its image is pinned to a digest. Until then,
+-- projects are only indexed if an indexer is configured in codeIntelAutoIndexing.indexerMap.
+local indexer = require("sg.autoindex.indexes").lookup "dotnet"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "bin",
+  pattern.new_path_segment "obj",
+})
+
+local is_solution_file = function(base)
+  return string.sub(base, -4) == ".sln"
+end
+
+-- Returns true if the given directory or one of its ancestors is already a root.
+local is_covered = function(roots, dir)
+  if roots[dir] then
+    return true
+  end
+
+  local ancestors = path.ancestors(dir)
+  for i = 1, #ancestors do
+    if roots[ancestors[i]] then
+      return true
+    end
+  end
+
+  return false
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when solution or C# project files exist. Solutions are indexed as a whole, and
+  -- projects are only indexed on their own when no solution includes their directory.
+  generate = function(_, paths)
+    if indexer == nil then
+      return {}
+    end
+
+    local roots = {}
+    local ordered_roots = {}
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      if is_solution_file(path.basename(paths[i])) and not roots[dir] then
+        roots[dir] = true
+        table.insert(ordered_roots, dir)
+      end
+    end
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      if not is_solution_file(path.basename(paths[i])) and not is_covered(roots, dir) then
+        roots[dir] = true
+        table.insert(ordered_roots, dir)
+      end
+    end
+
+    local jobs = {}
+    for i = 1, #ordered_roots do
+      local root = ordered_roots[i]
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            commands = { "dotnet restore" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-dotnet", "index" },
+        outfile = outfile,
+        requested_envvars = { "NUGET_AUTH_TOKEN" },
+      })
+    end
+
+    return jobs
+  end,
+
+  -- Invoked when solution or C# project files exist
+  hints = function(_, paths)
+    if indexer == nil then
+      return {}
+    end
+
+    local hints = {}
+    local visited = {}
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+
+      if visited[dir] == nil then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "PROJECT_STRUCTURE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    return hints
+  end,
+}
@@ -0,0 +1,68 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+local util = require "sg.autoindex.util"
+
+local indexer = require("sg.autoindex.indexes").get "java"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "build",
+  pattern.new_path_segment "target",
+})
+
+-- Creates a recognizer that emits a scip-java job for each top-most directory containing one of
+-- the given build files. Nested build files belong to subprojects of the enclosing build, which
+-- scip-java indexes together. Repositories with an lsif-java.json are handled by the java
+-- recognizer instead.
+local new_build_tool_recognizer = function(build_files, build_tool)
+  local patterns = { pattern.new_path_literal "lsif-java.json" }
+  for i = 1, #build_files do
+    table.insert(patterns, pattern.new_path_basename(build_files[i]))
+  end
+  table.insert(patterns, pattern.new_path_exclude(exclude_paths))
+
+  return recognizer.new_path_recognizer {
+    patterns = patterns,
+
+    generate = function(_, paths)
+      if util.contains(paths, "lsif-java.json") then
+        return {}
+      end
+
+      local roots = {}
+      for i = 1, #paths do
+        roots[path.dirname(paths[i])] = true
+      end
+
+      local jobs = {}
+      for root in pairs(roots) do
+        local is_subproject = false
+        local ancestors = path.ancestors(root)
+        for i = 1, #ancestors do
+          if ancestors[i] ~= root and roots[ancestors[i]] then
+            is_subproject = true
+          end
+        end
+
+        if not is_subproject then
+          table.insert(jobs, {
+            steps = {},
+            root = root,
+            indexer = indexer,
+            indexer_args = { "scip-java", "index", "--build-tool=" .. build_tool },
+            outfile = outfile,
+          })
+        end
+      end
+
+      return jobs
+    end,
+  }
+end
+
+return {
+  new_build_tool_recognizer = new_build_tool_recognizer,
+}
@@ -0,0 +1,4 @@
+local jvm = require "sg.autoindex.jvm"
+
+-- Invoked when Gradle Kotlin DSL build files exist
+return jvm.new_build_tool_recognizer({ "settings.gradle.kts", "build.gradle.kts" }, "gradle")
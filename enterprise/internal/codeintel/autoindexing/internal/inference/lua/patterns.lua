@@ -28,12 +28,12 @@ M.new_path_extension = function(pattern)
   return new_pattern("(^|/)[^/]+.", pattern, "$")
 end
 
-M.new_path_combine = function(pattern)
-  return patterns.path_combine(pattern)
+M.new_path_combine = function(...)
+  return patterns.path_combine(...)
 end
 
-M.new_path_exclude = function(pattern)
-  return patterns.path_exclude(pattern)
+M.new_path_exclude = function(...)
+  return patterns.path_exclude(...)
 end
 
 return M
@@ -0,0 +1,70 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+-- The default PHP indexer is only used once its image is pinned to a digest. Until then,
+-- projects are only indexed if an indexer is configured in codeIntelAutoIndexing.indexerMap.
+local indexer = require("sg.autoindex.indexes").lookup "php"
+local outfile = "dump.lsif"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json files exist
+  generate = function(_, paths)
+    if indexer == nil then
+      return {}
+    end
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            -- Dependencies are needed to resolve symbols, but scripts may need an environment
+            -- we can't provide.
+            commands = { "composer install --no-interaction --no-progress --no-scripts --ignore-platform-reqs" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "lsif-php" },
+        outfile = outfile,
+        requested_envvars = { "COMPOSER_AUTH" },
+      })
+    end
+
+    return jobs
+  end,
+
+  -- Invoked when composer.json files exist
+  hints = function(_, paths)
+    if indexer == nil then
+      return {}
+    end
+
+    local hints = {}
+    for i = 1, #paths do
+      table.insert(hints, {
+        root = path.dirname(paths[i]),
+        indexer = indexer,
+        confidence = "PROJECT_STRUCTURE_SUPPORTED",
+      })
+    end
+
+    return hints
+  end,
+}
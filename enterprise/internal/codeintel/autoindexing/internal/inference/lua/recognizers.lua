@@ -2,11 +2,15 @@ local config = require("sg.autoindex.config").new {}
 
 for _, name in ipairs {
   "clang",
+  "dotnet",
   "go",
   "java",
+  "kotlin",
+  "php",
   "python",
   "ruby",
   "rust",
+  "scala",
   "test",
   "typescript",
 } do
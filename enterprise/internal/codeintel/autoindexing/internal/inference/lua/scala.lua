@@ -0,0 +1,4 @@
+local jvm = require "sg.autoindex.jvm"
+
+-- Invoked when sbt build files exist
+return jvm.new_build_tool_recognizer({ "build.sbt" }, "sbt")
@@ -53,7 +53,8 @@ func FlattenPatterns(pathPatterns []*PathPattern, inverted bool) (patterns []str
 }
 
 // FlattenPattern returns the set of patterns matching the given inverted flag on this
-// path pattern or any of its descendants.
+// path pattern or any of its descendants. All descendants of an exclude pattern are
+// considered inverted.
 func FlattenPattern(pathPattern *PathPattern, inverted bool) (patterns []string) {
 	if pathPattern.invert == inverted {
 		if pathPattern.pattern != "" {
@@ -61,13 +62,31 @@ func FlattenPattern(pathPattern *PathPattern, inverted bool) (patterns []string)
 		}
 
 		for _, child := range pathPattern.children {
-			patterns = append(patterns, FlattenPattern(child, inverted)...)
+			if pathPattern.invert {
+				patterns = append(patterns, flattenAllPatterns(child)...)
+			} else {
+				patterns = append(patterns, FlattenPattern(child, inverted)...)
+			}
 		}
 	}
 
 	return
 }
 
+// flattenAllPatterns returns the set of patterns on this path pattern or any of its
+// descendants, regardless of their inverted flags.
+func flattenAllPatterns(pathPattern *PathPattern) (patterns []string) {
+	if pathPattern.pattern != "" {
+		patterns = append(patterns, pathPattern.pattern)
+	}
+
+	for _, child := range pathPattern.children {
+		patterns = append(patterns, flattenAllPatterns(child)...)
+	}
+
+	return
+}
+
 // PathPatternsFromUserData decodes a single path pattern or slice of path patterns from
 // the given Lua value.
 func PathPatternsFromUserData(value lua.LValue) (patterns []*PathPattern, err error) {
@@ -8,7 +8,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestEmptyGenerators(t *testing.T) {
@@ -164,3 +166,12 @@ func sortIndexJobs(s []config.IndexJob) []config.IndexJob {
 
 	return s
 }
+
+// mockIndexerMap sets the codeIntelAutoIndexing.indexerMap site configuration for the duration of
+// the test.
+func mockIndexerMap(t *testing.T, indexers map[string]string) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelAutoIndexingIndexerMap: indexers,
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+}
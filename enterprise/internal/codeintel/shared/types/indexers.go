@@ -78,6 +78,10 @@ var (
 		Name: "lsif-dotnet",
 		URN:  "github.com/tcz717/LsifDotnet",
 	}
+	scipDotnet = CodeIntelIndexer{
+		Name: "scip-dotnet",
+		URN:  "github.com/sourcegraph/scip-dotnet",
+	}
 )
 
 var AllIndexers = []CodeIntelIndexer{
@@ -99,6 +103,7 @@ var AllIndexers = []CodeIntelIndexer{
 	lsifPHP,
 	lsifTerraform,
 	lsifDotnet,
+	scipDotnet,
 }
 
 // A map of file extension to a list of indexers in order of recommendation
@@ -126,7 +131,7 @@ var LanguageToIndexer = map[string][]CodeIntelIndexer{
 	".rs":      {rustAnalyzer},
 	".php":     {lsifPHP},
 	".tf":      {lsifTerraform},
-	".cs":      {lsifDotnet},
+	".cs":      {scipDotnet, lsifDotnet},
 }
 
 var ImageToIndexer = map[string]CodeIntelIndexer{
@@ -138,6 +143,7 @@ var ImageToIndexer = map[string]CodeIntelIndexer{
 	"davidrjenni/lsif-php":        lsifPHP,
 	"sourcegraph/lsif-rust":       rustAnalyzer,
 	"sourcegraph/scip-python":     scipPython,
+	"sourcegraph/scip-dotnet":     scipDotnet,
 }
 
 var PreferredIndexers = map[string]CodeIntelIndexer{
@@ -159,4 +165,5 @@ var PreferredIndexers = map[string]CodeIntelIndexer{
 	"lsif-php":        lsifPHP,
 	"lsif-terraform":  lsifTerraform,
 	"lsif-dotnet":     lsifDotnet,
+	"scip-dotnet":     scipDotnet,
 }
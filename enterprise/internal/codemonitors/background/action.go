@@ -18,4 +18,8 @@ type actionArgs struct {
 	Query          string
 	Results        []*result.CommitMatch
 	IncludeResults bool
+
+	// Digest, if set, holds the results of all trigger runs since the last
+	// digest, grouped by repository. Results then contains all of them.
+	Digest *digest
 }
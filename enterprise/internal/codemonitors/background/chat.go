@@ -0,0 +1,126 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// discordMaxContentLength is the maximum length of the content of a Discord
+// message.
+const discordMaxContentLength = 2000
+
+// mattermostMessage is the payload of a Mattermost incoming webhook, which is
+// compatible with Slack's legacy incoming webhooks.
+//
+// See https://developers.mattermost.com/integrate/webhooks/incoming/
+type mattermostMessage struct {
+	Text string `json:"text"`
+}
+
+// discordMessage is the payload of a Discord webhook.
+//
+// See https://discord.com/developers/docs/resources/webhook#execute-webhook
+type discordMessage struct {
+	Content string `json:"content"`
+}
+
+func sendChatNotification(ctx context.Context, service edb.ChatWebhookService, url string, args actionArgs) error {
+	return postChatWebhook(ctx, httpcli.ExternalDoer, service, url, chatMessageText(args))
+}
+
+// chatMessageText renders the notification as markdown, which is understood
+// by both Mattermost and Discord.
+func chatMessageText(args actionArgs) string {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	var b strings.Builder
+	fmt.Fprintf(&b,
+		"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.\n",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+			}
+			fmt.Fprintf(&b,
+				"\n%s match: [%s@%s](%s)\n",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)
+			var contentRaw string
+			if result.DiffPreview != nil {
+				contentRaw = truncateString(result.DiffPreview.Content, 10)
+			} else {
+				contentRaw = truncateString(result.MessagePreview.Content, 10)
+			}
+			b.WriteString(formatMarkdownCodeBlock(contentRaw))
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b,
+				"\n...and [%d more matches](%s).\n",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)
+		}
+	} else {
+		fmt.Fprintf(&b, "\n[View results](%s)\n", getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+	}
+
+	fmt.Fprintf(&b,
+		"\nIf you are %s, you can [edit your code monitor](%s)",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)
+	return b.String()
+}
+
+// formatMarkdownCodeBlock returns s as a fenced code block. Unlike Slack,
+// Mattermost and Discord treat text after the opening fence as the language,
+// so the content starts on a new line.
+func formatMarkdownCodeBlock(s string) string {
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return fmt.Sprintf("```\n%s```\n", strings.ReplaceAll(s, "```", "\\`\\`\\`"))
+}
+
+// chatPayload returns the JSON payload that posts text to an incoming webhook
+// of service.
+func chatPayload(service edb.ChatWebhookService, text string) ([]byte, error) {
+	switch service {
+	case edb.ChatWebhookServiceMattermost:
+		return json.Marshal(mattermostMessage{Text: text})
+	case edb.ChatWebhookServiceDiscord:
+		if runes := []rune(text); len(runes) > discordMaxContentLength {
+			text = string(runes[:discordMaxContentLength-3]) + "..."
+		}
+		return json.Marshal(discordMessage{Content: text})
+	default:
+		return nil, errors.Errorf("unknown chat service %q", service)
+	}
+}
+
+func postChatWebhook(ctx context.Context, doer httpcli.Doer, service edb.ChatWebhookService, url, text string) error {
+	raw, err := chatPayload(service, text)
+	if err != nil {
+		return err
+	}
+	return postJSONWebhook(ctx, doer, url, raw)
+}
+
+func SendTestChatWebhook(ctx context.Context, doer httpcli.Doer, service edb.ChatWebhookService, description, url string) error {
+	return postChatWebhook(ctx, doer, service, url, fmt.Sprintf("Test message for Code Monitor '%s'", description))
+}
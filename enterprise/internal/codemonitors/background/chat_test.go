@@ -0,0 +1,105 @@
+package background
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestChatWebhook(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	for _, service := range []edb.ChatWebhookService{edb.ChatWebhookServiceMattermost, edb.ChatWebhookServiceDiscord} {
+		service := service
+		t.Run(strings.ToLower(string(service)), func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				autogold.Equal(t, autogold.Raw(b))
+				// Discord responds with 204 No Content.
+				w.WriteHeader(204)
+			}))
+			defer s.Close()
+
+			client := s.Client()
+			err := postChatWebhook(context.Background(), client, service, s.URL, chatMessageText(action))
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("error is returned", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+		}))
+		defer s.Close()
+
+		client := s.Client()
+		err := postChatWebhook(context.Background(), client, edb.ChatWebhookServiceMattermost, s.URL, chatMessageText(action))
+		require.Error(t, err)
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		_, err := chatPayload("IRC", "hello")
+		require.Error(t, err)
+	})
+
+	t.Run("discord content is truncated", func(t *testing.T) {
+		b, err := chatPayload(edb.ChatWebhookServiceDiscord, strings.Repeat("ü", 3000))
+		require.NoError(t, err)
+		require.Equal(t, `{"content":"`+strings.Repeat("ü", discordMaxContentLength-3)+`..."}`, string(b))
+	})
+
+	t.Run("golden with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		autogold.Equal(t, autogold.Raw(chatMessageText(actionCopy)))
+	})
+
+	t.Run("golden with truncated results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		// quadruple the number of results
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		autogold.Equal(t, autogold.Raw(chatMessageText(actionCopy)))
+	})
+
+	t.Run("golden without results", func(t *testing.T) {
+		autogold.Equal(t, autogold.Raw(chatMessageText(action)))
+	})
+}
+
+func TestTriggerTestChatWebhookAction(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		autogold.Equal(t, autogold.Raw(b))
+		w.WriteHeader(200)
+	}))
+	defer s.Close()
+
+	client := s.Client()
+	err := SendTestChatWebhook(context.Background(), client, edb.ChatWebhookServiceDiscord, "My test monitor", s.URL)
+	require.NoError(t, err)
+}
@@ -0,0 +1,136 @@
+package background
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// digestMaxRepos is the maximum number of repositories listed in a digest.
+	digestMaxRepos = 10
+
+	// digestMaxResultsPerRepo is the maximum number of results listed per
+	// repository in a digest.
+	digestMaxResultsPerRepo = 3
+)
+
+// digest combines the results of all trigger runs of a code monitor since an
+// action last sent a digest.
+type digest struct {
+	Interval edb.DigestInterval
+
+	// RunCount is the number of trigger runs that found results.
+	RunCount int
+
+	// Repos are the results grouped by repository, with the repositories with
+	// the most results first.
+	Repos []*digestRepo
+
+	// Query is the query of the first trigger run in the digest. Its after:
+	// filter, if any, includes the results of all later runs.
+	Query string
+
+	// LastTriggerJob is the most recent trigger job included in the digest.
+	LastTriggerJob int32
+}
+
+type digestRepo struct {
+	Name    string
+	Count   int
+	Results []*result.CommitMatch
+}
+
+// newDigest returns the digest of the given trigger jobs, or nil if none of
+// them found results.
+func newDigest(interval edb.DigestInterval, triggerJobs []*edb.TriggerJob) *digest {
+	d := &digest{Interval: interval}
+	repos := map[string]*digestRepo{}
+	for _, tj := range triggerJobs {
+		if len(tj.SearchResults) == 0 {
+			continue
+		}
+		d.RunCount++
+		if d.Query == "" && tj.QueryString != nil {
+			d.Query = *tj.QueryString
+		}
+		if tj.ID > d.LastTriggerJob {
+			d.LastTriggerJob = tj.ID
+		}
+		for _, res := range tj.SearchResults {
+			name := string(res.Repo.Name)
+			repo, ok := repos[name]
+			if !ok {
+				repo = &digestRepo{Name: name}
+				repos[name] = repo
+				d.Repos = append(d.Repos, repo)
+			}
+			repo.Count += res.ResultCount()
+			repo.Results = append(repo.Results, res)
+		}
+	}
+	if d.RunCount == 0 {
+		return nil
+	}
+
+	sort.SliceStable(d.Repos, func(i, j int) bool {
+		if d.Repos[i].Count != d.Repos[j].Count {
+			return d.Repos[i].Count > d.Repos[j].Count
+		}
+		return d.Repos[i].Name < d.Repos[j].Name
+	})
+	return d
+}
+
+// Results returns the results of all repositories in the digest.
+func (d *digest) Results() []*result.CommitMatch {
+	var results []*result.CommitMatch
+	for _, repo := range d.Repos {
+		results = append(results, repo.Results...)
+	}
+	return results
+}
+
+// TotalCount returns the number of results in the digest.
+func (d *digest) TotalCount() int {
+	count := 0
+	for _, repo := range d.Repos {
+		count += repo.Count
+	}
+	return count
+}
+
+// TruncatedRepos returns the repositories listed in a digest notification and
+// the number of repositories that are left out.
+func (d *digest) TruncatedRepos() (_ []*digestRepo, truncatedCount int) {
+	if len(d.Repos) <= digestMaxRepos {
+		return d.Repos, 0
+	}
+	return d.Repos[:digestMaxRepos], len(d.Repos) - digestMaxRepos
+}
+
+// Adjective returns the interval of the digest for use in a sentence, such
+// as "daily".
+func (d *digest) Adjective() string {
+	return strings.ToLower(string(d.Interval))
+}
+
+// collectDigest returns the digest of the trigger runs of the monitor that
+// haven't been sent by the action yet, or nil if there are none. The digest
+// starts with the trigger event of the action job, which is the first one
+// after the previous digest was sent.
+func collectDigest(ctx context.Context, s edb.CodeMonitorStore, monitorID int64, j *edb.ActionJob, interval edb.DigestInterval, lastDigestTriggerEvent *int32) (*digest, error) {
+	from := j.TriggerEvent
+	if lastDigestTriggerEvent != nil && *lastDigestTriggerEvent >= from {
+		from = *lastDigestTriggerEvent + 1
+	}
+	triggerJobs, err := s.ListDigestTriggerJobs(ctx, monitorID, from)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListDigestTriggerJobs")
+	}
+	return newDigest(interval, triggerJobs), nil
+}
@@ -0,0 +1,58 @@
+package background
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// otherRepoResultMock is a copy of diffResultMock in a different repository.
+var otherRepoResultMock = func() result.CommitMatch {
+	m := diffResultMock
+	m.Repo.Name = api.RepoName("github.com/test/other")
+	return m
+}()
+
+func TestNewDigest(t *testing.T) {
+	query := "repo:test after:\"2023-01-16T10:00:00Z\""
+
+	t.Run("no results", func(t *testing.T) {
+		d := newDigest(edb.DigestIntervalDaily, []*edb.TriggerJob{{ID: 1, QueryString: &query}})
+		require.Nil(t, d)
+	})
+
+	t.Run("grouped by repository", func(t *testing.T) {
+		laterQuery := "repo:test after:\"2023-01-16T11:00:00Z\""
+		d := newDigest(edb.DigestIntervalDaily, []*edb.TriggerJob{{
+			ID:            3,
+			QueryString:   &query,
+			SearchResults: []*result.CommitMatch{&commitResultMock},
+		}, {
+			ID:          4,
+			QueryString: &laterQuery,
+		}, {
+			ID:            5,
+			QueryString:   &laterQuery,
+			SearchResults: []*result.CommitMatch{&otherRepoResultMock, &diffResultMock},
+		}})
+
+		require.Equal(t, 2, d.RunCount)
+		require.Equal(t, query, d.Query)
+		require.Equal(t, int32(5), d.LastTriggerJob)
+		require.Equal(t, "daily", d.Adjective())
+		require.Equal(t, 5, d.TotalCount())
+
+		// Repositories with more results come first.
+		require.Len(t, d.Repos, 2)
+		require.Equal(t, "github.com/test/test", d.Repos[0].Name)
+		require.Equal(t, 3, d.Repos[0].Count)
+		require.Equal(t, []*result.CommitMatch{&commitResultMock, &diffResultMock}, d.Repos[0].Results)
+		require.Equal(t, "github.com/test/other", d.Repos[1].Name)
+		require.Equal(t, 2, d.Repos[1].Count)
+		require.Len(t, d.Results(), 3)
+	})
+}
@@ -44,7 +44,7 @@ var (
 )
 
 var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
-	Subject: `{{ if .IsTest }}Test: {{ end }}{{.Priority}}Sourcegraph code monitor {{.Description}} detected {{.TotalCount}} new {{.ResultPluralized}}`,
+	Subject: `{{ if .IsTest }}Test: {{ end }}{{.Priority}}Sourcegraph code monitor {{.Description}} detected {{.TotalCount}} new {{.ResultPluralized}}{{ with .Digest }} ({{.Interval}} digest){{ end }}`,
 	Text:    textTemplate,
 	HTML:    htmlTemplate,
 })
@@ -62,6 +62,30 @@ type TemplateDataNewSearchResults struct {
 	TruncatedResultPluralized string
 	DisplayMoreLink           bool
 	IsTest                    bool
+
+	// Digest is set if the email combines the results of several runs of the
+	// code monitor.
+	Digest *DigestTemplateData
+}
+
+type DigestTemplateData struct {
+	Interval                string
+	RunCount                int
+	RunPluralized           string
+	RepoCount               int
+	RepoPluralized          string
+	Repos                   []*DigestRepoTemplateData
+	TruncatedRepoCount      int
+	TruncatedRepoPluralized string
+}
+
+type DigestRepoTemplateData struct {
+	RepoName                  string
+	Count                     int
+	ResultPluralized          string
+	TruncatedResults          []*DisplayResult
+	TruncatedCount            int
+	TruncatedResultPluralized string
 }
 
 func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction) (d *TemplateDataNewSearchResults, err error) {
@@ -78,11 +102,23 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 		priority = ""
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	var (
+		displayResults             []*DisplayResult
+		totalCount, truncatedCount int
+		digestData                 *DigestTemplateData
+	)
+	if args.Digest != nil {
+		// Digests list the results per repository instead.
+		digestData = newDigestTemplateData(args.Digest, args.ExternalURL)
+		totalCount = args.Digest.TotalCount()
+	} else {
+		var truncatedResults []*result.CommitMatch
+		truncatedResults, totalCount, truncatedCount = truncateResults(args.Results, 5)
 
-	displayResults := make([]*DisplayResult, len(truncatedResults))
-	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+		displayResults = make([]*DisplayResult, len(truncatedResults))
+		for i, result := range truncatedResults {
+			displayResults[i] = toDisplayResult(result, args.ExternalURL)
+		}
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -97,9 +133,39 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 		ResultPluralized:          pluralize("result", totalCount),
 		TruncatedResultPluralized: pluralize("result", truncatedCount),
 		DisplayMoreLink:           args.IncludeResults && truncatedCount > 0,
+		Digest:                    digestData,
 	}, nil
 }
 
+func newDigestTemplateData(d *digest, externalURL *url.URL) *DigestTemplateData {
+	repos, truncatedRepoCount := d.TruncatedRepos()
+	data := &DigestTemplateData{
+		Interval:                d.Adjective(),
+		RunCount:                d.RunCount,
+		RunPluralized:           pluralize("run", d.RunCount),
+		RepoCount:               len(d.Repos),
+		RepoPluralized:          pluralizeRepository(len(d.Repos)),
+		TruncatedRepoCount:      truncatedRepoCount,
+		TruncatedRepoPluralized: pluralizeRepository(truncatedRepoCount),
+	}
+	for _, repo := range repos {
+		truncatedResults, _, truncatedCount := truncateResults(repo.Results, digestMaxResultsPerRepo)
+		displayResults := make([]*DisplayResult, len(truncatedResults))
+		for i, result := range truncatedResults {
+			displayResults[i] = toDisplayResult(result, externalURL)
+		}
+		data.Repos = append(data.Repos, &DigestRepoTemplateData{
+			RepoName:                  repo.Name,
+			Count:                     repo.Count,
+			ResultPluralized:          pluralize("result", repo.Count),
+			TruncatedResults:          displayResults,
+			TruncatedCount:            truncatedCount,
+			TruncatedResultPluralized: pluralize("result", truncatedCount),
+		})
+	}
+	return data
+}
+
 func NewTestTemplateDataForNewSearchResults(monitorDescription string) *TemplateDataNewSearchResults {
 	return &TemplateDataNewSearchResults{
 		IsTest:                    true,
@@ -122,6 +188,12 @@ func NewTestTemplateDataForNewSearchResults(monitorDescription string) *Template
 }
 
 func sendEmail(ctx context.Context, db database.DB, userID int32, template txtypes.Templates, data any) error {
+	return SendEmail(ctx, db, userID, "code-monitor", template, data)
+}
+
+// SendEmail sends an email rendered from template and data to the primary
+// email address of the user. source identifies the feature sending the email.
+func SendEmail(ctx context.Context, db database.DB, userID int32, source string, template txtypes.Templates, data any) error {
 	email, _, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
 	if err != nil {
 		if errcode.IsNotFound(err) {
@@ -129,7 +201,7 @@ func sendEmail(ctx context.Context, db database.DB, userID int32, template txtyp
 		}
 		return errors.Errorf("internalapi.Client.UserEmailsGetEmail for userID=%d: %w", userID, err)
 	}
-	if err := internalapi.Client.SendEmail(ctx, "code-monitor", txtypes.Message{
+	if err := internalapi.Client.SendEmail(ctx, source, txtypes.Message{
 		To:       []string{email},
 		Template: template,
 		Data:     data,
@@ -193,6 +265,13 @@ func pluralize(word string, count int) string {
 	return word + "s"
 }
 
+func pluralizeRepository(count int) string {
+	if count == 1 {
+		return "repository"
+	}
+	return "repositories"
+}
+
 type DisplayResult struct {
 	ResultType string
 	CommitURL  string
@@ -8,6 +8,8 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/txemail"
 )
 
@@ -185,4 +187,43 @@ func TestEmail(t *testing.T) {
 		})
 	})
 
+	t.Run("digest with results", func(t *testing.T) {
+		d := newDigest(edb.DigestIntervalDaily, []*edb.TriggerJob{{
+			ID:            1,
+			SearchResults: []*result.CommitMatch{&commitResultMock, &diffResultMock},
+		}, {
+			ID:            2,
+			SearchResults: []*result.CommitMatch{&otherRepoResultMock, &commitResultMock, &diffResultMock},
+		}})
+		templateData, err := NewTemplateDataForNewSearchResults(actionArgs{
+			MonitorDescription: "My test monitor",
+			ExternalURL:        externalURLMock,
+			Query:              "repo:test",
+			Results:            d.Results(),
+			IncludeResults:     true,
+			Digest:             d,
+		}, &edb.EmailAction{Monitor: 1})
+		require.NoError(t, err)
+
+		t.Run("html", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Html.Execute(&buf, templateData)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(buf.String()))
+		})
+
+		t.Run("text", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Text.Execute(&buf, templateData)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(buf.String()))
+		})
+
+		t.Run("subject", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Subj.Execute(&buf, templateData)
+			require.NoError(t, err)
+			require.Equal(t, "Sourcegraph code monitor My test monitor detected 8 new results (daily digest)", buf.String())
+		})
+	})
 }
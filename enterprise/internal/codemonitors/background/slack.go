@@ -17,10 +17,14 @@ import (
 )
 
 func sendSlackNotification(ctx context.Context, url string, args actionArgs) error {
-	return postSlackWebhook(ctx, httpcli.ExternalDoer, url, slackPayload(args))
+	return PostSlackWebhook(ctx, httpcli.ExternalDoer, url, slackPayload(args))
 }
 
 func slackPayload(args actionArgs) *slack.WebhookMessage {
+	if args.Digest != nil {
+		return slackDigestPayload(args)
+	}
+
 	newMarkdownSection := func(s string) slack.Block {
 		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
 	}
@@ -81,6 +85,76 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
 }
 
+// slackDigestPayload lists the results of a digest by repository. Unlike
+// regular notifications, it only links to the results, which keeps the message
+// within Slack's limit of 50 blocks.
+func slackDigestPayload(args actionArgs) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	d := args.Digest
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, *%s*, detected *%d* new matches in %d %s over %d %s. This is your %s digest.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			d.TotalCount(),
+			len(d.Repos),
+			pluralizeRepository(len(d.Repos)),
+			d.RunCount,
+			pluralize("run", d.RunCount),
+			d.Adjective(),
+		)),
+	}
+
+	repos, truncatedRepoCount := d.TruncatedRepos()
+	for _, repo := range repos {
+		var b strings.Builder
+		fmt.Fprintf(&b, "*%s*: %d new matches", repo.Name, repo.Count)
+		if args.IncludeResults {
+			truncatedResults, _, truncatedCount := truncateResults(repo.Results, digestMaxResultsPerRepo)
+			for _, result := range truncatedResults {
+				resultType := "Message"
+				if result.DiffPreview != nil {
+					resultType = "Diff"
+				}
+				fmt.Fprintf(&b,
+					"\n• %s match: <%s|%s@%s>",
+					resultType,
+					getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+					result.Repo.Name,
+					result.Commit.ID.Short(),
+				)
+			}
+			if truncatedCount > 0 {
+				fmt.Fprintf(&b, "\n...and %d more matches.", truncatedCount)
+			}
+		}
+		blocks = append(blocks, newMarkdownSection(b.String()))
+	}
+	if truncatedRepoCount > 0 {
+		blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+			"...and new matches in %d more %s.",
+			truncatedRepoCount,
+			pluralizeRepository(truncatedRepoCount),
+		)))
+	}
+
+	blocks = append(blocks,
+		newMarkdownSection(fmt.Sprintf(
+			"<%s|View results>",
+			getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+		)),
+		newMarkdownSection(fmt.Sprintf(
+			`If you are %s, you can <%s|edit your code monitor>`,
+			args.MonitorOwnerName,
+			getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		)),
+	)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
 func formatCodeBlock(s string) string {
 	return fmt.Sprintf("```%s```", strings.ReplaceAll(s, "```", "\\`\\`\\`"))
 }
@@ -114,8 +188,10 @@ func truncateResults(results []*result.CommitMatch, maxResults int) (_ []*result
 	return output, totalCount, totalCount - outputCount
 }
 
+// PostSlackWebhook posts msg to the Slack incoming webhook at url.
+//
 // adapted from slack.PostWebhookCustomHTTPContext
-func postSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
+func PostSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
 	raw, err := json.Marshal(msg)
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
@@ -161,5 +237,5 @@ func SendTestSlackWebhook(ctx context.Context, doer httpcli.Doer, description, u
 		),
 	}}}
 
-	return postSlackWebhook(ctx, doer, url, testMessage)
+	return PostSlackWebhook(ctx, doer, url, testMessage)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -45,7 +46,7 @@ func TestSlackWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
+		err := PostSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
 		require.NoError(t, err)
 	})
 
@@ -59,7 +60,7 @@ func TestSlackWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
+		err := PostSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
 		require.Error(t, err)
 	})
 
@@ -83,6 +84,20 @@ func TestSlackWebhook(t *testing.T) {
 	t.Run("golden without results", func(t *testing.T) {
 		autogold.Equal(t, jsonSlackPayload(action))
 	})
+
+	t.Run("golden digest", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.Digest = newDigest(edb.DigestIntervalWeekly, []*edb.TriggerJob{{
+			ID:            1,
+			SearchResults: []*result.CommitMatch{&diffResultMock, &commitResultMock, &diffResultMock},
+		}, {
+			ID:            2,
+			SearchResults: []*result.CommitMatch{&otherRepoResultMock},
+		}})
+		actionCopy.Results = actionCopy.Digest.Results()
+		autogold.Equal(t, jsonSlackPayload(actionCopy))
+	})
 }
 
 func TestTriggerTestSlackWebhookAction(t *testing.T) {
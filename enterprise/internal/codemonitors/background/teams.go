@@ -0,0 +1,190 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// teamsMessage is the payload of a Microsoft Teams incoming webhook that
+// contains a single Adaptive Card.
+//
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using#send-adaptive-cards-using-an-incoming-webhook
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     adaptiveCard `json:"content"`
+}
+
+// adaptiveCard is the subset of the Adaptive Card schema that we use.
+//
+// See https://adaptivecards.io/explorer/AdaptiveCard.html
+type adaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []adaptiveCardElement `json:"body"`
+	Actions []adaptiveCardAction  `json:"actions,omitempty"`
+	MSTeams adaptiveCardMSTeams   `json:"msteams"`
+}
+
+type adaptiveCardElement struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Wrap     bool   `json:"wrap"`
+	FontType string `json:"fontType,omitempty"`
+	Size     string `json:"size,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+}
+
+type adaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type adaptiveCardMSTeams struct {
+	Width string `json:"width"`
+}
+
+func sendTeamsNotification(ctx context.Context, url string, args actionArgs) error {
+	return postTeamsWebhook(ctx, httpcli.ExternalDoer, url, teamsPayload(args))
+}
+
+func newTeamsMessage(body []adaptiveCardElement, actions []adaptiveCardAction) *teamsMessage {
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+				MSTeams: adaptiveCardMSTeams{Width: "Full"},
+			},
+		}},
+	}
+}
+
+func teamsPayload(args actionArgs) *teamsMessage {
+	newTextBlock := func(s string) adaptiveCardElement {
+		return adaptiveCardElement{Type: "TextBlock", Text: s, Wrap: true}
+	}
+
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	body := []adaptiveCardElement{{
+		Type:   "TextBlock",
+		Text:   "Sourcegraph code monitor",
+		Size:   "Medium",
+		Weight: "Bolder",
+	}, newTextBlock(fmt.Sprintf(
+		"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	))}
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+			}
+			body = append(body, newTextBlock(fmt.Sprintf(
+				"%s match: [%s@%s](%s)",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)))
+			var contentRaw string
+			if result.DiffPreview != nil {
+				contentRaw = truncateString(result.DiffPreview.Content, 10)
+			} else {
+				contentRaw = truncateString(result.MessagePreview.Content, 10)
+			}
+			codeBlock := newTextBlock(contentRaw)
+			codeBlock.FontType = "Monospace"
+			body = append(body, codeBlock)
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTextBlock(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)))
+		}
+	}
+
+	actions := []adaptiveCardAction{{
+		Type:  "Action.OpenUrl",
+		Title: "View results",
+		URL:   getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+	}, {
+		Type:  "Action.OpenUrl",
+		Title: "Edit code monitor",
+		URL:   getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	}}
+
+	return newTeamsMessage(body, actions)
+}
+
+func postTeamsWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *teamsMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	return postJSONWebhook(ctx, doer, url, raw)
+}
+
+// postJSONWebhook posts a JSON payload to an incoming webhook of a chat
+// service. Unlike Slack, these services may respond with any 2xx status on
+// success.
+func postJSONWebhook(ctx context.Context, doer httpcli.Doer, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "failed new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return StatusCodeError{
+			Code:   resp.StatusCode,
+			Status: resp.Status,
+			Body:   string(body),
+		}
+	}
+
+	return nil
+}
+
+func SendTestTeamsWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := newTeamsMessage([]adaptiveCardElement{{
+		Type: "TextBlock",
+		Text: fmt.Sprintf("Test message for Code Monitor '%s'", description),
+		Wrap: true,
+	}}, nil)
+
+	return postTeamsWebhook(ctx, doer, url, testMessage)
+}
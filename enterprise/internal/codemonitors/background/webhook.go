@@ -3,24 +3,76 @@ package background
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/url"
+	"text/template"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func sendWebhookNotification(ctx context.Context, url string, args actionArgs) error {
-	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
+// WebhookSignatureHeader is the header containing the HMAC-SHA256 signature
+// of the request body, if the webhook action has a secret. The value has the
+// form "sha256=<hex digest>".
+const WebhookSignatureHeader = "X-Sourcegraph-Signature-256"
+
+// WebhookOptions are the user-defined options of a webhook action.
+type WebhookOptions struct {
+	// PayloadTemplate is a text/template used to render the request body. It
+	// is executed with the default payload as data, so the template can refer
+	// to fields such as .MonitorDescription, .Query and .Results. If empty, the
+	// default payload is sent as JSON.
+	PayloadTemplate string
+	// Headers are set on the request, overriding default headers.
+	Headers map[string]string
+	// Secret, if set, is used to sign the request body.
+	Secret string
 }
 
-func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
-	raw, err := json.Marshal(payload)
+func sendWebhookNotification(ctx context.Context, w *edb.WebhookAction, args actionArgs) error {
+	webhookURL, err := w.URL.Decrypt(ctx)
 	if err != nil {
-		return errors.Wrap(err, "marshal failed")
+		return errors.Wrap(err, "decrypting webhook URL")
+	}
+	opts, err := webhookOptionsForAction(ctx, w)
+	if err != nil {
+		return err
+	}
+	return PostWebhook(ctx, httpcli.ExternalDoer, webhookURL, generateWebhookPayload(args), opts)
+}
+
+// webhookOptionsForAction decrypts the headers and secret of w.
+func webhookOptionsForAction(ctx context.Context, w *edb.WebhookAction) (opts WebhookOptions, err error) {
+	if w.PayloadTemplate != nil {
+		opts.PayloadTemplate = *w.PayloadTemplate
+	}
+	if w.Headers != nil {
+		if opts.Headers, err = w.Headers.Decrypt(ctx); err != nil {
+			return opts, errors.Wrap(err, "decrypting headers")
+		}
+	}
+	if w.Secret != nil {
+		if opts.Secret, err = w.Secret.Decrypt(ctx); err != nil {
+			return opts, errors.Wrap(err, "decrypting secret")
+		}
+	}
+	return opts, nil
+}
+
+// PostWebhook posts payload to url, rendered with the payload template of opts
+// if there is one. Besides the payload of code monitors, other features that
+// notify through webhooks can pass their own payload.
+func PostWebhook(ctx context.Context, doer httpcli.Doer, url string, payload any, opts WebhookOptions) error {
+	raw, err := renderWebhookPayload(payload, opts.PayloadTemplate)
+	if err != nil {
+		return err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
@@ -28,6 +80,12 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 		return errors.Wrap(err, "failed new request")
 	}
 	req.Header.Set("Content-Type", "application/json")
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+	if opts.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, signWebhookPayload(opts.Secret, raw))
+	}
 
 	resp, err := doer.Do(req)
 	if err != nil {
@@ -47,13 +105,63 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	return nil
 }
 
-func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string) error {
+func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string, opts WebhookOptions) error {
+	return PostWebhook(ctx, doer, u, generateTestWebhookPayload(description), opts)
+}
+
+func generateTestWebhookPayload(description string) webhookPayload {
 	args := actionArgs{
 		ExternalURL:        &url.URL{},
 		MonitorDescription: description,
 		Query:              "test query",
 	}
-	return postWebhook(ctx, httpcli.ExternalDoer, u, generateWebhookPayload(args))
+	return generateWebhookPayload(args)
+}
+
+// ValidateWebhookPayloadTemplate returns an error if tmpl can't be parsed or
+// can't be executed with a test payload.
+func ValidateWebhookPayloadTemplate(tmpl string) error {
+	_, err := renderWebhookPayload(generateTestWebhookPayload("test monitor"), tmpl)
+	return err
+}
+
+var webhookTemplateFuncs = template.FuncMap{
+	// json encodes a value as JSON, so that strings can be safely embedded in
+	// JSON payloads.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// renderWebhookPayload renders the request body for payload. If tmpl is
+// empty, payload is encoded as JSON.
+func renderWebhookPayload(payload any, tmpl string) ([]byte, error) {
+	if tmpl == "" {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal failed")
+		}
+		return raw, nil
+	}
+
+	t, err := template.New("payload").Funcs(webhookTemplateFuncs).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing payload template")
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, payload); err != nil {
+		return nil, errors.Wrap(err, "executing payload template")
+	}
+	return buf.Bytes(), nil
+}
+
+// signWebhookPayload returns the value of the signature header for the given
+// request body.
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
 }
 
 type webhookPayload struct {
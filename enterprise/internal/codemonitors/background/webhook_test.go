@@ -2,6 +2,9 @@ package background
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -38,7 +41,7 @@ func TestWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
+		err := PostWebhook(context.Background(), client, s.URL, generateWebhookPayload(action), WebhookOptions{})
 		require.NoError(t, err)
 	})
 
@@ -64,9 +67,67 @@ func TestWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
+		err := PostWebhook(context.Background(), client, s.URL, generateWebhookPayload(action), WebhookOptions{})
 		require.Error(t, err)
 	})
+
+	t.Run("payload template", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+
+		opts := WebhookOptions{
+			PayloadTemplate: `{"text": {{ json .MonitorDescription }}, "commits": [{{ range $i, $r := .Results }}{{ if $i }}, {{ end }}{{ json $r.Commit }}{{ end }}]}`,
+		}
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(b))
+			w.WriteHeader(200)
+		}))
+		defer s.Close()
+
+		err := PostWebhook(context.Background(), s.Client(), s.URL, generateWebhookPayload(actionCopy), opts)
+		require.NoError(t, err)
+	})
+
+	t.Run("headers and signature", func(t *testing.T) {
+		opts := WebhookOptions{
+			Headers: map[string]string{
+				"Authorization": "Bearer token",
+				"Content-Type":  "application/vnd.test+json",
+			},
+			Secret: "hunter2",
+		}
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			require.Equal(t, "application/vnd.test+json", r.Header.Get("Content-Type"))
+
+			mac := hmac.New(sha256.New, []byte("hunter2"))
+			mac.Write(b)
+			require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get(WebhookSignatureHeader))
+			w.WriteHeader(200)
+		}))
+		defer s.Close()
+
+		err := PostWebhook(context.Background(), s.Client(), s.URL, generateWebhookPayload(action), opts)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid payload template", func(t *testing.T) {
+		err := PostWebhook(context.Background(), http.DefaultClient, "http://invalid", generateWebhookPayload(action), WebhookOptions{
+			PayloadTemplate: "{{ .DoesNotExist }}",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestValidateWebhookPayloadTemplate(t *testing.T) {
+	require.NoError(t, ValidateWebhookPayloadTemplate(`{"text": {{ json .MonitorDescription }}}`))
+	require.Error(t, ValidateWebhookPayloadTemplate(`{{ .MonitorDescription `))
+	require.Error(t, ValidateWebhookPayloadTemplate(`{{ .DoesNotExist }}`))
 }
 
 func TestTriggerTestWebhookAction(t *testing.T) {
@@ -79,6 +140,6 @@ func TestTriggerTestWebhookAction(t *testing.T) {
 	defer s.Close()
 
 	client := s.Client()
-	err := SendTestWebhook(context.Background(), client, "My test monitor", s.URL)
+	err := SendTestWebhook(context.Background(), client, "My test monitor", s.URL, WebhookOptions{})
 	require.NoError(t, err)
 }
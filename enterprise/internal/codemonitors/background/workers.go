@@ -3,6 +3,7 @@ package background
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -15,9 +16,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -92,7 +95,7 @@ func newActionRunner(ctx context.Context, observationCtx *observation.Context, s
 
 	store := createDBWorkerStoreForActionJobs(observationCtx, s)
 
-	worker := dbworker.NewWorker[*edb.ActionJob](ctx, store, &actionRunner{s}, options)
+	worker := dbworker.NewWorker[*edb.ActionJob](ctx, store, &actionRunner{CodeMonitorStore: s, workerStore: store}, options)
 	return worker
 }
 
@@ -215,6 +218,7 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 
 type actionRunner struct {
 	edb.CodeMonitorStore
+	workerStore dbworkerstore.Store[*edb.ActionJob]
 }
 
 func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *edb.ActionJob) (err error) {
@@ -232,12 +236,16 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *edb.Act
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.TeamsWebhook != nil:
+		return r.handleTeamsWebhook(ctx, j)
+	case j.ChatWebhook != nil:
+		return r.handleChatWebhook(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, teams webhook, or chat webhook")
 	}
 }
 
-func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error {
+func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) (err error) {
 	s, err := r.CodeMonitorStore.Transact(ctx)
 	if err != nil {
 		return err
@@ -275,6 +283,21 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		IncludeResults:     e.IncludeResults,
 	}
 
+	if e.DigestInterval != edb.DigestIntervalNone {
+		args.Digest, err = collectDigest(ctx, s, m.MonitorID, j, e.DigestInterval, e.LastDigestTriggerEvent)
+		if err != nil {
+			return err
+		}
+		if args.Digest == nil {
+			// The results were sent by a previous digest already.
+			return nil
+		}
+		if err = s.SetEmailActionLastDigest(ctx, e.ID, args.Digest.LastTriggerJob); err != nil {
+			return errors.Wrap(err, "SetEmailActionLastDigest")
+		}
+		args.Results, args.Query = args.Digest.Results(), args.Digest.Query
+	}
+
 	data, err := NewTemplateDataForNewSearchResults(args, e)
 	if err != nil {
 		return errors.Wrap(err, "NewTemplateDataForNewSearchResults")
@@ -295,7 +318,16 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 	return nil
 }
 
-func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) error {
+func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) (err error) {
+	// deliveryFailure fails the job once the transaction is committed, so that
+	// the failed delivery attempt is recorded.
+	var deliveryFailure error
+	defer func() {
+		if err == nil {
+			err = deliveryFailure
+		}
+	}()
+
 	s, err := r.CodeMonitorStore.Transact(ctx)
 	if err != nil {
 		return err
@@ -328,10 +360,58 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		IncludeResults:     w.IncludeResults,
 	}
 
-	return sendWebhookNotification(ctx, w.URL, args)
+	deliveryErr := sendWebhookNotification(ctx, w, args)
+	attempts, err := s.RecordActionJobDeliveryAttempt(ctx, j.ID, deliveryErr)
+	if err != nil {
+		return errors.Wrap(err, "RecordActionJobDeliveryAttempt")
+	}
+	if deliveryErr == nil {
+		return nil
+	}
+
+	if !isRetryableWebhookError(deliveryErr) || attempts >= maxWebhookDeliveryAttempts {
+		deliveryFailure = errcode.MakeNonRetryable(deliveryErr)
+		return nil
+	}
+
+	// Instead of erroring, which retries the job after a fixed delay, we
+	// requeue the job ourselves to back off exponentially.
+	if err = r.workerStore.With(s).Requeue(ctx, int(j.ID), s.Now().Add(webhookRetryDelay(attempts))); err != nil {
+		return errors.Wrap(err, "Requeue")
+	}
+	return nil
+}
+
+const (
+	maxWebhookDeliveryAttempts = 5
+	webhookRetryBaseDelay      = 30 * time.Second
+	webhookRetryMaxDelay       = 30 * time.Minute
+)
+
+// webhookRetryDelay returns the delay before the next delivery attempt after
+// the given number of failed attempts.
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempts && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMaxDelay {
+		return webhookRetryMaxDelay
+	}
+	return delay
+}
+
+// isRetryableWebhookError returns false if err is a response status code that
+// indicates that retrying the request won't succeed.
+func isRetryableWebhookError(err error) bool {
+	var statusErr StatusCodeError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	return statusErr.Code >= 500 || statusErr.Code == http.StatusRequestTimeout || statusErr.Code == http.StatusTooManyRequests
 }
 
-func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob) error {
+func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob) (err error) {
 	s, err := r.CodeMonitorStore.Transact(ctx)
 	if err != nil {
 		return err
@@ -348,6 +428,11 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		return errors.Wrap(err, "GetSlackWebhookAction")
 	}
 
+	url, err := w.URL.Decrypt(ctx)
+	if err != nil {
+		return errors.Wrap(err, "decrypting webhook URL")
+	}
+
 	externalURL, err := getExternalURL(ctx)
 	if err != nil {
 		return err
@@ -364,7 +449,104 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		IncludeResults:     w.IncludeResults,
 	}
 
-	return sendSlackNotification(ctx, w.URL, args)
+	if w.DigestInterval != edb.DigestIntervalNone {
+		args.Digest, err = collectDigest(ctx, s, w.Monitor, j, w.DigestInterval, w.LastDigestTriggerEvent)
+		if err != nil {
+			return err
+		}
+		if args.Digest == nil {
+			// The results were sent by a previous digest already.
+			return nil
+		}
+		if err = s.SetSlackWebhookActionLastDigest(ctx, w.ID, args.Digest.LastTriggerJob); err != nil {
+			return errors.Wrap(err, "SetSlackWebhookActionLastDigest")
+		}
+		args.Results, args.Query = args.Digest.Results(), args.Digest.Query
+	}
+
+	return sendSlackNotification(ctx, url, args)
+}
+
+func (r *actionRunner) handleTeamsWebhook(ctx context.Context, j *edb.ActionJob) (err error) {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTeamsWebhookAction(ctx, *j.TeamsWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamsWebhookAction")
+	}
+
+	url, err := w.URL.Decrypt(ctx)
+	if err != nil {
+		return errors.Wrap(err, "decrypting webhook URL")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTeamsNotification(ctx, url, args)
+}
+
+func (r *actionRunner) handleChatWebhook(ctx context.Context, j *edb.ActionJob) (err error) {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetChatWebhookAction(ctx, *j.ChatWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetChatWebhookAction")
+	}
+
+	url, err := w.URL.Decrypt(ctx)
+	if err != nil {
+		return errors.Wrap(err, "decrypting webhook URL")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-chat-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendChatNotification(ctx, w.Service, url, args)
 }
 
 type StatusCodeError struct {
@@ -383,7 +565,9 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	// For q.LatestResult = nil we return a query string without after: filter, which
 	// effectively triggers actions immediately provided the query returns any
 	// results.
-	if q.LatestResult == nil {
+	//
+	// Queries that don't search commits can't have an after: filter.
+	if q.LatestResult == nil || !searchesCommits(q.QueryString) {
 		return q.QueryString
 	}
 	// ATTENTION: This is a stop gap. Add(time.Second) is necessary because currently
@@ -396,6 +580,23 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
 }
 
+// searchesCommits returns whether the query contains a type:diff or
+// type:commit filter.
+func searchesCommits(queryString string) bool {
+	q, err := query.ParseStandard(queryString)
+	if err != nil {
+		// Let the search report the error
+		return true
+	}
+	found := false
+	query.VisitField(q, query.FieldType, func(value string, _ bool, _ query.Annotation) {
+		if value == "diff" || value == "commit" {
+			found = true
+		}
+	})
+	return found
+}
+
 func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
 	if searchErr != nil || len(results) == 0 {
 		// Error performing the search, or there were no results. Assume the
@@ -2,6 +2,9 @@ package background
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -13,7 +16,10 @@ import (
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestActionRunner(t *testing.T) {
@@ -77,7 +83,7 @@ func TestActionRunner(t *testing.T) {
 			record, err := ts.GetActionJob(ctx, 1)
 			require.NoError(t, err)
 
-			a := actionRunner{s}
+			a := actionRunner{CodeMonitorStore: s}
 			err = a.Handle(ctx, logtest.Scoped(t), record)
 			require.NoError(t, err)
 
@@ -111,3 +117,81 @@ func TestActionRunner(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookRetryDelay(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		6:  16 * time.Minute,
+		7:  30 * time.Minute,
+		50: 30 * time.Minute,
+	} {
+		require.Equal(t, want, webhookRetryDelay(attempts), "attempts=%d", attempts)
+	}
+}
+
+func TestIsRetryableWebhookError(t *testing.T) {
+	require.True(t, isRetryableWebhookError(errors.New("connection refused")))
+	require.True(t, isRetryableWebhookError(StatusCodeError{Code: 502}))
+	require.True(t, isRetryableWebhookError(errors.Wrap(StatusCodeError{Code: 429}, "wrapped")))
+	require.False(t, isRetryableWebhookError(StatusCodeError{Code: 404}))
+}
+
+func TestNewQueryWithAfterFilter(t *testing.T) {
+	latestResult := time.Date(2023, 1, 17, 10, 0, 0, 0, time.UTC)
+
+	got := newQueryWithAfterFilter(&edb.QueryTrigger{QueryString: "type:diff repo:test foo", LatestResult: &latestResult})
+	require.Equal(t, `type:diff repo:test foo after:"2023-01-17T10:00:01Z"`, got)
+
+	// Queries that don't search commits don't support after:
+	got = newQueryWithAfterFilter(&edb.QueryTrigger{QueryString: "file:Dockerfile FROM ubuntu:18.04", LatestResult: &latestResult})
+	require.Equal(t, "file:Dockerfile FROM ubuntu:18.04", got)
+}
+
+func TestHandleWebhookDeliveryFailure(t *testing.T) {
+	MockExternalURL = func() *url.URL {
+		externalURL, _ := url.Parse("https://www.sourcegraph.com")
+		return externalURL
+	}
+	t.Cleanup(func() { MockExternalURL = nil })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	webhookID := int64(1)
+	job := &edb.ActionJob{ID: 1, Webhook: &webhookID}
+
+	newStore := func(recordErr error) (*edb.MockCodeMonitorStore, *[]error) {
+		var doneErrs []error
+		s := edb.NewMockCodeMonitorStore()
+		s.TransactFunc.SetDefaultReturn(s, nil)
+		s.DoneFunc.SetDefaultHook(func(err error) error {
+			doneErrs = append(doneErrs, err)
+			return err
+		})
+		s.GetActionJobMetadataFunc.SetDefaultReturn(&edb.ActionJobMetadata{Description: "monitor"}, nil)
+		s.GetWebhookActionFunc.SetDefaultReturn(&edb.WebhookAction{ID: webhookID, URL: encryption.NewUnencrypted(srv.URL)}, nil)
+		s.RecordActionJobDeliveryAttemptFunc.SetDefaultReturn(1, recordErr)
+		return s, &doneErrs
+	}
+
+	t.Run("failed delivery is recorded before failing the job", func(t *testing.T) {
+		s, doneErrs := newStore(nil)
+		err := (&actionRunner{CodeMonitorStore: s}).handleWebhook(context.Background(), job)
+		require.Error(t, err)
+		require.True(t, errcode.IsNonRetryable(err))
+		require.Equal(t, []error{nil}, *doneErrs)
+	})
+
+	t.Run("failing to record the delivery rolls back", func(t *testing.T) {
+		recordErr := errors.New("database is down")
+		s, doneErrs := newStore(recordErr)
+		err := (&actionRunner{CodeMonitorStore: s}).handleWebhook(context.Background(), job)
+		require.ErrorIs(t, err, recordErr)
+		require.Len(t, *doneErrs, 1)
+		require.ErrorIs(t, (*doneErrs)[0], recordErr)
+	})
+}
@@ -0,0 +1,357 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// maxResultSetSize is the maximum number of results stored for a code
+	// monitor whose query doesn't search commits. Their searches return up to
+	// this many results, and runs whose search returns more are skipped.
+	maxResultSetSize = 10000
+
+	// maxResultSetChanges is the maximum number of appeared and disappeared
+	// results reported by a single run.
+	maxResultSetChanges = 500
+
+	// maxPreviewLength is the maximum number of characters of a matched line
+	// stored for notifications.
+	maxPreviewLength = 200
+)
+
+const (
+	fingerprintKindRepo    = "repo"
+	fingerprintKindPath    = "path"
+	fingerprintKindContent = "content"
+	fingerprintKindSymbol  = "symbol"
+)
+
+var ErrMixedMonitorQuery = errors.New("code monitor cannot combine type:diff or type:commit searches with other searches")
+
+// isResultSetJob returns true if the job doesn't search commits, in which case
+// the code monitor compares the results of each run to the results of the
+// previous one instead of searching new commits.
+func isResultSetJob(j job.Job) (bool, error) {
+	commitJobs, otherJobs := 0, 0
+	job.Map(j, func(j job.Job) job.Job {
+		switch j.(type) {
+		case *commit.SearchJob:
+			commitJobs++
+		case *repos.ComputeExcludedJob, *jobutil.NoopJob:
+		default:
+			if len(j.Children()) == 0 {
+				otherJobs++
+			}
+		}
+		return j
+	})
+	if commitJobs > 0 && otherJobs > 0 {
+		return false, ErrMixedMonitorQuery
+	}
+	return commitJobs == 0, nil
+}
+
+// resultSetInputs returns a copy of inputs whose searches return up to
+// maxResultSetSize results: queries without a count: filter would otherwise
+// stop at the default limit, and their result sets would never be complete.
+// Larger counts are lowered to maxResultSetSize.
+func resultSetInputs(inputs *search.Inputs) *search.Inputs {
+	plan := make(query.Plan, 0, len(inputs.Plan))
+	for _, b := range inputs.Plan {
+		if count := b.Count(); count == nil {
+			parameters := append(append(make([]query.Parameter, 0, len(b.Parameters)+1), b.Parameters...), query.Parameter{
+				Field: query.FieldCount,
+				Value: strconv.Itoa(maxResultSetSize),
+			})
+			b = b.MapParameters(parameters)
+		} else if *count > maxResultSetSize {
+			b = b.MapCount(maxResultSetSize)
+		}
+		plan = append(plan, b)
+	}
+
+	withCount := *inputs
+	withCount.Plan = plan
+	withCount.Query = plan.ToQ()
+	return &withCount
+}
+
+// searchResultSet runs the search of a code monitor whose query doesn't search
+// commits and returns the results that appeared or disappeared since the
+// previous run. The first run only stores the result set.
+//
+// Results are only compared when the search returned all of them: a result
+// missing from a partial search didn't necessarily disappear, so incomplete
+// searches and searches that return too many results are skipped, and leave
+// the stored result set unchanged.
+func searchResultSet(ctx context.Context, logger log.Logger, db database.DB, monitorID int64, planJob job.Job, clients job.RuntimeClients) ([]*result.CommitMatch, error) {
+	agg := streaming.NewAggregatingStream()
+	if _, err := planJob.Run(ctx, clients, agg); err != nil {
+		return nil, err
+	}
+	if incompleteResults(agg.Stats) {
+		return nil, nil
+	}
+
+	next, matches := fingerprintResults(agg.Results)
+	if limitHit(agg.Stats) || len(next) > maxResultSetSize {
+		logger.Warn("skipping code monitor run: search returns too many results to compare them with the previous run",
+			log.Int64("monitorID", monitorID),
+			log.Int("maxResultSetSize", maxResultSetSize))
+		return nil, nil
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	prev, ok, err := cm.GetResultSet(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var appeared, disappeared []edb.ResultFingerprint
+	if ok {
+		appeared, disappeared = diffResultSets(prev, next)
+	}
+
+	changes, err := resultSetChanges(ctx, clients.Gitserver, matches, appeared, disappeared)
+	if err != nil {
+		return nil, err
+	}
+	if err := cm.UpsertResultSet(ctx, monitorID, next); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// limitHit returns true if a search stopped returning results because it
+// reached its result limit.
+func limitHit(stats streaming.Stats) bool {
+	return stats.IsLimitHit || stats.Status.Any(search.RepoStatusLimitHit)
+}
+
+// incompleteResults returns true if a search didn't search all repositories,
+// for example because it timed out or because some repositories are still
+// being cloned.
+func incompleteResults(stats streaming.Stats) bool {
+	return stats.Status.Any(search.RepoStatusCloning | search.RepoStatusTimedout)
+}
+
+// fingerprintResults returns the fingerprints of the given search results,
+// sorted and without duplicates, and the match each fingerprint comes from,
+// keyed by the fingerprint key.
+func fingerprintResults(matches result.Matches) ([]edb.ResultFingerprint, map[string]result.Match) {
+	var fingerprints []edb.ResultFingerprint
+	byKey := make(map[string]result.Match)
+	for _, match := range matches {
+		var fs []edb.ResultFingerprint
+		switch m := match.(type) {
+		case *result.RepoMatch:
+			fs = []edb.ResultFingerprint{{
+				Repo:    string(m.Name),
+				Kind:    fingerprintKindRepo,
+				Commit:  m.Rev,
+				Preview: string(m.Name),
+			}}
+		case *result.FileMatch:
+			fs = fingerprintFileMatch(m)
+		}
+		for _, f := range fs {
+			if _, ok := byKey[f.Key()]; !ok {
+				byKey[f.Key()] = match
+			}
+		}
+		fingerprints = append(fingerprints, fs...)
+	}
+	return sortResultSet(fingerprints), byKey
+}
+
+func fingerprintFileMatch(fm *result.FileMatch) []edb.ResultFingerprint {
+	newFingerprint := func(kind, identity, preview string) edb.ResultFingerprint {
+		f := edb.ResultFingerprint{
+			Repo:    string(fm.Repo.Name),
+			Path:    fm.Path,
+			Kind:    kind,
+			Commit:  string(fm.CommitID),
+			Preview: truncatePreview(preview),
+		}
+		if identity != "" {
+			f.Hash = hashIdentity(identity)
+		}
+		return f
+	}
+
+	var fingerprints []edb.ResultFingerprint
+	for _, sm := range fm.Symbols {
+		sym := sm.Symbol
+		fingerprints = append(fingerprints, newFingerprint(
+			fingerprintKindSymbol,
+			sym.Name+"\x00"+sym.Kind+"\x00"+sym.Parent,
+			sym.Name+" ("+sym.Kind+")",
+		))
+	}
+	for _, chunk := range fm.ChunkMatches {
+		// A content match is identified by the line it is on rather than its
+		// position, so that it doesn't reappear when lines above it change.
+		lines := strings.Split(chunk.Content, "\n")
+		for _, rr := range chunk.Ranges {
+			for l := rr.Start.Line; l <= rr.End.Line; l++ {
+				i := l - chunk.ContentStart.Line
+				if i < 0 || i >= len(lines) {
+					continue
+				}
+				line := strings.TrimSpace(lines[i])
+				fingerprints = append(fingerprints, newFingerprint(fingerprintKindContent, line, line))
+			}
+		}
+	}
+	if len(fingerprints) == 0 {
+		fingerprints = append(fingerprints, newFingerprint(fingerprintKindPath, "", fm.Path))
+	}
+	return fingerprints
+}
+
+// sortResultSet sorts and deduplicates the given fingerprints.
+func sortResultSet(fingerprints []edb.ResultFingerprint) []edb.ResultFingerprint {
+	sort.SliceStable(fingerprints, func(i, j int) bool {
+		return fingerprints[i].Key() < fingerprints[j].Key()
+	})
+	deduped := fingerprints[:0]
+	for _, f := range fingerprints {
+		if len(deduped) > 0 && f.Key() == deduped[len(deduped)-1].Key() {
+			continue
+		}
+		deduped = append(deduped, f)
+	}
+	return deduped
+}
+
+// diffResultSets returns the fingerprints in next that aren't in prev, and the
+// fingerprints in prev that aren't in next.
+func diffResultSets(prev, next []edb.ResultFingerprint) (appeared, disappeared []edb.ResultFingerprint) {
+	prevKeys := make(map[string]struct{}, len(prev))
+	for _, f := range prev {
+		prevKeys[f.Key()] = struct{}{}
+	}
+	nextKeys := make(map[string]struct{}, len(next))
+	for _, f := range next {
+		nextKeys[f.Key()] = struct{}{}
+		if _, ok := prevKeys[f.Key()]; !ok {
+			appeared = append(appeared, f)
+		}
+	}
+	for _, f := range prev {
+		if _, ok := nextKeys[f.Key()]; !ok {
+			disappeared = append(disappeared, f)
+		}
+	}
+	return appeared, disappeared
+}
+
+// resultSetChanges converts appeared and disappeared results into commit
+// matches, so that they can be sent by the existing code monitor actions. The
+// diff preview of each match is the path of the result followed by the
+// preview, prefixed with "+" if it appeared and "-" if it disappeared.
+//
+// Appeared results are built from the matches of the current search, at the
+// commit they were found at. Disappeared results point to the commit of the
+// previous search they were last found at. Repository results don't have a
+// commit, so they point to the commit their revision currently resolves to,
+// and are skipped if it doesn't resolve anymore.
+func resultSetChanges(ctx context.Context, gs gitserver.Client, matches map[string]result.Match, appeared, disappeared []edb.ResultFingerprint) ([]*result.CommitMatch, error) {
+	newChange := func(f edb.ResultFingerprint, repo types.MinimalRepo, commitID api.CommitID, prefix string) *result.CommitMatch {
+		content := prefix + f.Preview
+		if f.Kind != fingerprintKindRepo {
+			content = f.Path + "\n" + content
+		}
+		return &result.CommitMatch{
+			Repo:   repo,
+			Commit: gitdomain.Commit{ID: commitID},
+			DiffPreview: &result.MatchedString{
+				Content: content,
+			},
+		}
+	}
+	resolveCommit := func(repo api.RepoName, rev string) (api.CommitID, bool, error) {
+		commitID, err := gs.ResolveRevision(ctx, repo, rev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				return "", false, nil
+			}
+			return "", false, errors.Wrapf(err, "resolving revision %q of %s", rev, repo)
+		}
+		return commitID, true, nil
+	}
+
+	var changes []*result.CommitMatch
+	for _, f := range appeared {
+		if len(changes) >= maxResultSetChanges {
+			return changes, nil
+		}
+		switch m := matches[f.Key()].(type) {
+		case *result.FileMatch:
+			changes = append(changes, newChange(f, m.Repo, m.CommitID, "+"))
+		case *result.RepoMatch:
+			commitID, ok, err := resolveCommit(m.Name, m.Rev)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				changes = append(changes, newChange(f, m.RepoName(), commitID, "+"))
+			}
+		}
+	}
+	for _, f := range disappeared {
+		if len(changes) >= maxResultSetChanges {
+			return changes, nil
+		}
+		repo := types.MinimalRepo{Name: api.RepoName(f.Repo)}
+		if f.Kind != fingerprintKindRepo {
+			changes = append(changes, newChange(f, repo, api.CommitID(f.Commit), "-"))
+			continue
+		}
+		commitID, ok, err := resolveCommit(repo.Name, f.Commit)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			changes = append(changes, newChange(f, repo, commitID, "-"))
+		}
+	}
+	return changes, nil
+}
+
+func hashIdentity(identity string) string {
+	sum := sha256.Sum256([]byte(identity))
+	return hex.EncodeToString(sum[:8])
+}
+
+func truncatePreview(s string) string {
+	if utf8.RuneCountInString(s) <= maxPreviewLength {
+		return s
+	}
+	return string([]rune(s)[:maxPreviewLength]) + "…"
+}
@@ -0,0 +1,294 @@
+package codemonitors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIsResultSetJob(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		job     job.Job
+		want    bool
+		wantErr bool
+	}{{
+		name: "commit search",
+		job:  jobutil.NewLimitJob(1000, &commit.SearchJob{}),
+		want: false,
+	}, {
+		name: "repo search",
+		job:  jobutil.NewTimeoutJob(0, &jobutil.RepoSearchJob{}),
+		want: true,
+	}, {
+		name: "content and symbol search",
+		job:  jobutil.NewParallelJob(&searcher.TextSearchJob{}, &searcher.SymbolSearchJob{}),
+		want: true,
+	}, {
+		name:    "mixed search",
+		job:     jobutil.NewParallelJob(&jobutil.RepoSearchJob{}, &commit.SearchJob{}),
+		wantErr: true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := isResultSetJob(tc.job)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrMixedMonitorQuery)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFingerprintResults(t *testing.T) {
+	t.Parallel()
+
+	repo := types.MinimalRepo{Name: "github.com/test/test"}
+	file := result.File{Repo: repo, CommitID: "deadbeef", Path: "Dockerfile"}
+
+	readme := &result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "README.md"}}
+	fingerprints, matches := fingerprintResults(result.Matches{
+		&result.FileMatch{
+			File: file,
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "FROM ubuntu:18.04\nRUN apt-get update",
+				ContentStart: result.Location{Line: 0},
+				Ranges: result.Ranges{
+					{Start: result.Location{Line: 0, Column: 5}, End: result.Location{Line: 0, Column: 11}},
+					{Start: result.Location{Line: 0, Column: 12}, End: result.Location{Line: 0, Column: 17}},
+				},
+			}},
+		},
+		&result.FileMatch{
+			File: file,
+			Symbols: []*result.SymbolMatch{{
+				Symbol: result.Symbol{Name: "main", Kind: "function"},
+			}},
+		},
+		readme,
+		&result.RepoMatch{Name: "github.com/test/other"},
+	})
+
+	require.Equal(t, []edb.ResultFingerprint{{
+		Repo:    "github.com/test/other",
+		Kind:    fingerprintKindRepo,
+		Preview: "github.com/test/other",
+	}, {
+		Repo:    "github.com/test/test",
+		Path:    "Dockerfile",
+		Kind:    fingerprintKindContent,
+		Hash:    hashIdentity("FROM ubuntu:18.04"),
+		Commit:  "deadbeef",
+		Preview: "FROM ubuntu:18.04",
+	}, {
+		Repo:    "github.com/test/test",
+		Path:    "Dockerfile",
+		Kind:    fingerprintKindSymbol,
+		Hash:    hashIdentity("main\x00function\x00"),
+		Commit:  "deadbeef",
+		Preview: "main (function)",
+	}, {
+		Repo:    "github.com/test/test",
+		Path:    "README.md",
+		Kind:    fingerprintKindPath,
+		Commit:  "deadbeef",
+		Preview: "README.md",
+	}}, fingerprints)
+	require.Len(t, matches, 4)
+	require.Equal(t, readme, matches[fingerprints[3].Key()])
+}
+
+func TestDiffResultSets(t *testing.T) {
+	t.Parallel()
+
+	a := edb.ResultFingerprint{Repo: "a", Kind: fingerprintKindRepo, Preview: "a"}
+	b := edb.ResultFingerprint{Repo: "b", Path: "Dockerfile", Kind: fingerprintKindContent, Hash: "1", Commit: "c1", Preview: "FROM ubuntu:18.04"}
+	c := edb.ResultFingerprint{Repo: "c", Kind: fingerprintKindRepo, Preview: "c"}
+
+	// A result found at a different commit is the same result
+	movedB := b
+	movedB.Commit = "c2"
+
+	appeared, disappeared := diffResultSets([]edb.ResultFingerprint{a, b}, []edb.ResultFingerprint{movedB, c})
+	require.Equal(t, []edb.ResultFingerprint{c}, appeared)
+	require.Equal(t, []edb.ResultFingerprint{a}, disappeared)
+
+}
+
+func TestResultSetChanges(t *testing.T) {
+	t.Parallel()
+
+	gs := gitserver.NewMockClient()
+	gs.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		if repo == "deleted" {
+			return "", &gitdomain.RepoNotExistError{Repo: repo}
+		}
+		return api.CommitID("head-" + repo), nil
+	})
+
+	fm := &result.FileMatch{File: result.File{Repo: types.MinimalRepo{ID: 1, Name: "b"}, CommitID: "c2", Path: "Dockerfile"}}
+	rm := &result.RepoMatch{ID: 2, Name: "c"}
+	b := edb.ResultFingerprint{Repo: "b", Path: "Dockerfile", Kind: fingerprintKindContent, Hash: "1", Commit: "c2", Preview: "FROM ubuntu:18.04"}
+	c := edb.ResultFingerprint{Repo: "c", Kind: fingerprintKindRepo, Preview: "c"}
+	d := edb.ResultFingerprint{Repo: "d", Path: "Dockerfile", Kind: fingerprintKindContent, Hash: "2", Commit: "c1", Preview: "FROM ubuntu:16.04"}
+	deleted := edb.ResultFingerprint{Repo: "deleted", Kind: fingerprintKindRepo, Preview: "deleted"}
+
+	changes, err := resultSetChanges(
+		context.Background(),
+		gs,
+		map[string]result.Match{b.Key(): fm, c.Key(): rm},
+		[]edb.ResultFingerprint{b, c},
+		[]edb.ResultFingerprint{d, deleted},
+	)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	// Appeared results are found at the commit of their match
+	require.Equal(t, "Dockerfile\n+FROM ubuntu:18.04", changes[0].DiffPreview.Content)
+	require.Equal(t, fm.Repo, changes[0].Repo)
+	require.Equal(t, api.CommitID("c2"), changes[0].Commit.ID)
+	require.Equal(t, "+c", changes[1].DiffPreview.Content)
+	require.Equal(t, types.MinimalRepo{ID: 2, Name: "c"}, changes[1].Repo)
+	require.Equal(t, api.CommitID("head-c"), changes[1].Commit.ID)
+
+	// Disappeared results point to the commit they were last found at, and
+	// repositories that don't exist anymore are skipped
+	require.Equal(t, "Dockerfile\n-FROM ubuntu:16.04", changes[2].DiffPreview.Content)
+	require.Equal(t, api.CommitID("c1"), changes[2].Commit.ID)
+}
+
+func TestSearchResultSet(t *testing.T) {
+	t.Parallel()
+
+	var stored []edb.ResultFingerprint
+	var ok bool
+	store := edb.NewMockCodeMonitorStore()
+	store.GetResultSetFunc.SetDefaultHook(func(context.Context, int64) ([]edb.ResultFingerprint, bool, error) {
+		return stored, ok, nil
+	})
+	store.UpsertResultSetFunc.SetDefaultHook(func(_ context.Context, _ int64, fingerprints []edb.ResultFingerprint) error {
+		stored, ok = fingerprints, true
+		return nil
+	})
+	db := edb.NewMockEnterpriseDB()
+	db.CodeMonitorsFunc.SetDefaultReturn(store)
+
+	gs := gitserver.NewMockClient()
+	gs.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID("head-" + repo), nil
+	})
+
+	run := func(stats streaming.Stats, repos ...string) ([]*result.CommitMatch, error) {
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			var matches result.Matches
+			for _, repo := range repos {
+				matches = append(matches, &result.RepoMatch{Name: api.RepoName("github.com/test/" + repo)})
+			}
+			s.Send(streaming.SearchEvent{Results: matches, Stats: stats})
+			return nil, nil
+		})
+		return searchResultSet(context.Background(), logtest.Scoped(t), db, 1, j, job.RuntimeClients{Gitserver: gs})
+	}
+	mustRun := func(stats streaming.Stats, repos ...string) []*result.CommitMatch {
+		results, err := run(stats, repos...)
+		require.NoError(t, err)
+		return results
+	}
+
+	// The first run only stores the result set
+	require.Empty(t, mustRun(streaming.Stats{}, "a", "b"))
+	require.Len(t, stored, 2)
+
+	// Unchanged results
+	require.Empty(t, mustRun(streaming.Stats{}, "a", "b"))
+
+	changes := mustRun(streaming.Stats{}, "b", "c")
+	require.Len(t, changes, 2)
+	require.Equal(t, "+github.com/test/c", changes[0].DiffPreview.Content)
+	require.Equal(t, api.CommitID("head-github.com/test/c"), changes[0].Commit.ID)
+	require.Equal(t, "-github.com/test/a", changes[1].DiffPreview.Content)
+
+	// Incomplete searches aren't compared, and don't change the stored results
+	var cloning streaming.Stats
+	cloning.Status.Update(3, search.RepoStatusCloning)
+	require.Empty(t, mustRun(cloning, "d"))
+	require.Equal(t, []string{"github.com/test/b", "github.com/test/c"}, storedRepos(stored))
+
+	// Searches that hit their limit are skipped without changing the stored results
+	require.Empty(t, mustRun(streaming.Stats{IsLimitHit: true}, "d"))
+	require.Equal(t, []string{"github.com/test/b", "github.com/test/c"}, storedRepos(stored))
+
+	// The next complete search is compared to the last complete one
+	changes = mustRun(streaming.Stats{}, "c", "d")
+	require.Len(t, changes, 2)
+	require.Equal(t, "+github.com/test/d", changes[0].DiffPreview.Content)
+	require.Equal(t, "-github.com/test/b", changes[1].DiffPreview.Content)
+}
+
+func TestResultSetInputs(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		query string
+		want  string
+	}{{
+		query: "foo",
+		want:  "count:10000",
+	}, {
+		query: "foo count:100",
+		want:  "count:100",
+	}, {
+		query: "foo count:all",
+		want:  "count:10000",
+	}, {
+		query: "foo or type:repo bar",
+		want:  "count:10000",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(tc.query, query.SearchTypeLiteral))
+			require.NoError(t, err)
+			inputs := &search.Inputs{Plan: plan, Query: plan.ToQ()}
+
+			got := resultSetInputs(inputs)
+			for _, b := range got.Plan {
+				require.Contains(t, b.String(), tc.want)
+			}
+			require.Contains(t, got.Query.String(), tc.want)
+			// The original inputs are left unchanged.
+			require.Equal(t, plan, inputs.Plan)
+		})
+	}
+}
+
+func storedRepos(fingerprints []edb.ResultFingerprint) []string {
+	repos := make([]string, 0, len(fingerprints))
+	for _, f := range fingerprints {
+		repos = append(repos, f.Repo)
+	}
+	return repos
+}
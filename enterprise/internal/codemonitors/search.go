@@ -107,6 +107,10 @@ func Settings(ctx context.Context) (_ *schema.Settings, err error) {
 	return &unmarshaledSettings, nil
 }
 
+// Search runs the query of a code monitor and returns its new results. For
+// queries that search commits, these are the matching commits that a previous
+// run hasn't searched yet. For other queries, these are the results that
+// appeared or disappeared since the previous run.
 func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
@@ -130,6 +134,19 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	resultSet, err := isResultSetJob(planJob)
+	if err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+	if resultSet {
+		inputs = resultSetInputs(inputs)
+		planJob, err = jobutil.NewPlanJob(inputs, inputs.Plan)
+		if err != nil {
+			return nil, errcode.MakeNonRetryable(err)
+		}
+		return searchResultSet(ctx, logger, db, monitorID, planJob, clients)
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, logger, gs, monitorID, repoID, args, doSearch)
@@ -186,7 +203,8 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For queries that don't search commits, the first run after Snapshot
+// stores the result set instead.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
@@ -209,6 +227,16 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	resultSet, err := isResultSetJob(planJob)
+	if err != nil {
+		return err
+	}
+	if resultSet {
+		// Forget the results of the previous query, so that the next run only
+		// stores the result set of this query without reporting any results.
+		return edb.NewEnterpriseDB(db).CodeMonitors().DeleteResultSet(ctx, monitorID)
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -0,0 +1,100 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// replacePatchPath is where the generated batch spec writes the patch inside
+// the step container.
+const replacePatchPath = "/tmp/compute.patch"
+
+// The types below describe the subset of the batch spec schema that
+// NewReplaceBatchSpec emits. We don't marshal lib/batches.BatchSpec directly
+// because its YAML tags lack omitempty, which would clutter the draft with
+// empty fields.
+
+type replaceBatchSpec struct {
+	Name              string                    `yaml:"name"`
+	Description       string                    `yaml:"description"`
+	On                []replaceBatchSpecOn      `yaml:"on"`
+	Steps             []replaceBatchSpecStep    `yaml:"steps"`
+	ChangesetTemplate replaceBatchSpecChangeset `yaml:"changesetTemplate"`
+}
+
+type replaceBatchSpecOn struct {
+	Repository string `yaml:"repository"`
+}
+
+type replaceBatchSpecStep struct {
+	If        string            `yaml:"if"`
+	Run       string            `yaml:"run"`
+	Container string            `yaml:"container"`
+	Files     map[string]string `yaml:"files"`
+}
+
+type replaceBatchSpecChangeset struct {
+	Title  string `yaml:"title"`
+	Body   string `yaml:"body"`
+	Branch string `yaml:"branch"`
+	Commit struct {
+		Message string `yaml:"message"`
+	} `yaml:"commit"`
+	Published bool `yaml:"published"`
+}
+
+// NewReplaceBatchSpec returns a draft batch spec in YAML that applies diffs,
+// the result of running the compute replace query computeQuery, as changesets.
+// Each repository gets one step that applies the concatenated patches for that
+// repository on its default branch, so diffs must be computed against the HEAD
+// of the default branch. Changesets are left unpublished so the draft can be
+// reviewed before anything is pushed.
+func NewReplaceBatchSpec(computeQuery string, diffs []*FileDiff) (string, error) {
+	if len(diffs) == 0 {
+		return "", errors.New("compute replace produced no changes")
+	}
+
+	patches := make(map[string][]string)
+	for _, d := range diffs {
+		patches[d.Repository] = append(patches[d.Repository], d.Value)
+	}
+	repos := make([]string, 0, len(patches))
+	for repo := range patches {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	spec := replaceBatchSpec{
+		Name:        "compute-replace",
+		Description: fmt.Sprintf("Changes generated by the compute query: %s", computeQuery),
+	}
+	for _, repo := range repos {
+		spec.On = append(spec.On, replaceBatchSpecOn{Repository: repo})
+		spec.Steps = append(spec.Steps, replaceBatchSpecStep{
+			If:        fmt.Sprintf("${{ eq repository.name %q }}", repo),
+			Run:       fmt.Sprintf("patch -p1 < %s", replacePatchPath),
+			Container: "alpine:3",
+			Files:     map[string]string{replacePatchPath: strings.Join(patches[repo], "")},
+		})
+	}
+	spec.ChangesetTemplate.Title = "Apply compute replace"
+	spec.ChangesetTemplate.Body = fmt.Sprintf("This changeset was generated from the compute query `%s`.", computeQuery)
+	spec.ChangesetTemplate.Branch = "compute/replace"
+	spec.ChangesetTemplate.Commit.Message = "Apply compute replace"
+
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		return "", errors.Wrap(err, "encoding batch spec")
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.Wrap(err, "encoding batch spec")
+	}
+	return b.String(), nil
+}
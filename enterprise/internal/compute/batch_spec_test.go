@@ -0,0 +1,64 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestNewReplaceBatchSpec(t *testing.T) {
+	diffs := []*FileDiff{
+		{Repository: "github.com/sourcegraph/b", Path: "b.go", Value: formatFileDiff("b.go", diffHunks("b.go", "foo\n", "bar\n"))},
+		{Repository: "github.com/sourcegraph/a", Path: "a.go", Value: formatFileDiff("a.go", diffHunks("a.go", "foo\n", "bar\n"))},
+	}
+
+	spec, err := NewReplaceBatchSpec("content:replace.diff(foo -> bar)", diffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := batcheslib.ParseBatchSpec([]byte(spec)); err != nil {
+		t.Fatalf("generated batch spec is invalid: %s\n%s", err, spec)
+	}
+	autogold.Want("batch spec", `name: compute-replace
+description: 'Changes generated by the compute query: content:replace.diff(foo -> bar)'
+"on":
+  - repository: github.com/sourcegraph/a
+  - repository: github.com/sourcegraph/b
+steps:
+  - if: ${{ eq repository.name "github.com/sourcegraph/a" }}
+    run: patch -p1 < /tmp/compute.patch
+    container: alpine:3
+    files:
+      /tmp/compute.patch: |
+        diff --git a/a.go b/a.go
+        --- a/a.go
+        +++ b/a.go
+        @@ -1,1 +1,1 @@
+        -foo
+        +bar
+  - if: ${{ eq repository.name "github.com/sourcegraph/b" }}
+    run: patch -p1 < /tmp/compute.patch
+    container: alpine:3
+    files:
+      /tmp/compute.patch: |
+        diff --git a/b.go b/b.go
+        --- a/b.go
+        +++ b/b.go
+        @@ -1,1 +1,1 @@
+        -foo
+        +bar
+changesetTemplate:
+  title: Apply compute replace
+  body: This changeset was generated from the compute query `+"`content:replace.diff(foo -> bar)`"+`.
+  branch: compute/replace
+  commit:
+    message: Apply compute replace
+  published: false
+`).Equal(t, spec)
+
+	if _, err := NewReplaceBatchSpec("content:replace.diff(foo -> bar)", nil); err == nil {
+		t.Fatal("expected error for empty diffs")
+	}
+}
@@ -0,0 +1,79 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+)
+
+// FileDiff is a unified diff of the changes to a single file. Value is the diff
+// in the textual format understood by tools like `git apply`, and Hunks is the
+// same diff in structured form.
+type FileDiff struct {
+	Value        string     `json:"value"`
+	Kind         string     `json:"kind"`
+	Hunks        []DiffHunk `json:"hunks"`
+	Path         string     `json:"path"`
+	Commit       string     `json:"commit"`
+	RepositoryID int32      `json:"repositoryID"`
+	Repository   string     `json:"repository"`
+}
+
+// DiffHunk is a contiguous set of changed lines and their context. Line numbers
+// are 1-based, and every line of Body is prefixed by one of ' ', '-' or '+'.
+type DiffHunk struct {
+	OldStart int    `json:"oldStart"`
+	OldLines int    `json:"oldLines"`
+	NewStart int    `json:"newStart"`
+	NewLines int    `json:"newLines"`
+	Body     string `json:"body"`
+}
+
+// diffHunks computes the hunks that turn before into after.
+func diffHunks(path, before, after string) []DiffHunk {
+	edits := myers.ComputeEdits(span.URIFromPath(path), before, after)
+	unified := gotextdiff.ToUnified(path, path, before, edits)
+
+	hunks := make([]DiffHunk, 0, len(unified.Hunks))
+	for _, h := range unified.Hunks {
+		hunk := DiffHunk{OldStart: h.FromLine, NewStart: h.ToLine}
+		var body strings.Builder
+		for _, l := range h.Lines {
+			switch l.Kind {
+			case gotextdiff.Delete:
+				hunk.OldLines++
+				body.WriteString("-")
+			case gotextdiff.Insert:
+				hunk.NewLines++
+				body.WriteString("+")
+			default:
+				hunk.OldLines++
+				hunk.NewLines++
+				body.WriteString(" ")
+			}
+			body.WriteString(l.Content)
+			if !strings.HasSuffix(l.Content, "\n") {
+				body.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		hunk.Body = body.String()
+		hunks = append(hunks, hunk)
+	}
+	return hunks
+}
+
+// formatFileDiff renders hunks as a git-style patch for path.
+func formatFileDiff(path string, hunks []DiffHunk) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+	for _, h := range hunks {
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+		b.WriteString(h.Body)
+	}
+	return b.String()
+}
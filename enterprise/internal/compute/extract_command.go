@@ -0,0 +1,171 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Extract maps the capture groups of a regular expression, or the holes of a
+// comby pattern, to the columns of a table with one row per match.
+type Extract struct {
+	SearchPattern MatchPattern
+
+	// ComputePattern is the pattern used to extract values. For regular
+	// expressions it mirrors the case sensitivity of the search, like
+	// MatchOnly.ComputePattern.
+	ComputePattern MatchPattern
+
+	Columns []string
+
+	// Language is the value of the query's lang: filter, if any. It overrides
+	// the comby matcher that would otherwise be inferred from the file path.
+	Language string
+	// Matcher is the comby matcher set by the matcher: parameter, if any. It
+	// takes precedence over Language and does not change the search query.
+	Matcher string
+}
+
+func (c *Extract) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *Extract) String() string {
+	return fmt.Sprintf("Extract: (%s) columns: %s", c.SearchPattern.String(), strings.Join(c.Columns, ", "))
+}
+
+// regexpColumns returns a column for every capture group in r. Unnamed groups
+// are numbered, like their $1 style references in replacements.
+func regexpColumns(r *regexp.Regexp) []string {
+	names := r.SubexpNames()
+	columns := make([]string, 0, len(names)-1)
+	for i, name := range names[1:] {
+		if name == "" {
+			name = strconv.Itoa(i + 1)
+		}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// combyHole matches the name of holes like :[x], :[[x]], :[x.] or :[x:e].
+var combyHole = lazyregexp.New(`:\[\[?\s?(\w+)`)
+
+// combyColumns returns a column for every named hole in template. Holes named
+// _ are anonymous in comby and don't bind a value.
+func combyColumns(template string) []string {
+	var columns []string
+	seen := map[string]struct{}{}
+	for _, m := range combyHole.FindAllStringSubmatch(template, -1) {
+		name := m[1]
+		if name == "_" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+func extractRegexp(fm *result.FileMatch, r *regexp.Regexp, columns []string) []Row {
+	var rows []Row
+	for _, cm := range fm.ChunkMatches {
+		for _, range_ := range cm.Ranges {
+			content := chunkContent(cm, range_)
+			for _, submatches := range r.FindAllStringSubmatchIndex(content, -1) {
+				values := make(map[string]string, len(columns))
+				for i, column := range columns {
+					start, end := submatches[2*(i+1)], submatches[2*(i+1)+1]
+					if start == -1 || end == -1 {
+						// The capture group did not participate in the match.
+						values[column] = ""
+						continue
+					}
+					values[column] = content[start:end]
+				}
+				rows = append(rows, newRow(fm, range_.Start.Line+strings.Count(content[:submatches[0]], "\n")+1, values))
+			}
+		}
+	}
+	return rows
+}
+
+func extractComby(ctx context.Context, fm *result.FileMatch, content []byte, template string, columns []string, matcher string) ([]Row, error) {
+	fileMatches, err := comby.Matches(ctx, comby.Args{
+		Input:         comby.FileContent(content),
+		MatchTemplate: template,
+		Matcher:       matcher,
+		NumWorkers:    0, // Just a single file's content.
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for _, m := range fileMatches {
+		for _, match := range m.Matches {
+			values := make(map[string]string, len(columns))
+			for _, column := range columns {
+				values[column] = ""
+			}
+			for _, entry := range match.Environment {
+				if _, ok := values[entry.Variable]; ok {
+					values[entry.Variable] = entry.Value
+				}
+			}
+			// Comby lines are already 1-based.
+			rows = append(rows, newRow(fm, match.Range.Start.Line, values))
+		}
+	}
+	return rows, nil
+}
+
+func newRow(fm *result.FileMatch, line int, values map[string]string) Row {
+	return Row{
+		Repository:   string(fm.Repo.Name),
+		RepositoryID: int32(fm.Repo.ID),
+		Commit:       string(fm.CommitID),
+		Path:         fm.Path,
+		Line:         line,
+		Values:       values,
+	}
+}
+
+func (c *Extract) Run(ctx context.Context, db database.DB, r result.Match) (Result, error) {
+	fm, ok := r.(*result.FileMatch)
+	if !ok {
+		return nil, nil
+	}
+
+	var rows []Row
+	switch p := c.ComputePattern.(type) {
+	case *Regexp:
+		rows = extractRegexp(fm, p.Value, c.Columns)
+	case *Comby:
+		content, err := gitserver.NewClient(db).ReadFile(ctx, authz.DefaultSubRepoPermsChecker, fm.Repo.Name, fm.CommitID, fm.Path)
+		if err != nil {
+			return nil, err
+		}
+		rows, err = extractComby(ctx, fm, content, p.Value, c.Columns, combyMatcher(c.Matcher, c.Language, fm.Path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &Table{Columns: c.Columns, Rows: rows}, nil
+}
@@ -0,0 +1,95 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestExtract(t *testing.T) {
+	content := "a := 1\nb := 22\n"
+	fm := &result.FileMatch{
+		File: result.File{
+			Repo:     types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+			CommitID: "deadbeef",
+			Path:     "main.go",
+		},
+		ChunkMatches: result.ChunkMatches{{
+			Content:      content,
+			ContentStart: result.Location{Offset: 0, Line: 0, Column: 0},
+			Ranges: result.Ranges{{
+				Start: result.Location{Offset: 0, Line: 0, Column: 0},
+				End:   result.Location{Offset: len(content), Line: 2, Column: 0},
+			}},
+		}},
+	}
+
+	test := func(input string) string {
+		q, err := Parse(input)
+		if err != nil {
+			return err.Error()
+		}
+		res, err := q.Command.Run(context.Background(), nil, fm)
+		if err != nil {
+			return err.Error()
+		}
+		v, _ := json.Marshal(res)
+		return string(v)
+	}
+
+	autogold.Want("named and unnamed groups", `{"columns":["name","2"],"rows":[{"repository":"github.com/sourcegraph/sourcegraph","repositoryID":1,"commit":"deadbeef","path":"main.go","line":1,"values":{"2":"1","name":"a"}},{"repository":"github.com/sourcegraph/sourcegraph","repositoryID":1,"commit":"deadbeef","path":"main.go","line":2,"values":{"2":"22","name":"b"}}]}`).
+		Equal(t, test(`content:extract((?P<name>\w+) := (\d+))`))
+
+	autogold.Want("no match", "null").
+		Equal(t, test(`content:extract((\w+) = (\d+))`))
+}
+
+func TestParseExtract(t *testing.T) {
+	test := func(input string) string {
+		q, err := Parse(input)
+		if err != nil {
+			return err.Error()
+		}
+		return q.String()
+	}
+
+	autogold.Want("regexp extract",
+		"Command: `Extract: (func (?P<name>\\w+)) columns: name`").
+		Equal(t, test(`content:extract(func (?P<name>\w+))`))
+
+	autogold.Want("structural extract",
+		"Command: `Extract: (:[fn](:[[arg]], :[_])) columns: fn, arg`").
+		Equal(t, test(`content:extract.structural(:[fn](:[[arg]], :[_]))`))
+
+	autogold.Want("extract without groups",
+		"extract command expects at least one capture group in its pattern").
+		Equal(t, test(`content:extract(foo)`))
+
+	autogold.Want("structural extract without holes",
+		"extract command expects at least one named hole in its pattern").
+		Equal(t, test(`content:extract.structural(foo(:[_]))`))
+
+	autogold.Want("extract with metadata column",
+		"extract command: column \"path\" conflicts with the \"path\" column of every row, rename its capture group or hole").
+		Equal(t, test(`content:extract(import (?P<path>"\S+"))`))
+
+	autogold.Want("structural extract with metadata column",
+		"extract command: column \"Commit\" conflicts with the \"commit\" column of every row, rename its capture group or hole").
+		Equal(t, test(`content:extract.structural(:[Commit] := :[x])`))
+
+	autogold.Want("extract with duplicate column",
+		"extract command: column \"1\" is defined more than once").
+		Equal(t, test(`content:extract((\w+) := (?P<1>\d+))`))
+}
+
+func TestRowRecord(t *testing.T) {
+	columns := []string{"name", "value"}
+	row := Row{Repository: "r", Commit: "c", Path: "p", Line: 3, Values: map[string]string{"name": "a"}}
+	autogold.Want("header", []string{"repository", "commit", "path", "line", "name", "value"}).Equal(t, TableHeader(columns))
+	autogold.Want("record", []string{"r", "c", "p", "3", "a", ""}).Equal(t, row.Record(columns))
+}
@@ -19,6 +19,12 @@ type Output struct {
 	Selector      string
 	TypeValue     string
 	Kind          string
+	// Language is the value of the query's lang: filter, if any. It overrides
+	// the comby matcher that would otherwise be inferred from the file path.
+	Language string
+	// Matcher is the comby matcher set by the matcher: parameter, if any. It
+	// takes precedence over Language and does not change the search query.
+	Matcher string
 }
 
 func (c *Output) ToSearchPattern() string {
@@ -38,7 +44,7 @@ func substituteRegexp(content string, match *regexp.Regexp, replacePattern, sepa
 	return b.String()
 }
 
-func output(ctx context.Context, fragment string, matchPattern MatchPattern, replacePattern string, separator string) (string, error) {
+func output(ctx context.Context, fragment string, matchPattern MatchPattern, replacePattern, separator, matcher string) (string, error) {
 	var newContent string
 	var err error
 	switch match := matchPattern.(type) {
@@ -49,7 +55,7 @@ func output(ctx context.Context, fragment string, matchPattern MatchPattern, rep
 			Input:           comby.FileContent(fragment),
 			MatchTemplate:   match.Value,
 			RewriteTemplate: replacePattern,
-			Matcher:         ".generic", // TODO(rvantoner): use language or file filter
+			Matcher:         matcher,
 			ResultKind:      comby.NewlineSeparatedOutput,
 			NumWorkers:      0,
 		})
@@ -105,14 +111,14 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 	}
 }
 
-func toTextResult(ctx context.Context, content string, matchPattern MatchPattern, outputPattern, separator, selector string) (string, error) {
+func toTextResult(ctx context.Context, content string, matchPattern MatchPattern, outputPattern, separator, selector, matcher string) (string, error) {
 	if selector != "" {
 		// Don't run the search pattern over the search result content
 		// when there's an explicit `select:` value.
 		return outputPattern, nil
 	}
 
-	return output(ctx, content, matchPattern, outputPattern, separator)
+	return output(ctx, content, matchPattern, outputPattern, separator, matcher)
 }
 
 func toTextExtraResult(content string, r result.Match) *TextExtra {
@@ -127,6 +133,12 @@ func (c *Output) Run(ctx context.Context, _ database.DB, r result.Match) (Result
 	onlyPath := c.TypeValue == "path" // don't read file contents for file matches when we only want type:path
 	chunks := resultChunks(r, c.Kind, onlyPath)
 
+	var path string
+	if fm, ok := r.(*result.FileMatch); ok {
+		path = fm.Path
+	}
+	matcher := combyMatcher(c.Matcher, c.Language, path)
+
 	var sb strings.Builder
 	for _, content := range chunks {
 		env := NewMetaEnvironment(r, content)
@@ -135,7 +147,7 @@ func (c *Output) Run(ctx context.Context, _ database.DB, r result.Match) (Result
 			return nil, err
 		}
 
-		result, err := toTextResult(ctx, content, c.SearchPattern, outputPattern, c.Separator, c.Selector)
+		result, err := toTextResult(ctx, content, c.SearchPattern, outputPattern, c.Separator, c.Selector, matcher)
 		if err != nil {
 			return nil, err
 		}
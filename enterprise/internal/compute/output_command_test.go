@@ -18,7 +18,7 @@ import (
 
 func Test_output(t *testing.T) {
 	test := func(input string, cmd *Output) string {
-		result, err := output(context.Background(), input, cmd.SearchPattern, cmd.OutputPattern, cmd.Separator)
+		result, err := output(context.Background(), input, cmd.SearchPattern, cmd.OutputPattern, cmd.Separator, combyMatcher(cmd.Matcher, cmd.Language, ""))
 		if err != nil {
 			return err.Error()
 		}
@@ -47,6 +47,15 @@ train(commuter, lightrail)`).
 			SearchPattern: &Comby{Value: `train(:[x], :[y])`},
 			OutputPattern: "train(:[y], :[x])",
 		}))
+
+	autogold.Want(
+		"structural search output honors language",
+		"go").
+		Equal(t, test("/* train(comment) */ train(go)", &Output{
+			SearchPattern: &Comby{Value: `train(:[x])`},
+			OutputPattern: ":[x]",
+			Language:      "go",
+		}))
 }
 
 func fileMatch(chunks ...string) result.Match {
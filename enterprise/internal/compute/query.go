@@ -2,9 +2,11 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
+	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -97,13 +99,19 @@ func toRegexpPattern(value string) (MatchPattern, error) {
 
 var ComputePredicateRegistry = query.PredicateRegistry{
 	query.FieldContent: {
-		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
-		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace":                 func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp":          func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural":      func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff":            func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp.diff":     func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural.diff": func() query.Predicate { return query.EmptyPredicate{} },
+		"output":                  func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":           func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural":       func() query.Predicate { return query.EmptyPredicate{} },
+		"output.extra":            func() query.Predicate { return query.EmptyPredicate{} },
+		"extract":                 func() query.Predicate { return query.EmptyPredicate{} },
+		"extract.regexp":          func() query.Predicate { return query.EmptyPredicate{} },
+		"extract.structural":      func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -145,6 +153,10 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 		return nil, false, err
 	}
 
+	// The .diff variants emit diffs instead of replaced file contents.
+	diff := strings.HasSuffix(name, ".diff")
+	name = strings.TrimSuffix(name, ".diff")
+
 	var matchPattern MatchPattern
 	switch name {
 	case "replace", "replace.regexp":
@@ -161,7 +173,12 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 		return nil, false, nil
 	}
 
-	return &Replace{SearchPattern: matchPattern, ReplacePattern: right}, true, nil
+	return &Replace{
+		SearchPattern:  matchPattern,
+		ReplacePattern: right,
+		Diff:           diff,
+		Language:       languageFilter(q),
+	}, true, nil
 }
 
 func parseOutput(q *query.Basic) (Command, bool, error) {
@@ -214,9 +231,67 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 		TypeValue:     typeValue,
 		Selector:      selector,
 		Kind:          name,
+		Language:      languageFilter(q),
 	}, true, nil
 }
 
+func parseExtract(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch name {
+	case "extract", "extract.regexp":
+		searchPattern, err := toRegexpPattern(args)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "extract command")
+		}
+		computePattern := searchPattern
+		if !q.IsCaseSensitive() {
+			computePattern, err = toRegexpPattern("(?i:" + args + ")")
+			if err != nil {
+				return nil, false, errors.Wrap(err, "extract command")
+			}
+		}
+		columns := regexpColumns(searchPattern.(*Regexp).Value)
+		if len(columns) == 0 {
+			return nil, false, errors.New("extract command expects at least one capture group in its pattern")
+		}
+		if err := validateColumns(columns); err != nil {
+			return nil, false, errors.Wrap(err, "extract command")
+		}
+		return &Extract{
+			SearchPattern:  searchPattern,
+			ComputePattern: computePattern,
+			Columns:        columns,
+			Language:       languageFilter(q),
+		}, true, nil
+	case "extract.structural":
+		columns := combyColumns(args)
+		if len(columns) == 0 {
+			return nil, false, errors.New("extract command expects at least one named hole in its pattern")
+		}
+		if err := validateColumns(columns); err != nil {
+			return nil, false, errors.Wrap(err, "extract command")
+		}
+		matchPattern := &Comby{Value: args}
+		return &Extract{
+			SearchPattern:  matchPattern,
+			ComputePattern: matchPattern,
+			Columns:        columns,
+			Language:       languageFilter(q),
+		}, true, nil
+	}
+	// unrecognized name
+	return nil, false, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -239,6 +314,28 @@ func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	return &MatchOnly{SearchPattern: sp, ComputePattern: cp}, true, nil
 }
 
+// languageFilter returns the first value of the query's lang: filter, which
+// determines the comby matcher for structural patterns. There is no support
+// for applying multiple language matchers in a single query.
+func languageFilter(q *query.Basic) string {
+	var lang string
+	query.VisitField(q.ToParseTree(), query.FieldLang, func(value string, negated bool, _ query.Annotation) {
+		if lang == "" && !negated {
+			lang = value
+		}
+	})
+	return lang
+}
+
+// combyMatcher returns the comby matcher for a file. An explicit matcher:
+// parameter takes precedence over the lang: filter and the file's extension.
+func combyMatcher(matcher, language, filePath string) string {
+	if matcher != "" {
+		return matcher
+	}
+	return comby.MatcherForFile(language, filePath)
+}
+
 type commandParser func(pattern *query.Basic) (Command, bool, error)
 
 // first returns the first parser that succeeds at parsing a command from a pattern.
@@ -258,12 +355,15 @@ func first(parsers ...commandParser) commandParser {
 }
 
 var parseCommand = first(
+	// parseExtract goes first because the other content predicate parsers
+	// reject arguments without arrow syntax before checking the name.
+	parseExtract,
 	parseReplace,
 	parseOutput,
 	parseMatchOnly,
 )
 
-func toComputeQuery(plan query.Plan) (*Query, error) {
+func toComputeQuery(plan query.Plan, matcher string) (*Query, error) {
 	if len(plan) < 1 {
 		return nil, errors.New("compute endpoint can't do anything with empty query")
 	}
@@ -273,6 +373,19 @@ func toComputeQuery(plan query.Plan) (*Query, error) {
 		return nil, err
 	}
 
+	if matcher != "" {
+		switch c := command.(type) {
+		case *Replace:
+			c.Matcher = matcher
+		case *Output:
+			c.Matcher = matcher
+		case *Extract:
+			c.Matcher = matcher
+		default:
+			return nil, errors.New("compute endpoint only supports matcher: for replace, output, and extract commands")
+		}
+	}
+
 	parameters := query.MapPattern(plan.ToQ(), func(_ string, _ bool, _ query.Annotation) query.Node {
 		// remove the pattern node.
 		return nil
@@ -283,7 +396,65 @@ func toComputeQuery(plan query.Plan) (*Query, error) {
 	}, nil
 }
 
+// matcherParameter is the prefix of the compute-only parameter that sets the
+// comby matcher for structural patterns, e.g. matcher:.go or matcher:go.
+const matcherParameter = "matcher:"
+
+// extractMatcher removes the matcher: parameter from q, so that it does not
+// become part of the search query, and returns the comby matcher it specifies.
+// Unlike lang:, the parameter does not restrict which files are searched.
+func extractMatcher(q string) (string, string, error) {
+	// Parse without transformations, since those concatenate adjacent patterns.
+	parseTree, err := query.Parse(q, query.SearchTypeRegex)
+	if err != nil {
+		return "", "", err
+	}
+
+	var value string
+	var rrange query.Range
+	seen := false
+	query.VisitPattern(parseTree, func(v string, negated bool, annotation query.Annotation) {
+		if err != nil || annotation.Labels.IsSet(query.Quoted) {
+			return
+		}
+		if strings.HasPrefix(v, "-"+matcherParameter) {
+			negated = true
+		} else if !strings.HasPrefix(v, matcherParameter) {
+			return
+		}
+		if negated {
+			err = errors.New("compute endpoint does not support negating matcher:")
+			return
+		}
+		if seen {
+			err = errors.New("compute endpoint only supports one matcher: parameter")
+			return
+		}
+		value = strings.TrimPrefix(v, matcherParameter)
+		rrange = annotation.Range
+		seen = true
+	})
+	if err != nil || !seen {
+		return q, "", err
+	}
+	if value == "" {
+		return "", "", errors.New("compute endpoint expects a value for matcher:, like matcher:.go or matcher:go")
+	}
+
+	// Patterns are parsed from a single line, so columns are offsets into q.
+	q = strings.TrimSpace(q[:rrange.Start.Column] + " " + q[rrange.End.Column:])
+	if strings.HasPrefix(value, ".") {
+		return q, comby.ExtensionToMatcher(value), nil
+	}
+	return q, comby.LookupMatcher(value), nil
+}
+
 func Parse(q string) (*Query, error) {
+	q, matcher, err := extractMatcher(q)
+	if err != nil {
+		return nil, err
+	}
+
 	parseTree, err := query.ParseRegexp(q)
 	if err != nil {
 		return nil, err
@@ -301,5 +472,5 @@ func Parse(q string) (*Query, error) {
 	if err != nil {
 		return nil, err
 	}
-	return toComputeQuery(plan)
+	return toComputeQuery(plan, matcher)
 }
@@ -50,6 +50,65 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("replace as diff",
+		"Command: `Replace as diff: (sourcegraph) -> (smorgasboard)`").
+		Equal(t, test("content:replace.diff(sourcegraph -> smorgasboard)"))
+
+	autogold.Want("structural replace as diff",
+		"Command: `Replace as diff: (foo(:[x])) -> (bar(:[x]))`").
+		Equal(t, test("content:replace.structural.diff(foo(:[x]) -> bar(:[x]))"))
+}
+
+func TestParseLanguage(t *testing.T) {
+	test := func(input string) string {
+		q, err := Parse(input)
+		if err != nil {
+			return err.Error()
+		}
+		switch c := q.Command.(type) {
+		case *Replace:
+			return c.Language
+		case *Output:
+			return c.Language
+		}
+		return "unsupported command"
+	}
+
+	autogold.Want("replace without lang", "").Equal(t, test("content:replace.structural(foo(:[x]) -> bar(:[x]))"))
+	autogold.Want("replace with lang", "go").Equal(t, test("content:replace.structural(foo(:[x]) -> bar(:[x])) lang:go"))
+	autogold.Want("output with lang alias", "python").Equal(t, test("content:output.structural(foo(:[x]) -> :[x]) language:python"))
+	autogold.Want("negated lang ignored", "").Equal(t, test("content:output.structural(foo(:[x]) -> :[x]) -lang:go"))
+}
+
+func TestParseMatcher(t *testing.T) {
+	test := func(input string) string {
+		q, err := Parse(input)
+		if err != nil {
+			return err.Error()
+		}
+		s, _ := q.ToSearchQuery()
+		switch c := q.Command.(type) {
+		case *Replace:
+			return c.Matcher + " " + s
+		case *Output:
+			return c.Matcher + " " + s
+		case *Extract:
+			return c.Matcher + " " + s
+		}
+		return "unsupported command"
+	}
+
+	autogold.Want("replace without matcher", " (foo(:[x]))").Equal(t, test("content:replace.structural(foo(:[x]) -> bar(:[x]))"))
+	autogold.Want("replace with extension matcher", ".go repo:foo foo(:[x])").Equal(t, test("content:replace.structural(foo(:[x]) -> bar(:[x])) matcher:.go repo:foo"))
+	autogold.Want("output with language matcher", ".py lang:go foo(:[x])").Equal(t, test("matcher:python content:output.structural(foo(:[x]) -> :[x]) lang:go"))
+	autogold.Want("extract with unknown matcher", ".generic (foo(:[x]))").Equal(t, test("content:extract.structural(foo(:[x])) matcher:.unknown"))
+	autogold.Want("matcher inside pattern is not a parameter", " (matcher:x)").Equal(t, test("content:output(matcher:x -> b)"))
+	autogold.Want("match only with matcher", "compute endpoint only supports matcher: for replace, output, and extract commands").Equal(t, test("foo matcher:.go"))
+	autogold.Want("negated matcher", "compute endpoint does not support negating matcher:").Equal(t, test("content:output.structural(foo(:[x]) -> :[x]) -matcher:.go"))
+	autogold.Want("not matcher", "compute endpoint does not support negating matcher:").Equal(t, test("content:output.structural(foo(:[x]) -> :[x]) not matcher:.go"))
+	autogold.Want("quoted matcher is a pattern", "compute endpoint cannot currently support expressions in patterns containing 'and', 'or', 'not' (or negation) right now!").Equal(t, test(`content:output.structural(foo(:[x]) -> :[x]) "matcher:.go"`))
+	autogold.Want("multiple matchers", "compute endpoint only supports one matcher: parameter").Equal(t, test("content:output.structural(foo(:[x]) -> :[x]) matcher:.go matcher:.py"))
 }
 
 func TestToSearchQuery(t *testing.T) {
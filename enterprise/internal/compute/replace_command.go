@@ -15,6 +15,15 @@ import (
 type Replace struct {
 	SearchPattern  MatchPattern
 	ReplacePattern string
+	// Diff is true if the result should be a diff of the file rather than its
+	// replaced contents.
+	Diff bool
+	// Language is the value of the query's lang: filter, if any. It overrides
+	// the comby matcher that would otherwise be inferred from the file path.
+	Language string
+	// Matcher is the comby matcher set by the matcher: parameter, if any. It
+	// takes precedence over Language and does not change the search query.
+	Matcher string
 }
 
 func (c *Replace) ToSearchPattern() string {
@@ -22,10 +31,13 @@ func (c *Replace) ToSearchPattern() string {
 }
 
 func (c *Replace) String() string {
+	if c.Diff {
+		return fmt.Sprintf("Replace as diff: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
+	}
 	return fmt.Sprintf("Replace in place: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
 }
 
-func replace(ctx context.Context, content []byte, matchPattern MatchPattern, replacePattern string) (*Text, error) {
+func replace(ctx context.Context, content []byte, matchPattern MatchPattern, replacePattern, matcher string) (*Text, error) {
 	var newContent string
 	switch match := matchPattern.(type) {
 	case *Regexp:
@@ -35,7 +47,7 @@ func replace(ctx context.Context, content []byte, matchPattern MatchPattern, rep
 			Input:           comby.FileContent(content),
 			MatchTemplate:   match.Value,
 			RewriteTemplate: replacePattern,
-			Matcher:         ".generic", // TODO(rvantonder): use language or file filter
+			Matcher:         matcher,
 			ResultKind:      comby.Replacement,
 			NumWorkers:      0, // Just a single file's content.
 		})
@@ -57,7 +69,27 @@ func (c *Replace) Run(ctx context.Context, db database.DB, r result.Match) (Resu
 		if err != nil {
 			return nil, err
 		}
-		return replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+		replaced, err := replace(ctx, content, c.SearchPattern, c.ReplacePattern, combyMatcher(c.Matcher, c.Language, m.Path))
+		if err != nil {
+			return nil, err
+		}
+		if !c.Diff {
+			return replaced, nil
+		}
+		if replaced.Value == string(content) {
+			// Nothing to apply.
+			return nil, nil
+		}
+		hunks := diffHunks(m.Path, string(content), replaced.Value)
+		return &FileDiff{
+			Value:        formatFileDiff(m.Path, hunks),
+			Kind:         "replace-diff",
+			Hunks:        hunks,
+			Path:         m.Path,
+			Commit:       string(m.CommitID),
+			RepositoryID: int32(m.Repo.ID),
+			Repository:   string(m.Repo.Name),
+		}, nil
 	}
 	return nil, nil
 }
@@ -13,7 +13,7 @@ import (
 
 func Test_replace(t *testing.T) {
 	test := func(input string, cmd *Replace) string {
-		result, err := replace(context.Background(), []byte(input), cmd.SearchPattern, cmd.ReplacePattern)
+		result, err := replace(context.Background(), []byte(input), cmd.SearchPattern, cmd.ReplacePattern, combyMatcher(cmd.Matcher, cmd.Language, ""))
 		if err != nil {
 			return err.Error()
 		}
@@ -40,4 +40,50 @@ func Test_replace(t *testing.T) {
 			SearchPattern:  &Comby{Value: `foo(:[x], :[y])`},
 			ReplacePattern: "foo(:[y], :[x])",
 		}))
+
+	autogold.Want(
+		"structural search replace honors language",
+		"// foo(a, b)\nfoo(d, c)").
+		Equal(t, test("// foo(a, b)\nfoo(c, d)", &Replace{
+			SearchPattern:  &Comby{Value: `foo(:[x], :[y])`},
+			ReplacePattern: "foo(:[y], :[x])",
+			Language:       "go",
+		}))
+
+	autogold.Want(
+		"structural search replace matcher overrides language",
+		"// foo(a, b)\nfoo(d, c)").
+		Equal(t, test("// foo(a, b)\nfoo(c, d)", &Replace{
+			SearchPattern:  &Comby{Value: `foo(:[x], :[y])`},
+			ReplacePattern: "foo(:[y], :[x])",
+			Language:       "text",
+			Matcher:        ".go",
+		}))
+}
+
+func Test_diffHunks(t *testing.T) {
+	test := func(before, after string) string {
+		return formatFileDiff("a.go", diffHunks("a.go", before, after))
+	}
+
+	autogold.Want("single line change", `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,3 @@
+ one
+-two
++deux
+ three
+`).Equal(t, test("one\ntwo\nthree\n", "one\ndeux\nthree\n"))
+
+	autogold.Want("no newline at end of file", `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,2 +1,2 @@
+ one
+-two
+\ No newline at end of file
++deux
+\ No newline at end of file
+`).Equal(t, test("one\ntwo", "one\ndeux"))
 }
@@ -0,0 +1,65 @@
+package compute
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Table is the result of extracting values from the matches in a single search
+// result. Every row has a value for each column, which may be empty if the
+// corresponding capture group or hole did not participate in the match.
+type Table struct {
+	Columns []string `json:"columns"`
+	Rows    []Row    `json:"rows"`
+}
+
+// Row is the values extracted from a single match, along with where the match
+// was found. Line is 1-based.
+type Row struct {
+	Repository   string            `json:"repository"`
+	RepositoryID int32             `json:"repositoryID"`
+	Commit       string            `json:"commit"`
+	Path         string            `json:"path"`
+	Line         int               `json:"line"`
+	Values       map[string]string `json:"values"`
+}
+
+// Record returns the row as a CSV record whose fields line up with
+// TableHeader(columns).
+func (r Row) Record(columns []string) []string {
+	record := make([]string, 0, len(tableMetadataColumns)+len(columns))
+	record = append(record, r.Repository, r.Commit, r.Path, strconv.Itoa(r.Line))
+	for _, c := range columns {
+		record = append(record, r.Values[c])
+	}
+	return record
+}
+
+var tableMetadataColumns = []string{"repository", "commit", "path", "line"}
+
+// validateColumns returns an error if value columns can't be told apart from
+// each other or from the metadata columns in a table header.
+func validateColumns(columns []string) error {
+	seen := make(map[string]struct{}, len(columns))
+	for _, c := range columns {
+		for _, m := range tableMetadataColumns {
+			if strings.EqualFold(c, m) {
+				return errors.Errorf("column %q conflicts with the %q column of every row, rename its capture group or hole", c, m)
+			}
+		}
+		if _, ok := seen[c]; ok {
+			return errors.Errorf("column %q is defined more than once", c)
+		}
+		seen[c] = struct{}{}
+	}
+	return nil
+}
+
+// TableHeader returns the CSV header for rows with the given value columns.
+func TableHeader(columns []string) []string {
+	header := make([]string, 0, len(tableMetadataColumns)+len(columns))
+	header = append(header, tableMetadataColumns...)
+	return append(header, columns...)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
@@ -17,8 +18,16 @@ type ActionJob struct {
 	Email        *int64
 	Webhook      *int64
 	SlackWebhook *int64
+	TeamsWebhook *int64
+	ChatWebhook  *int64
 	TriggerEvent int32
 
+	// DeliveryAttempts is the number of times delivery of the notification
+	// was attempted, and LastDeliveryError the error of the most recent failed
+	// attempt.
+	DeliveryAttempts  int32
+	LastDeliveryError *string
+
 	// Fields demanded by any dbworker.
 	State          string
 	FailureMessage *string
@@ -51,7 +60,11 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.teams_webhook"),
+	sqlf.Sprintf("cm_action_jobs.chat_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
+	sqlf.Sprintf("cm_action_jobs.delivery_attempts"),
+	sqlf.Sprintf("cm_action_jobs.last_delivery_error"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
 	sqlf.Sprintf("cm_action_jobs.started_at"),
@@ -82,6 +95,16 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// TeamsWebhookID, if set, will filter to only actions jobs that are
+	// executing the given Microsoft Teams webhook action. Refers to
+	// cm_teams_webhooks(id)
+	TeamsWebhookID *int
+
+	// ChatWebhookID, if set, will filter to only actions jobs that are
+	// executing the given Mattermost or Discord webhook action. Refers to
+	// cm_chat_webhooks(id)
+	ChatWebhookID *int
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -105,6 +128,12 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.TeamsWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("teams_webhook = %s", *o.TeamsWebhookID))
+	}
+	if o.ChatWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("chat_webhook = %s", *o.ChatWebhookID))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -166,14 +195,15 @@ func (s *codeMonitorStore) CountActionJobs(ctx context.Context, opts ListActionJ
 
 const enqueueActionEmailFmtStr = `
 WITH due_emails AS (
-	SELECT id
+	SELECT id, %s AS process_after
 	FROM cm_emails
 	WHERE monitor = %s
 		AND enabled = true
-	EXCEPT
-	SELECT DISTINCT email as id FROM cm_action_jobs
-	WHERE state = 'queued'
-		OR state = 'processing'
+		AND NOT EXISTS (
+			SELECT 1 FROM cm_action_jobs
+			WHERE email = cm_emails.id
+				AND %s
+		)
 ), due_webhooks AS (
 	SELECT id
 	FROM cm_webhooks
@@ -184,34 +214,83 @@ WITH due_emails AS (
 	WHERE state = 'queued'
 		OR state = 'processing'
 ), due_slack_webhooks AS (
-	SELECT id
+	SELECT id, %s AS process_after
 	FROM cm_slack_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+		AND NOT EXISTS (
+			SELECT 1 FROM cm_action_jobs
+			WHERE slack_webhook = cm_slack_webhooks.id
+				AND %s
+		)
+), due_teams_webhooks AS (
+	SELECT id
+	FROM cm_teams_webhooks
 	WHERE monitor = %s
 		AND enabled = true
 	EXCEPT
-	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
+	SELECT DISTINCT teams_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
+), due_chat_webhooks AS (
+	SELECT id
+	FROM cm_chat_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT chat_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, teams_webhook, chat_webhook, trigger_event, process_after)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, process_after from due_emails
+UNION
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMPTZ) from due_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, process_after from due_slack_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMPTZ) from due_teams_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer, CAST(NULL AS TIMESTAMPTZ) from due_chat_webhooks
+ORDER BY 1, 2, 3, 4, 5
 RETURNING %s
 `
 
+// digestProcessAfterFmtStr evaluates to the end of the current digest
+// interval of an action, or NULL if the action doesn't send digests. Digest
+// action jobs aren't processed before then, so all trigger events within the
+// interval are sent together.
+const digestProcessAfterFmtStr = `CASE digest_interval
+		WHEN 'HOURLY' THEN date_trunc('hour', now()) + '1 hour'::interval
+		WHEN 'DAILY' THEN date_trunc('day', now()) + '1 day'::interval
+		WHEN 'WEEKLY' THEN date_trunc('week', now()) + '1 week'::interval
+	END`
+
+// pendingActionJobFmtStr matches the action jobs that new results of an action
+// will be sent by. A digest job that is processing may already have collected
+// the results of the current trigger event, so we queue another digest job for
+// the next interval rather than risk dropping them.
+const pendingActionJobFmtStr = `(state = 'queued' OR (state = 'processing' AND digest_interval IS NULL))`
+
+// EnqueueActionJobsForMonitor queues an action job for every enabled action of
+// the given monitor that doesn't have a pending one already.
 func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJobID int32) ([]*ActionJob, error) {
 	q := sqlf.Sprintf(
 		enqueueActionEmailFmtStr,
+		sqlf.Sprintf(digestProcessAfterFmtStr),
+		monitorID,
+		sqlf.Sprintf(pendingActionJobFmtStr),
+		monitorID,
+		sqlf.Sprintf(digestProcessAfterFmtStr),
 		monitorID,
+		sqlf.Sprintf(pendingActionJobFmtStr),
 		monitorID,
 		monitorID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
+		triggerJobID,
+		triggerJobID,
 		sqlf.Join(ActionJobColumns, ","),
 	)
 	rows, err := s.Query(ctx, q)
@@ -264,6 +343,28 @@ func (s *codeMonitorStore) GetActionJob(ctx context.Context, jobID int32) (*Acti
 	return ScanActionJob(row)
 }
 
+const recordActionJobDeliveryAttemptFmtStr = `
+UPDATE cm_action_jobs
+SET
+	delivery_attempts = delivery_attempts + 1,
+	last_delivery_error = COALESCE(%s, last_delivery_error)
+WHERE id = %s
+RETURNING delivery_attempts
+`
+
+// RecordActionJobDeliveryAttempt records an attempt to deliver the
+// notification of the given action job and returns the total number of
+// attempts. If deliveryErr is nil, the attempt succeeded.
+func (s *codeMonitorStore) RecordActionJobDeliveryAttempt(ctx context.Context, jobID int32, deliveryErr error) (int, error) {
+	var lastError *string
+	if deliveryErr != nil {
+		msg := deliveryErr.Error()
+		lastError = &msg
+	}
+	attempts, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(recordActionJobDeliveryAttemptFmtStr, lastError, jobID)))
+	return attempts, err
+}
+
 func scanActionJobs(rows *sql.Rows) ([]*ActionJob, error) {
 	var ajs []*ActionJob
 	for rows.Next() {
@@ -283,7 +384,11 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
+		&aj.TeamsWebhook,
+		&aj.ChatWebhook,
 		&aj.TriggerEvent,
+		&aj.DeliveryAttempts,
+		&aj.LastDeliveryError,
 		&aj.State,
 		&aj.FailureMessage,
 		&aj.StartedAt,
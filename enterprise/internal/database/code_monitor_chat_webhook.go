@@ -0,0 +1,254 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+)
+
+// ChatWebhookService is a chat service that accepts Slack-like incoming
+// webhooks. The service determines the payload format.
+type ChatWebhookService string
+
+const (
+	ChatWebhookServiceMattermost ChatWebhookService = "MATTERMOST"
+	ChatWebhookServiceDiscord    ChatWebhookService = "DISCORD"
+)
+
+// Valid returns whether s is a known chat service.
+func (s ChatWebhookService) Valid() bool {
+	switch s {
+	case ChatWebhookServiceMattermost, ChatWebhookServiceDiscord:
+		return true
+	}
+	return false
+}
+
+type ChatWebhookAction struct {
+	ID      int64
+	Monitor int64
+	Enabled bool
+	Service ChatWebhookService
+	// URL is the incoming webhook URL. See TeamsWebhookAction.URL.
+	URL            *encryption.Encryptable
+	IncludeResults bool
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+const updateChatWebhookActionQuery = `
+UPDATE cm_chat_webhooks
+SET enabled = %s,
+	include_results = %s,
+	service = %s,
+	url = %s,
+	encryption_key_id = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_chat_webhooks.monitor
+			AND cm_monitors.namespace_user_id = %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateChatWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, service ChatWebhookService, url string) (*ChatWebhookAction, error) {
+	encryptedURL, keyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), url)
+	if err != nil {
+		return nil, err
+	}
+
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateChatWebhookActionQuery,
+		enabled,
+		includeResults,
+		service,
+		encryptedURL,
+		dbutil.NullStringColumn(keyID),
+		a.UID,
+		s.Now(),
+		id,
+		a.UID,
+		sqlf.Join(chatWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanChatWebhookAction(row, s.getEncryptionKey())
+}
+
+const createChatWebhookActionQuery = `
+INSERT INTO cm_chat_webhooks
+(monitor, enabled, include_results, service, url, encryption_key_id, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateChatWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, service ChatWebhookService, url string) (*ChatWebhookAction, error) {
+	encryptedURL, keyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), url)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createChatWebhookActionQuery,
+		monitorID,
+		enabled,
+		includeResults,
+		service,
+		encryptedURL,
+		dbutil.NullStringColumn(keyID),
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(chatWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanChatWebhookAction(row, s.getEncryptionKey())
+}
+
+const deleteChatWebhookActionQuery = `
+DELETE FROM cm_chat_webhooks
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteChatWebhookActions(ctx context.Context, monitorID int64, webhookIDs ...int64) error {
+	if len(webhookIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(webhookIDs))
+	for _, ids := range webhookIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteChatWebhookActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countChatWebhookActionsQuery = `
+SELECT COUNT(*)
+FROM cm_chat_webhooks
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountChatWebhookActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countChatWebhookActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getChatWebhookActionQuery = `
+SELECT %s -- ChatWebhookActionColumns
+FROM cm_chat_webhooks
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetChatWebhookAction(ctx context.Context, id int64) (*ChatWebhookAction, error) {
+	q := sqlf.Sprintf(
+		getChatWebhookActionQuery,
+		sqlf.Join(chatWebhookActionColumns, ","),
+		id,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanChatWebhookAction(row, s.getEncryptionKey())
+}
+
+const listChatWebhookActionsQuery = `
+SELECT %s -- ChatWebhookActionColumns
+FROM cm_chat_webhooks
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListChatWebhookActions(ctx context.Context, opts ListActionsOpts) ([]*ChatWebhookAction, error) {
+	q := sqlf.Sprintf(
+		listChatWebhookActionsQuery,
+		sqlf.Join(chatWebhookActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanChatWebhookActions(rows, s.getEncryptionKey())
+}
+
+// chatWebhookActionColumns is the set of columns in the cm_chat_webhooks table
+// This must be kept in sync with scanChatWebhookAction
+var chatWebhookActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_chat_webhooks.id"),
+	sqlf.Sprintf("cm_chat_webhooks.monitor"),
+	sqlf.Sprintf("cm_chat_webhooks.enabled"),
+	sqlf.Sprintf("cm_chat_webhooks.service"),
+	sqlf.Sprintf("cm_chat_webhooks.url"),
+	sqlf.Sprintf("cm_chat_webhooks.encryption_key_id"),
+	sqlf.Sprintf("cm_chat_webhooks.include_results"),
+	sqlf.Sprintf("cm_chat_webhooks.created_by"),
+	sqlf.Sprintf("cm_chat_webhooks.created_at"),
+	sqlf.Sprintf("cm_chat_webhooks.changed_by"),
+	sqlf.Sprintf("cm_chat_webhooks.changed_at"),
+}
+
+func scanChatWebhookActions(rows *sql.Rows, key encryption.Key) ([]*ChatWebhookAction, error) {
+	var ws []*ChatWebhookAction
+	for rows.Next() {
+		w, err := scanChatWebhookAction(rows, key)
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, rows.Err()
+}
+
+// scanChatWebhookAction scans a ChatWebhookAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with chatWebhookActionColumns.
+func scanChatWebhookAction(scanner dbutil.Scanner, key encryption.Key) (*ChatWebhookAction, error) {
+	var (
+		w          ChatWebhookAction
+		url, keyID string
+	)
+	if err := scanner.Scan(
+		&w.ID,
+		&w.Monitor,
+		&w.Enabled,
+		&w.Service,
+		&url,
+		&dbutil.NullString{S: &keyID},
+		&w.IncludeResults,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.ChangedBy,
+		&w.ChangedAt,
+	); err != nil {
+		return &w, err
+	}
+
+	w.URL = newEncryptableWebhookURL(url, keyID, key)
+	return &w, nil
+}
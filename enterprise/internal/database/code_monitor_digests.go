@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+)
+
+// DigestInterval is the interval at which an action collects the results of
+// its code monitor and sends them as a single notification. The zero value
+// sends a notification for every trigger event with results.
+type DigestInterval string
+
+const (
+	DigestIntervalNone   DigestInterval = ""
+	DigestIntervalHourly DigestInterval = "HOURLY"
+	DigestIntervalDaily  DigestInterval = "DAILY"
+	DigestIntervalWeekly DigestInterval = "WEEKLY"
+)
+
+func (i DigestInterval) Valid() bool {
+	switch i {
+	case DigestIntervalNone, DigestIntervalHourly, DigestIntervalDaily, DigestIntervalWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+const listDigestTriggerJobsFmtStr = `
+SELECT %s -- TriggerJobsColumns
+FROM cm_trigger_jobs
+INNER JOIN cm_queries ON cm_queries.id = cm_trigger_jobs.query
+WHERE cm_queries.monitor = %s
+	AND cm_trigger_jobs.id >= %s
+	AND cm_trigger_jobs.state = 'completed'
+	AND jsonb_array_length(cm_trigger_jobs.search_results) > 0
+ORDER BY cm_trigger_jobs.id ASC
+`
+
+// ListDigestTriggerJobs returns the completed trigger jobs of the given
+// monitor that found results, starting with the trigger job with ID
+// fromTriggerJob.
+func (s *codeMonitorStore) ListDigestTriggerJobs(ctx context.Context, monitorID int64, fromTriggerJob int32) ([]*TriggerJob, error) {
+	q := sqlf.Sprintf(
+		listDigestTriggerJobsFmtStr,
+		sqlf.Join(TriggerJobsColumns, ","),
+		monitorID,
+		fromTriggerJob,
+	)
+	rows, err := s.Store.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTriggerJobs(rows)
+}
+
+const setEmailActionLastDigestFmtStr = `
+UPDATE cm_emails
+SET last_digest_trigger_event = %s
+WHERE id = %s
+`
+
+// SetEmailActionLastDigest records the most recent trigger job whose results
+// were sent in a digest of the given email action.
+func (s *codeMonitorStore) SetEmailActionLastDigest(ctx context.Context, emailID int64, triggerJobID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(setEmailActionLastDigestFmtStr, triggerJobID, emailID))
+}
+
+const setSlackWebhookActionLastDigestFmtStr = `
+UPDATE cm_slack_webhooks
+SET last_digest_trigger_event = %s
+WHERE id = %s
+`
+
+// SetSlackWebhookActionLastDigest records the most recent trigger job whose
+// results were sent in a digest of the given Slack webhook action.
+func (s *codeMonitorStore) SetSlackWebhookActionLastDigest(ctx context.Context, id int64, triggerJobID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(setSlackWebhookActionLastDigestFmtStr, triggerJobID, id))
+}
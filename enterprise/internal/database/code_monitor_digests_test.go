@@ -0,0 +1,76 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestDigests(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+
+	digestEmail, err := s.UpdateEmailAction(userCTX, fixtures.emails[0].ID, &EmailActionArgs{
+		Enabled:        true,
+		Priority:       "NORMAL",
+		Header:         "test header 1",
+		DigestInterval: DigestIntervalDaily,
+	})
+	require.NoError(t, err)
+	require.Equal(t, DigestIntervalDaily, digestEmail.DigestInterval)
+
+	// runTrigger simulates a completed run of the monitor's query that found
+	// numResults results.
+	runTrigger := func(numResults int) ([]*ActionJob, int32) {
+		triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, triggerJobs, 1)
+		triggerJobID := triggerJobs[0].ID
+
+		err = s.UpdateTriggerJobWithResults(ctx, triggerJobID, testQuery, make([]*result.CommitMatch, numResults))
+		require.NoError(t, err)
+		err = s.Exec(ctx, sqlf.Sprintf("UPDATE cm_trigger_jobs SET state = 'completed' WHERE id = %s", triggerJobID))
+		require.NoError(t, err)
+
+		if numResults == 0 {
+			return nil, triggerJobID
+		}
+		actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobID)
+		require.NoError(t, err)
+		return actionJobs, triggerJobID
+	}
+
+	actionJobs, first := runTrigger(2)
+	require.Len(t, actionJobs, 2)
+	require.Equal(t, digestEmail.ID, *actionJobs[0].Email)
+	require.NotNil(t, actionJobs[0].ProcessAfter)
+	require.True(t, actionJobs[0].ProcessAfter.After(s.Now()))
+	require.Nil(t, actionJobs[1].ProcessAfter)
+
+	_, _ = runTrigger(0)
+
+	// The queued digest job also covers the results of later trigger runs.
+	actionJobs, second := runTrigger(3)
+	require.Empty(t, actionJobs)
+
+	triggerJobs, err := s.ListDigestTriggerJobs(ctx, fixtures.monitor.ID, first)
+	require.NoError(t, err)
+	require.Len(t, triggerJobs, 2)
+	require.Equal(t, first, triggerJobs[0].ID)
+	require.Equal(t, second, triggerJobs[1].ID)
+	require.Len(t, triggerJobs[1].SearchResults, 3)
+
+	triggerJobs, err = s.ListDigestTriggerJobs(ctx, fixtures.monitor.ID, second+1)
+	require.NoError(t, err)
+	require.Empty(t, triggerJobs)
+
+	err = s.SetEmailActionLastDigest(ctx, digestEmail.ID, second)
+	require.NoError(t, err)
+	got, err := s.GetEmailAction(ctx, digestEmail.ID)
+	require.NoError(t, err)
+	require.Equal(t, &second, got.LastDigestTriggerEvent)
+}
@@ -22,6 +22,12 @@ type EmailAction struct {
 	CreatedAt      time.Time
 	ChangedBy      int32
 	ChangedAt      time.Time
+
+	// DigestInterval, if set, sends the results of the monitor as a single
+	// email per interval. LastDigestTriggerEvent is the most recent trigger
+	// event whose results were sent in a digest.
+	DigestInterval         DigestInterval
+	LastDigestTriggerEvent *int32
 }
 
 const updateActionEmailFmtStr = `
@@ -30,6 +36,7 @@ SET enabled = %s,
     include_results = %s,
 	priority = %s,
 	header = %s,
+	digest_interval = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -47,6 +54,7 @@ type EmailActionArgs struct {
 	IncludeResults bool
 	Priority       string
 	Header         string
+	DigestInterval DigestInterval
 }
 
 func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args *EmailActionArgs) (*EmailAction, error) {
@@ -57,6 +65,7 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		dbutil.NullStringColumn(string(args.DigestInterval)),
 		a.UID,
 		s.Now(),
 		id,
@@ -70,8 +79,8 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 
 const createActionEmailFmtStr = `
 INSERT INTO cm_emails
-(monitor, enabled, include_results, priority, header, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, priority, header, digest_interval, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
@@ -85,6 +94,7 @@ func (s *codeMonitorStore) CreateEmailAction(ctx context.Context, monitorID int6
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		dbutil.NullStringColumn(string(args.DigestInterval)),
 		a.UID,
 		now,
 		a.UID,
@@ -206,6 +216,8 @@ var emailsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_emails.created_at"),
 	sqlf.Sprintf("cm_emails.changed_by"),
 	sqlf.Sprintf("cm_emails.changed_at"),
+	sqlf.Sprintf("cm_emails.digest_interval"),
+	sqlf.Sprintf("cm_emails.last_digest_trigger_event"),
 }
 
 func scanEmails(rows *sql.Rows) ([]*EmailAction, error) {
@@ -224,6 +236,7 @@ func scanEmails(rows *sql.Rows) ([]*EmailAction, error) {
 // It must be kept in sync with emailsColumns.
 func scanEmail(scanner dbutil.Scanner) (*EmailAction, error) {
 	m := &EmailAction{}
+	var digestInterval string
 	err := scanner.Scan(
 		&m.ID,
 		&m.Monitor,
@@ -235,6 +248,9 @@ func scanEmail(scanner dbutil.Scanner) (*EmailAction, error) {
 		&m.CreatedAt,
 		&m.ChangedBy,
 		&m.ChangedAt,
+		&dbutil.NullString{S: &digestInterval},
+		&m.LastDigestTriggerEvent,
 	)
+	m.DigestInterval = DigestInterval(digestInterval)
 	return m, err
 }
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ResultFingerprint identifies a result of a code monitor whose query doesn't
+// search commits, such as a content, symbol or select:repo query. Two results
+// are the same if their Key is the same.
+type ResultFingerprint struct {
+	Repo string `json:"r"`
+	Path string `json:"p,omitempty"`
+	// Kind is one of "repo", "path", "content" or "symbol".
+	Kind string `json:"k"`
+	// Hash is a short hash of the match, such as the matched line of a
+	// content match or the name and kind of a symbol.
+	Hash string `json:"h,omitempty"`
+
+	// Commit is the commit the result was found at, and Preview is a short
+	// description of the match for notifications. Neither is part of the
+	// identity of the result.
+	Commit  string `json:"c,omitempty"`
+	Preview string `json:"v,omitempty"`
+}
+
+// Key returns the identity of the result.
+func (f ResultFingerprint) Key() string {
+	return f.Repo + "\x00" + f.Path + "\x00" + f.Kind + "\x00" + f.Hash
+}
+
+func (s *codeMonitorStore) GetResultSet(ctx context.Context, monitorID int64) (_ []ResultFingerprint, ok bool, err error) {
+	rawQuery := `
+	SELECT fingerprints
+	FROM cm_result_sets
+	WHERE monitor_id = %s
+	`
+
+	var raw []byte
+	err = s.QueryRow(ctx, sqlf.Sprintf(rawQuery, monitorID)).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var fingerprints []ResultFingerprint
+	if err := json.Unmarshal(raw, &fingerprints); err != nil {
+		return nil, false, err
+	}
+	return fingerprints, true, nil
+}
+
+func (s *codeMonitorStore) UpsertResultSet(ctx context.Context, monitorID int64, fingerprints []ResultFingerprint) error {
+	rawQuery := `
+	INSERT INTO cm_result_sets (monitor_id, fingerprints, updated_at)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (monitor_id) DO UPDATE
+	SET fingerprints = EXCLUDED.fingerprints,
+		updated_at = EXCLUDED.updated_at
+	`
+
+	// Appease non-null constraint on column
+	if fingerprints == nil {
+		fingerprints = []ResultFingerprint{}
+	}
+	raw, err := json.Marshal(fingerprints)
+	if err != nil {
+		return err
+	}
+	return s.Exec(ctx, sqlf.Sprintf(rawQuery, monitorID, raw, s.Now()))
+}
+
+func (s *codeMonitorStore) DeleteResultSet(ctx context.Context, monitorID int64) error {
+	rawQuery := `
+	DELETE FROM cm_result_sets
+	WHERE monitor_id = %s
+	`
+
+	return s.Exec(ctx, sqlf.Sprintf(rawQuery, monitorID))
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreResultSets(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+	fixtures := populateCodeMonitorFixtures(t, db)
+	cm := db.CodeMonitors()
+
+	// A monitor that hasn't stored a result set yet
+	fingerprints, ok, err := cm.GetResultSet(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Empty(t, fingerprints)
+
+	// An empty result set is different from a missing one
+	err = cm.UpsertResultSet(ctx, fixtures.Monitor.ID, nil)
+	require.NoError(t, err)
+	fingerprints, ok, err = cm.GetResultSet(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Empty(t, fingerprints)
+
+	// Update
+	want := []ResultFingerprint{
+		{Repo: "github.com/test/test", Kind: "repo"},
+		{Repo: "github.com/test/test", Path: "Dockerfile", Kind: "content", Hash: "0123456789abcdef", Commit: "deadbeef", Preview: "FROM ubuntu:18.04"},
+	}
+	err = cm.UpsertResultSet(ctx, fixtures.Monitor.ID, want)
+	require.NoError(t, err)
+	fingerprints, ok, err = cm.GetResultSet(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, want, fingerprints)
+
+	// Delete
+	err = cm.DeleteResultSet(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	_, ok, err = cm.GetResultSet(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -9,15 +9,24 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 )
 
 type SlackWebhookAction struct {
 	ID             int64
 	Monitor        int64
-	Enabled        bool
-	URL            string
+	Enabled bool
+	// URL is the incoming webhook URL. It is stored encrypted because the URL
+	// itself grants permission to post to the channel.
+	URL            *encryption.Encryptable
 	IncludeResults bool
 
+	// DigestInterval, if set, sends the results of the monitor as a single
+	// message per interval. LastDigestTriggerEvent is the most recent trigger
+	// event whose results were sent in a digest.
+	DigestInterval         DigestInterval
+	LastDigestTriggerEvent *int32
+
 	CreatedBy int32
 	CreatedAt time.Time
 	ChangedBy int32
@@ -28,7 +37,9 @@ const updateSlackWebhookActionQuery = `
 UPDATE cm_slack_webhooks
 SET enabled = %s,
 	include_results = %s,
+	digest_interval = %s,
 	url = %s,
+	encryption_key_id = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,13 +52,20 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, digestInterval DigestInterval, url string) (*SlackWebhookAction, error) {
+	encryptedURL, keyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), url)
+	if err != nil {
+		return nil, err
+	}
+
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		updateSlackWebhookActionQuery,
 		enabled,
 		includeResults,
-		url,
+		dbutil.NullStringColumn(string(digestInterval)),
+		encryptedURL,
+		dbutil.NullStringColumn(keyID),
 		a.UID,
 		s.Now(),
 		id,
@@ -56,17 +74,22 @@ func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int6
 	)
 
 	row := s.QueryRow(ctx, q)
-	return scanSlackWebhookAction(row)
+	return scanSlackWebhookAction(row, s.getEncryptionKey())
 }
 
 const createSlackWebhookActionQuery = `
 INSERT INTO cm_slack_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, digest_interval, url, encryption_key_id, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, digestInterval DigestInterval, url string) (*SlackWebhookAction, error) {
+	encryptedURL, keyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), url)
+	if err != nil {
+		return nil, err
+	}
+
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -74,7 +97,9 @@ func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitor
 		monitorID,
 		enabled,
 		includeResults,
-		url,
+		dbutil.NullStringColumn(string(digestInterval)),
+		encryptedURL,
+		dbutil.NullStringColumn(keyID),
 		a.UID,
 		now,
 		a.UID,
@@ -83,7 +108,7 @@ func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitor
 	)
 
 	row := s.QueryRow(ctx, q)
-	return scanSlackWebhookAction(row)
+	return scanSlackWebhookAction(row, s.getEncryptionKey())
 }
 
 const deleteSlackWebhookActionQuery = `
@@ -135,7 +160,7 @@ func (s *codeMonitorStore) GetSlackWebhookAction(ctx context.Context, id int64)
 		id,
 	)
 	row := s.QueryRow(ctx, q)
-	return scanSlackWebhookAction(row)
+	return scanSlackWebhookAction(row, s.getEncryptionKey())
 }
 
 const listSlackWebhookActionsQuery = `
@@ -158,7 +183,7 @@ func (s *codeMonitorStore) ListSlackWebhookActions(ctx context.Context, opts Lis
 		return nil, err
 	}
 	defer rows.Close()
-	return scanSlackWebhookActions(rows)
+	return scanSlackWebhookActions(rows, s.getEncryptionKey())
 }
 
 // slackWebhookActionColumns is the set of columns in the cm_slack_webhooks table
@@ -168,17 +193,20 @@ var slackWebhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_slack_webhooks.monitor"),
 	sqlf.Sprintf("cm_slack_webhooks.enabled"),
 	sqlf.Sprintf("cm_slack_webhooks.url"),
+	sqlf.Sprintf("cm_slack_webhooks.encryption_key_id"),
 	sqlf.Sprintf("cm_slack_webhooks.include_results"),
+	sqlf.Sprintf("cm_slack_webhooks.digest_interval"),
+	sqlf.Sprintf("cm_slack_webhooks.last_digest_trigger_event"),
 	sqlf.Sprintf("cm_slack_webhooks.created_by"),
 	sqlf.Sprintf("cm_slack_webhooks.created_at"),
 	sqlf.Sprintf("cm_slack_webhooks.changed_by"),
 	sqlf.Sprintf("cm_slack_webhooks.changed_at"),
 }
 
-func scanSlackWebhookActions(rows *sql.Rows) ([]*SlackWebhookAction, error) {
+func scanSlackWebhookActions(rows *sql.Rows, key encryption.Key) ([]*SlackWebhookAction, error) {
 	var ws []*SlackWebhookAction
 	for rows.Next() {
-		w, err := scanSlackWebhookAction(rows)
+		w, err := scanSlackWebhookAction(rows, key)
 		if err != nil {
 			return nil, err
 		}
@@ -189,18 +217,29 @@ func scanSlackWebhookActions(rows *sql.Rows) ([]*SlackWebhookAction, error) {
 
 // scanSlackWebhookAction scans a SlackWebhookAction from a *sql.Row or *sql.Rows.
 // It must be kept in sync with slackWebhookActionColumns.
-func scanSlackWebhookAction(scanner dbutil.Scanner) (*SlackWebhookAction, error) {
-	var w SlackWebhookAction
-	err := scanner.Scan(
+func scanSlackWebhookAction(scanner dbutil.Scanner, key encryption.Key) (*SlackWebhookAction, error) {
+	var (
+		w                          SlackWebhookAction
+		url, keyID, digestInterval string
+	)
+	if err := scanner.Scan(
 		&w.ID,
 		&w.Monitor,
 		&w.Enabled,
-		&w.URL,
+		&url,
+		&dbutil.NullString{S: &keyID},
 		&w.IncludeResults,
+		&dbutil.NullString{S: &digestInterval},
+		&w.LastDigestTriggerEvent,
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
 		&w.ChangedAt,
-	)
-	return &w, err
+	); err != nil {
+		return &w, err
+	}
+
+	w.URL = newEncryptableWebhookURL(url, keyID, key)
+	w.DigestInterval = DigestInterval(digestInterval)
+	return &w, nil
 }
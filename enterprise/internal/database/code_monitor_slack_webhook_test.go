@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
 )
 
 func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
@@ -28,7 +29,7 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url1)
 		require.NoError(t, err)
 
 		got, err := s.GetSlackWebhookAction(ctx, action.ID)
@@ -45,13 +46,16 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url1)
 		require.NoError(t, err)
 
-		updated, err := s.UpdateSlackWebhookAction(ctx, action.ID, false, false, url2)
+		updated, err := s.UpdateSlackWebhookAction(ctx, action.ID, false, false, DigestIntervalDaily, url2)
 		require.NoError(t, err)
 		require.Equal(t, false, updated.Enabled)
-		require.Equal(t, url2, updated.URL)
+		gotURL, err := updated.URL.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, url2, gotURL)
+		require.Equal(t, DigestIntervalDaily, updated.DigestInterval)
 
 		got, err := s.GetSlackWebhookAction(ctx, action.ID)
 		require.NoError(t, err)
@@ -65,7 +69,7 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		_, _, ctx := newTestUser(ctx, t, db)
 		s := CodeMonitors(db)
 
-		_, err := s.UpdateSlackWebhookAction(ctx, 383838, false, false, url2)
+		_, err := s.UpdateSlackWebhookAction(ctx, 383838, false, false, DigestIntervalNone, url2)
 		require.Error(t, err)
 	})
 
@@ -77,10 +81,10 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action1, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action1, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url1)
 		require.NoError(t, err)
 
-		action2, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action2, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url1)
 		require.NoError(t, err)
 
 		err = s.DeleteSlackWebhookActions(ctx, fixtures.monitor.ID, action1.ID)
@@ -105,7 +109,7 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 0, count)
 
-		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url1)
 		require.NoError(t, err)
 
 		count, err = s.CountSlackWebhookActions(ctx, fixtures.monitor.ID)
@@ -125,10 +129,10 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, actions, 0)
 
-		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url1)
 		require.NoError(t, err)
 
-		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url2)
+		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url2)
 		require.NoError(t, err)
 
 		actions2, err := s.ListSlackWebhookActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
@@ -141,6 +145,32 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		require.Len(t, actions3, 1)
 	})
 
+	t.Run("EncryptedURL", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		s.key = et.TestKey{}
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, DigestIntervalNone, url1)
+		require.NoError(t, err)
+
+		got, err := s.GetSlackWebhookAction(ctx, action.ID)
+		require.NoError(t, err)
+		gotURL, err := got.URL.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, url1, gotURL)
+
+		// The URL is stored encrypted.
+		var rawURL, keyID string
+		err = db.QueryRowContext(ctx, "SELECT url, encryption_key_id FROM cm_slack_webhooks WHERE id = $1", action.ID).Scan(&rawURL, &keyID)
+		require.NoError(t, err)
+		require.NotEqual(t, url1, rawURL)
+		require.NotEmpty(t, keyID)
+	})
+
 	t.Run("Update permissions", func(t *testing.T) {
 		ctx, db, s := newTestStore(t)
 		uid1 := insertTestUser(ctx, t, db, "u1", false)
@@ -150,19 +180,21 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		fixtures := s.insertTestMonitor(ctx1, t)
 		_ = s.insertTestMonitor(ctx2, t)
 
-		wa, err := s.CreateSlackWebhookAction(ctx1, fixtures.monitor.ID, true, true, "https://true.com")
+		wa, err := s.CreateSlackWebhookAction(ctx1, fixtures.monitor.ID, true, true, DigestIntervalNone, "https://true.com")
 		require.NoError(t, err)
 
 		// User1 can update it
-		_, err = s.UpdateSlackWebhookAction(ctx1, wa.ID, true, true, "https://false.com")
+		_, err = s.UpdateSlackWebhookAction(ctx1, wa.ID, true, true, DigestIntervalNone, "https://false.com")
 		require.NoError(t, err)
 
 		// User2 cannot update it
-		_, err = s.UpdateSlackWebhookAction(ctx2, wa.ID, true, true, "https://truer.com")
+		_, err = s.UpdateSlackWebhookAction(ctx2, wa.ID, true, true, DigestIntervalNone, "https://truer.com")
 		require.Error(t, err)
 
 		wa, err = s.GetSlackWebhookAction(ctx1, wa.ID)
 		require.NoError(t, err)
-		require.Equal(t, wa.URL, "https://false.com")
+		gotURL, err := wa.URL.Decrypt(ctx1)
+		require.NoError(t, err)
+		require.Equal(t, gotURL, "https://false.com")
 	})
 }
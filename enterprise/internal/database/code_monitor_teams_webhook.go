@@ -0,0 +1,240 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+)
+
+type TeamsWebhookAction struct {
+	ID      int64
+	Monitor int64
+	Enabled bool
+	// URL is the incoming webhook URL. It is stored encrypted because the URL
+	// itself grants permission to post to the channel.
+	URL            *encryption.Encryptable
+	IncludeResults bool
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+const updateTeamsWebhookActionQuery = `
+UPDATE cm_teams_webhooks
+SET enabled = %s,
+	include_results = %s,
+	url = %s,
+	encryption_key_id = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_teams_webhooks.monitor
+			AND cm_monitors.namespace_user_id = %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateTeamsWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error) {
+	encryptedURL, keyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), url)
+	if err != nil {
+		return nil, err
+	}
+
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateTeamsWebhookActionQuery,
+		enabled,
+		includeResults,
+		encryptedURL,
+		dbutil.NullStringColumn(keyID),
+		a.UID,
+		s.Now(),
+		id,
+		a.UID,
+		sqlf.Join(teamsWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanTeamsWebhookAction(row, s.getEncryptionKey())
+}
+
+const createTeamsWebhookActionQuery = `
+INSERT INTO cm_teams_webhooks
+(monitor, enabled, include_results, url, encryption_key_id, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateTeamsWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error) {
+	encryptedURL, keyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), url)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createTeamsWebhookActionQuery,
+		monitorID,
+		enabled,
+		includeResults,
+		encryptedURL,
+		dbutil.NullStringColumn(keyID),
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(teamsWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanTeamsWebhookAction(row, s.getEncryptionKey())
+}
+
+const deleteTeamsWebhookActionQuery = `
+DELETE FROM cm_teams_webhooks
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteTeamsWebhookActions(ctx context.Context, monitorID int64, webhookIDs ...int64) error {
+	if len(webhookIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(webhookIDs))
+	for _, ids := range webhookIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteTeamsWebhookActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countTeamsWebhookActionsQuery = `
+SELECT COUNT(*)
+FROM cm_teams_webhooks
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountTeamsWebhookActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countTeamsWebhookActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getTeamsWebhookActionQuery = `
+SELECT %s -- TeamsWebhookActionColumns
+FROM cm_teams_webhooks
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetTeamsWebhookAction(ctx context.Context, id int64) (*TeamsWebhookAction, error) {
+	q := sqlf.Sprintf(
+		getTeamsWebhookActionQuery,
+		sqlf.Join(teamsWebhookActionColumns, ","),
+		id,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanTeamsWebhookAction(row, s.getEncryptionKey())
+}
+
+const listTeamsWebhookActionsQuery = `
+SELECT %s -- TeamsWebhookActionColumns
+FROM cm_teams_webhooks
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListTeamsWebhookActions(ctx context.Context, opts ListActionsOpts) ([]*TeamsWebhookAction, error) {
+	q := sqlf.Sprintf(
+		listTeamsWebhookActionsQuery,
+		sqlf.Join(teamsWebhookActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTeamsWebhookActions(rows, s.getEncryptionKey())
+}
+
+// teamsWebhookActionColumns is the set of columns in the cm_teams_webhooks table
+// This must be kept in sync with scanTeamsWebhookAction
+var teamsWebhookActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_teams_webhooks.id"),
+	sqlf.Sprintf("cm_teams_webhooks.monitor"),
+	sqlf.Sprintf("cm_teams_webhooks.enabled"),
+	sqlf.Sprintf("cm_teams_webhooks.url"),
+	sqlf.Sprintf("cm_teams_webhooks.encryption_key_id"),
+	sqlf.Sprintf("cm_teams_webhooks.include_results"),
+	sqlf.Sprintf("cm_teams_webhooks.created_by"),
+	sqlf.Sprintf("cm_teams_webhooks.created_at"),
+	sqlf.Sprintf("cm_teams_webhooks.changed_by"),
+	sqlf.Sprintf("cm_teams_webhooks.changed_at"),
+}
+
+func scanTeamsWebhookActions(rows *sql.Rows, key encryption.Key) ([]*TeamsWebhookAction, error) {
+	var ws []*TeamsWebhookAction
+	for rows.Next() {
+		w, err := scanTeamsWebhookAction(rows, key)
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, rows.Err()
+}
+
+// scanTeamsWebhookAction scans a TeamsWebhookAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with teamsWebhookActionColumns.
+func scanTeamsWebhookAction(scanner dbutil.Scanner, key encryption.Key) (*TeamsWebhookAction, error) {
+	var (
+		w          TeamsWebhookAction
+		url, keyID string
+	)
+	if err := scanner.Scan(
+		&w.ID,
+		&w.Monitor,
+		&w.Enabled,
+		&url,
+		&dbutil.NullString{S: &keyID},
+		&w.IncludeResults,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.ChangedBy,
+		&w.ChangedAt,
+	); err != nil {
+		return &w, err
+	}
+
+	w.URL = newEncryptableWebhookURL(url, keyID, key)
+	return &w, nil
+}
+
+// newEncryptableWebhookURL wraps a webhook URL read from the database, which is
+// only encrypted if keyID is set.
+func newEncryptableWebhookURL(url, keyID string, key encryption.Key) *encryption.Encryptable {
+	if keyID == "" {
+		return encryption.NewUnencrypted(url)
+	}
+	return encryption.NewEncrypted(url, keyID, key)
+}
@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+)
+
+func TestCodeMonitorStoreTeamsWebhooks(t *testing.T) {
+	ctx := context.Background()
+	url1 := "https://icanhazcheezburger.com/teams_webhook"
+	url2 := "https://icanthazcheezburger.com/teams_webhook"
+
+	logger := logtest.Scoped(t)
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		require.NoError(t, err)
+
+		got, err := s.GetTeamsWebhookAction(ctx, action.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, action, got)
+	})
+
+	t.Run("CreateUpdateGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		require.NoError(t, err)
+
+		updated, err := s.UpdateTeamsWebhookAction(ctx, action.ID, false, false, url2)
+		require.NoError(t, err)
+		require.Equal(t, false, updated.Enabled)
+		gotURL, err := updated.URL.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, url2, gotURL)
+
+		got, err := s.GetTeamsWebhookAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, updated, got)
+	})
+
+	t.Run("ErrorOnUpdateNonexistent", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+
+		_, err := s.UpdateTeamsWebhookAction(ctx, 383838, false, false, url2)
+		require.Error(t, err)
+	})
+
+	t.Run("CreateDeleteGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action1, err := s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		require.NoError(t, err)
+
+		action2, err := s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		require.NoError(t, err)
+
+		err = s.DeleteTeamsWebhookActions(ctx, fixtures.monitor.ID, action1.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetTeamsWebhookAction(ctx, action1.ID)
+		require.Error(t, err)
+
+		_, err = s.GetTeamsWebhookAction(ctx, action2.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("CountCreateCount", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		count, err := s.CountTeamsWebhookActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+
+		_, err = s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		require.NoError(t, err)
+
+		count, err = s.CountTeamsWebhookActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("ListCreateList", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		actions, err := s.ListTeamsWebhookActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions, 0)
+
+		_, err = s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		require.NoError(t, err)
+
+		_, err = s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url2)
+		require.NoError(t, err)
+
+		actions2, err := s.ListTeamsWebhookActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions2, 2)
+
+		first := 1
+		actions3, err := s.ListTeamsWebhookActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID, First: &first})
+		require.NoError(t, err)
+		require.Len(t, actions3, 1)
+	})
+
+	t.Run("EncryptedURL", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		s.key = et.TestKey{}
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateTeamsWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		require.NoError(t, err)
+
+		got, err := s.GetTeamsWebhookAction(ctx, action.ID)
+		require.NoError(t, err)
+		gotURL, err := got.URL.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, url1, gotURL)
+
+		// The URL is stored encrypted.
+		var rawURL, keyID string
+		err = db.QueryRowContext(ctx, "SELECT url, encryption_key_id FROM cm_teams_webhooks WHERE id = $1", action.ID).Scan(&rawURL, &keyID)
+		require.NoError(t, err)
+		require.NotEqual(t, url1, rawURL)
+		require.NotEmpty(t, keyID)
+	})
+
+	t.Run("Update permissions", func(t *testing.T) {
+		ctx, db, s := newTestStore(t)
+		uid1 := insertTestUser(ctx, t, db, "u1", false)
+		ctx1 := actor.WithActor(ctx, actor.FromUser(uid1))
+		uid2 := insertTestUser(ctx, t, db, "u2", false)
+		ctx2 := actor.WithActor(ctx, actor.FromUser(uid2))
+		fixtures := s.insertTestMonitor(ctx1, t)
+		_ = s.insertTestMonitor(ctx2, t)
+
+		wa, err := s.CreateTeamsWebhookAction(ctx1, fixtures.monitor.ID, true, true, "https://true.com")
+		require.NoError(t, err)
+
+		// User1 can update it
+		_, err = s.UpdateTeamsWebhookAction(ctx1, wa.ID, true, true, "https://false.com")
+		require.NoError(t, err)
+
+		// User2 cannot update it
+		_, err = s.UpdateTeamsWebhookAction(ctx2, wa.ID, true, true, "https://truer.com")
+		require.Error(t, err)
+
+		wa, err = s.GetTeamsWebhookAction(ctx1, wa.ID)
+		require.NoError(t, err)
+		gotURL, err := wa.URL.Decrypt(ctx1)
+		require.NoError(t, err)
+		require.Equal(t, gotURL, "https://false.com")
+	})
+}
@@ -3,32 +3,63 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type WebhookAction struct {
 	ID             int64
 	Monitor        int64
-	Enabled        bool
-	URL            string
+	Enabled bool
+	// URL is the webhook URL. It is stored encrypted because it commonly
+	// embeds a token that grants permission to post to the endpoint.
+	URL            *encryption.Encryptable
 	IncludeResults bool
 
+	// PayloadTemplate, if set, is the text/template used to render the request
+	// body. If not set, the default JSON payload is sent.
+	PayloadTemplate *string
+	// Headers are additional headers set on every request. It is nil if no
+	// headers are configured.
+	Headers *encryption.JSONEncryptable[map[string]string]
+	// Secret, if set, is used to sign request bodies. It is nil if no secret is
+	// configured.
+	Secret *encryption.Encryptable
+
 	CreatedBy int32
 	CreatedAt time.Time
 	ChangedBy int32
 	ChangedAt time.Time
 }
 
+// WebhookActionArgs are the fields of a webhook action that can be set by
+// users.
+type WebhookActionArgs struct {
+	Enabled         bool
+	IncludeResults  bool
+	URL             string
+	PayloadTemplate *string
+	Headers         map[string]string
+	Secret          *string
+}
+
 const updateWebhookActionQuery = `
 UPDATE cm_webhooks
 SET enabled = %s,
     include_results = %s,
 	url = %s,
+	url_encryption_key_id = %s,
+	payload_template = %s,
+	headers = %s,
+	secret = %s,
+	encryption_key_id = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,13 +72,27 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, args *WebhookActionArgs) (*WebhookAction, error) {
+	url, urlKeyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), args.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting URL")
+	}
+	headers, secret, keyID, err := s.encryptWebhookActionArgs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		updateWebhookActionQuery,
-		enabled,
-		includeResults,
+		args.Enabled,
+		args.IncludeResults,
 		url,
+		dbutil.NullStringColumn(urlKeyID),
+		args.PayloadTemplate,
+		dbutil.NullStringColumn(headers),
+		dbutil.NullStringColumn(secret),
+		dbutil.NullStringColumn(keyID),
 		a.UID,
 		s.Now(),
 		id,
@@ -56,25 +101,39 @@ func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, en
 	)
 
 	row := s.QueryRow(ctx, q)
-	return scanWebhookAction(row)
+	return scanWebhookAction(row, s.getEncryptionKey())
 }
 
 const createWebhookActionQuery = `
 INSERT INTO cm_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, url_encryption_key_id, payload_template, headers, secret, encryption_key_id, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, args *WebhookActionArgs) (*WebhookAction, error) {
+	url, urlKeyID, err := encryption.MaybeEncrypt(ctx, s.getEncryptionKey(), args.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting URL")
+	}
+	headers, secret, keyID, err := s.encryptWebhookActionArgs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		createWebhookActionQuery,
 		monitorID,
-		enabled,
-		includeResults,
+		args.Enabled,
+		args.IncludeResults,
 		url,
+		dbutil.NullStringColumn(urlKeyID),
+		args.PayloadTemplate,
+		dbutil.NullStringColumn(headers),
+		dbutil.NullStringColumn(secret),
+		dbutil.NullStringColumn(keyID),
 		a.UID,
 		now,
 		a.UID,
@@ -83,7 +142,32 @@ func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID in
 	)
 
 	row := s.QueryRow(ctx, q)
-	return scanWebhookAction(row)
+	return scanWebhookAction(row, s.getEncryptionKey())
+}
+
+// encryptWebhookActionArgs encrypts the headers and secret of args. Both are
+// encrypted with the same key, so a single key ID is returned. Unset values
+// are returned as empty strings.
+func (s *codeMonitorStore) encryptWebhookActionArgs(ctx context.Context, args *WebhookActionArgs) (headers, secret, keyID string, err error) {
+	key := s.getEncryptionKey()
+
+	if len(args.Headers) > 0 {
+		raw, err := json.Marshal(args.Headers)
+		if err != nil {
+			return "", "", "", errors.Wrap(err, "marshalling headers")
+		}
+		if headers, keyID, err = encryption.MaybeEncrypt(ctx, key, string(raw)); err != nil {
+			return "", "", "", errors.Wrap(err, "encrypting headers")
+		}
+	}
+
+	if args.Secret != nil && *args.Secret != "" {
+		if secret, keyID, err = encryption.MaybeEncrypt(ctx, key, *args.Secret); err != nil {
+			return "", "", "", errors.Wrap(err, "encrypting secret")
+		}
+	}
+
+	return headers, secret, keyID, nil
 }
 
 const deleteWebhookActionQuery = `
@@ -135,7 +219,7 @@ func (s *codeMonitorStore) GetWebhookAction(ctx context.Context, webhookID int64
 		webhookID,
 	)
 	row := s.QueryRow(ctx, q)
-	return scanWebhookAction(row)
+	return scanWebhookAction(row, s.getEncryptionKey())
 }
 
 const listWebhookActionsQuery = `
@@ -158,7 +242,7 @@ func (s *codeMonitorStore) ListWebhookActions(ctx context.Context, opts ListActi
 		return nil, err
 	}
 	defer rows.Close()
-	return scanWebhookActions(rows)
+	return scanWebhookActions(rows, s.getEncryptionKey())
 }
 
 // webhookActionColumns is the set of columns in the cm_webhooks table
@@ -168,17 +252,22 @@ var webhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_webhooks.monitor"),
 	sqlf.Sprintf("cm_webhooks.enabled"),
 	sqlf.Sprintf("cm_webhooks.url"),
+	sqlf.Sprintf("cm_webhooks.url_encryption_key_id"),
 	sqlf.Sprintf("cm_webhooks.include_results"),
+	sqlf.Sprintf("cm_webhooks.payload_template"),
+	sqlf.Sprintf("cm_webhooks.headers"),
+	sqlf.Sprintf("cm_webhooks.secret"),
+	sqlf.Sprintf("cm_webhooks.encryption_key_id"),
 	sqlf.Sprintf("cm_webhooks.created_by"),
 	sqlf.Sprintf("cm_webhooks.created_at"),
 	sqlf.Sprintf("cm_webhooks.changed_by"),
 	sqlf.Sprintf("cm_webhooks.changed_at"),
 }
 
-func scanWebhookActions(rows *sql.Rows) ([]*WebhookAction, error) {
+func scanWebhookActions(rows *sql.Rows, key encryption.Key) ([]*WebhookAction, error) {
 	var ws []*WebhookAction
 	for rows.Next() {
-		w, err := scanWebhookAction(rows)
+		w, err := scanWebhookAction(rows, key)
 		if err != nil {
 			return nil, err
 		}
@@ -189,18 +278,47 @@ func scanWebhookActions(rows *sql.Rows) ([]*WebhookAction, error) {
 
 // scanWebhookAction scans a WebhookAction from a *sql.Row or *sql.Rows.
 // It must be kept in sync with webhookActionColumns.
-func scanWebhookAction(scanner dbutil.Scanner) (*WebhookAction, error) {
-	var w WebhookAction
-	err := scanner.Scan(
+func scanWebhookAction(scanner dbutil.Scanner, key encryption.Key) (*WebhookAction, error) {
+	var (
+		w               WebhookAction
+		url, urlKeyID   string
+		headers, secret string
+		keyID           string
+	)
+	if err := scanner.Scan(
 		&w.ID,
 		&w.Monitor,
 		&w.Enabled,
-		&w.URL,
+		&url,
+		&dbutil.NullString{S: &urlKeyID},
 		&w.IncludeResults,
+		&w.PayloadTemplate,
+		&dbutil.NullString{S: &headers},
+		&dbutil.NullString{S: &secret},
+		&dbutil.NullString{S: &keyID},
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
 		&w.ChangedAt,
-	)
-	return &w, err
+	); err != nil {
+		return &w, err
+	}
+
+	w.URL = newEncryptableWebhookURL(url, urlKeyID, key)
+	if headers != "" {
+		if keyID == "" {
+			w.Headers = &encryption.JSONEncryptable[map[string]string]{Encryptable: encryption.NewUnencrypted(headers)}
+		} else {
+			w.Headers = encryption.NewEncryptedJSON[map[string]string](headers, keyID, key)
+		}
+	}
+	if secret != "" {
+		if keyID == "" {
+			w.Secret = encryption.NewUnencrypted(secret)
+		} else {
+			w.Secret = encryption.NewEncrypted(secret, keyID, key)
+		}
+	}
+
+	return &w, nil
 }
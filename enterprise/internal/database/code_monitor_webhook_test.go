@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
 )
 
 func TestCodeMonitorStoreWebhooks(t *testing.T) {
@@ -28,7 +29,7 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: false, URL: url1})
 		require.NoError(t, err)
 
 		got, err := s.GetWebhookAction(ctx, action.ID)
@@ -45,13 +46,15 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: false, URL: url1})
 		require.NoError(t, err)
 
-		updated, err := s.UpdateWebhookAction(ctx, action.ID, false, false, url2)
+		updated, err := s.UpdateWebhookAction(ctx, action.ID, &WebhookActionArgs{Enabled: false, IncludeResults: false, URL: url2})
 		require.NoError(t, err)
 		require.Equal(t, false, updated.Enabled)
-		require.Equal(t, url2, updated.URL)
+		gotURL, err := updated.URL.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, url2, gotURL)
 
 		got, err := s.GetWebhookAction(ctx, action.ID)
 		require.NoError(t, err)
@@ -65,7 +68,7 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		_, _, ctx := newTestUser(ctx, t, db)
 		s := CodeMonitors(db)
 
-		_, err := s.UpdateWebhookAction(ctx, 383838, false, false, url2)
+		_, err := s.UpdateWebhookAction(ctx, 383838, &WebhookActionArgs{Enabled: false, IncludeResults: false, URL: url2})
 		require.Error(t, err)
 	})
 
@@ -77,10 +80,10 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action1, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action1, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: false, URL: url1})
 		require.NoError(t, err)
 
-		action2, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action2, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: false, URL: url1})
 		require.NoError(t, err)
 
 		err = s.DeleteWebhookActions(ctx, fixtures.monitor.ID, action1.ID)
@@ -105,7 +108,7 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 0, count)
 
-		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: false, URL: url1})
 		require.NoError(t, err)
 
 		count, err = s.CountWebhookActions(ctx, fixtures.monitor.ID)
@@ -125,10 +128,10 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, actions, 0)
 
-		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: false, URL: url1})
 		require.NoError(t, err)
 
-		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url2)
+		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: false, URL: url2})
 		require.NoError(t, err)
 
 		actions2, err := s.ListWebhookActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
@@ -141,6 +144,60 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		require.Len(t, actions3, 1)
 	})
 
+	t.Run("EncryptedURLHeadersAndSecret", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		s.key = et.TestKey{}
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		tmpl := `{"text": {{ json .Description }}}`
+		secret := "hunter2"
+		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, &WebhookActionArgs{
+			Enabled:         true,
+			URL:             url1,
+			PayloadTemplate: &tmpl,
+			Headers:         map[string]string{"Authorization": "Bearer token"},
+			Secret:          &secret,
+		})
+		require.NoError(t, err)
+
+		got, err := s.GetWebhookAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, &tmpl, got.PayloadTemplate)
+
+		gotURL, err := got.URL.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, url1, gotURL)
+
+		headers, err := got.Headers.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"Authorization": "Bearer token"}, headers)
+
+		gotSecret, err := got.Secret.Decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, secret, gotSecret)
+
+		// The values are stored encrypted.
+		var rawURL, urlKeyID, rawHeaders, rawSecret, keyID string
+		err = db.QueryRowContext(ctx, "SELECT url, url_encryption_key_id, headers, secret, encryption_key_id FROM cm_webhooks WHERE id = $1", action.ID).Scan(&rawURL, &urlKeyID, &rawHeaders, &rawSecret, &keyID)
+		require.NoError(t, err)
+		require.NotEqual(t, url1, rawURL)
+		require.NotEmpty(t, urlKeyID)
+		require.NotEqual(t, secret, rawSecret)
+		require.NotContains(t, rawHeaders, "Bearer token")
+		require.NotEmpty(t, keyID)
+
+		// Updating without headers or secret removes them.
+		updated, err := s.UpdateWebhookAction(ctx, action.ID, &WebhookActionArgs{Enabled: true, URL: url1})
+		require.NoError(t, err)
+		require.Nil(t, updated.PayloadTemplate)
+		require.Nil(t, updated.Headers)
+		require.Nil(t, updated.Secret)
+	})
+
 	t.Run("Update permissions", func(t *testing.T) {
 		ctx, db, s := newTestStore(t)
 		uid1 := insertTestUser(ctx, t, db, "u1", false)
@@ -150,19 +207,21 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		fixtures := s.insertTestMonitor(ctx1, t)
 		_ = s.insertTestMonitor(ctx2, t)
 
-		wa, err := s.CreateWebhookAction(ctx1, fixtures.monitor.ID, true, true, "https://true.com")
+		wa, err := s.CreateWebhookAction(ctx1, fixtures.monitor.ID, &WebhookActionArgs{Enabled: true, IncludeResults: true, URL: "https://true.com"})
 		require.NoError(t, err)
 
 		// User1 can update it
-		_, err = s.UpdateWebhookAction(ctx1, wa.ID, true, true, "https://false.com")
+		_, err = s.UpdateWebhookAction(ctx1, wa.ID, &WebhookActionArgs{Enabled: true, IncludeResults: true, URL: "https://false.com"})
 		require.NoError(t, err)
 
 		// User2 cannot update it
-		_, err = s.UpdateWebhookAction(ctx2, wa.ID, true, true, "https://truer.com")
+		_, err = s.UpdateWebhookAction(ctx2, wa.ID, &WebhookActionArgs{Enabled: true, IncludeResults: true, URL: "https://truer.com"})
 		require.Error(t, err)
 
 		wa, err = s.GetWebhookAction(ctx1, wa.ID)
 		require.NoError(t, err)
-		require.Equal(t, wa.URL, "https://false.com")
+		gotURL, err := wa.URL.Decrypt(ctx1)
+		require.NoError(t, err)
+		require.Equal(t, gotURL, "https://false.com")
 	})
 }
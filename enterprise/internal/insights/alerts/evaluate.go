@@ -0,0 +1,193 @@
+// Package alerts evaluates the threshold alerts of insight series after new snapshots are
+// recorded, and notifies the owners of alerts that start breaching.
+package alerts
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Breach describes the point of a series that breached the threshold of an alert.
+type Breach struct {
+	Alert types.InsightAlert
+	// Capture is the capture group value of the breaching line, for series generated from
+	// capture groups.
+	Capture *string
+	Time    time.Time
+	Value   float64
+	// Previous is the value of the point before the breaching one, if there is one.
+	Previous *float64
+}
+
+// Evaluate returns the latest point of points that breaches the threshold of alert. Every capture
+// group value of a series is its own line and is evaluated independently, the first breaching line
+// in capture order is returned.
+func Evaluate(alert types.InsightAlert, points []store.SeriesPoint) (Breach, bool) {
+	lines := make(map[string][]store.SeriesPoint)
+	for _, p := range points {
+		capture := ""
+		if p.Capture != nil {
+			capture = *p.Capture
+		}
+		lines[capture] = append(lines[capture], p)
+	}
+	captures := make([]string, 0, len(lines))
+	for capture := range lines {
+		captures = append(captures, capture)
+	}
+	sort.Strings(captures)
+
+	for _, capture := range captures {
+		line := lines[capture]
+		sort.Slice(line, func(i, j int) bool { return line[i].Time.Before(line[j].Time) })
+
+		latest := line[len(line)-1]
+		breach := Breach{Alert: alert, Capture: latest.Capture, Time: latest.Time, Value: latest.Value}
+		if len(line) > 1 {
+			previous := line[len(line)-2].Value
+			breach.Previous = &previous
+		}
+		if breaches(alert, breach) {
+			return breach, true
+		}
+	}
+	return Breach{}, false
+}
+
+func breaches(alert types.InsightAlert, b Breach) bool {
+	switch alert.Condition {
+	case types.AlertAbove:
+		return b.Value > alert.Threshold
+	case types.AlertBelow:
+		return b.Value < alert.Threshold
+	case types.AlertIncreasePercent:
+		// An increase from zero has no meaningful percentage, so it never breaches.
+		if b.Previous == nil || *b.Previous <= 0 {
+			return false
+		}
+		return (b.Value-*b.Previous) / *b.Previous * 100 >= alert.Threshold
+	}
+	return false
+}
+
+// AlertStore is the subset of store.InsightStore used by the Evaluator.
+type AlertStore interface {
+	GetAll(ctx context.Context, args store.InsightQueryArgs) ([]types.InsightViewSeries, error)
+	GetAlerts(ctx context.Context, args store.GetAlertsArgs) ([]types.InsightAlert, error)
+	SetAlertBreached(ctx context.Context, id int, breached bool, triggeredAt *time.Time) error
+}
+
+// Evaluator evaluates the alerts of a series after a snapshot was recorded.
+type Evaluator struct {
+	db         database.DB
+	alertStore AlertStore
+	// seriesStore must enforce repository permissions from the context, see store.SeriesPoints.
+	seriesStore store.Interface
+	notifier    Notifier
+	logger      log.Logger
+	now         func() time.Time
+}
+
+func NewEvaluator(db database.DB, alertStore AlertStore, seriesStore store.Interface, notifier Notifier, logger log.Logger) *Evaluator {
+	return &Evaluator{
+		db:          db,
+		alertStore:  alertStore,
+		seriesStore: seriesStore,
+		notifier:    notifier,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// EvaluateSeries evaluates the enabled alerts of series and notifies the owners of the alerts that
+// started breaching. Alerts only notify again once they stopped breaching in between, so that a
+// series that stays above a threshold doesn't notify on every snapshot.
+func (e *Evaluator) EvaluateSeries(ctx context.Context, series types.InsightSeries) error {
+	alerts, err := e.alertStore.GetAlerts(ctx, store.GetAlertsArgs{SeriesID: series.SeriesID, EnabledOnly: true})
+	if err != nil {
+		return errors.Wrap(err, "GetAlerts")
+	}
+
+	var errs error
+	for _, alert := range alerts {
+		if err := e.evaluateAlert(ctx, series, alert); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "alert %d", alert.ID))
+		}
+	}
+	return errs
+}
+
+func (e *Evaluator) evaluateAlert(ctx context.Context, series types.InsightSeries, alert types.InsightAlert) error {
+	// 🚨 SECURITY: The series is loaded with the permissions of the owner of the alert, so that
+	// notifications don't reveal data from repositories the owner cannot see. 🚨
+	userCtx := actor.WithActor(ctx, actor.FromUser(alert.UserID))
+
+	opts := store.SeriesPointsOpts{SeriesID: &series.SeriesID}
+	if series.GenerationMethod == types.NumericCompute && series.Aggregation != nil {
+		opts.Aggregation = types.NumericAggregation(*series.Aggregation)
+	}
+	points, err := e.seriesStore.SeriesPoints(userCtx, opts)
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+
+	breach, breached := Evaluate(alert, points)
+	var triggeredAt *time.Time
+	if breached && !alert.Breached {
+		// 🚨 SECURITY: The owner may have lost access to the view since they created the alert,
+		// in which case the alert stays silent until they can see the view again. 🚨
+		canSee, err := e.ownerCanSeeView(ctx, alert)
+		if err != nil {
+			return errors.Wrap(err, "checking view access")
+		}
+		if !canSee {
+			e.logger.Debug("skipping insight alert of a user who cannot see its view", log.Int("alertID", alert.ID), log.Int32("userID", alert.UserID))
+			return nil
+		}
+		if err := e.notifier.Notify(ctx, breach); err != nil {
+			// Leave the alert as not breached so that the next snapshot tries again.
+			return errors.Wrap(err, "Notify")
+		}
+		now := e.now()
+		triggeredAt = &now
+		e.logger.Debug("insight alert triggered", log.Int("alertID", alert.ID), log.String("seriesID", series.SeriesID))
+	}
+	if breached == alert.Breached && triggeredAt == nil {
+		return nil
+	}
+	return e.alertStore.SetAlertBreached(ctx, alert.ID, breached, triggeredAt)
+}
+
+// ownerCanSeeView returns whether the owner of alert still exists and can see the view of the alert.
+func (e *Evaluator) ownerCanSeeView(ctx context.Context, alert types.InsightAlert) (bool, error) {
+	if _, err := e.db.Users().GetByID(ctx, alert.UserID); err != nil {
+		if errcode.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	orgs, err := e.db.Orgs().GetByUserID(ctx, alert.UserID)
+	if err != nil {
+		return false, err
+	}
+	orgIDs := make([]int, 0, len(orgs))
+	for _, org := range orgs {
+		orgIDs = append(orgIDs, int(org.ID))
+	}
+
+	views, err := e.alertStore.GetAll(ctx, store.InsightQueryArgs{UniqueID: alert.InsightViewUniqueID, UserID: []int{int(alert.UserID)}, OrgID: orgIDs})
+	if err != nil {
+		return false, errors.Wrap(err, "GetAll")
+	}
+	return len(views) > 0, nil
+}
@@ -0,0 +1,208 @@
+package alerts
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	itypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var t0 = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func points(values ...float64) []store.SeriesPoint {
+	points := make([]store.SeriesPoint, 0, len(values))
+	for i, v := range values {
+		points = append(points, store.SeriesPoint{SeriesID: "s1", Time: t0.Add(time.Duration(i) * time.Hour), Value: v})
+	}
+	return points
+}
+
+func TestEvaluate(t *testing.T) {
+	capture := func(c string, offset int, v float64) store.SeriesPoint {
+		return store.SeriesPoint{SeriesID: "s1", Time: t0.Add(time.Duration(offset) * time.Hour), Value: v, Capture: &c}
+	}
+
+	testCases := []struct {
+		name      string
+		condition types.AlertCondition
+		threshold float64
+		points    []store.SeriesPoint
+		want      autogold.Value
+	}{
+		{"above breached", types.AlertAbove, 10, points(5, 11), autogold.Want("above breached", "breach value=11 previous=5")},
+		{"above not breached", types.AlertAbove, 10, points(11, 10), autogold.Want("above not breached", "no breach")},
+		{"above uses latest point", types.AlertAbove, 10, []store.SeriesPoint{points(20, 5)[1], points(20, 5)[0]}, autogold.Want("above uses latest point", "no breach")},
+		{"below breached", types.AlertBelow, 10, points(9), autogold.Want("below breached", "breach value=9 previous=<nil>")},
+		{"increase breached", types.AlertIncreasePercent, 50, points(10, 15), autogold.Want("increase breached", "breach value=15 previous=10")},
+		{"increase not breached", types.AlertIncreasePercent, 50, points(10, 14), autogold.Want("increase not breached", "no breach")},
+		{"increase from zero", types.AlertIncreasePercent, 50, points(0, 100), autogold.Want("increase from zero", "no breach")},
+		{"increase single point", types.AlertIncreasePercent, 50, points(100), autogold.Want("increase single point", "no breach")},
+		{"no points", types.AlertAbove, 10, nil, autogold.Want("no points", "no breach")},
+		{
+			"captures evaluated independently", types.AlertAbove, 10,
+			[]store.SeriesPoint{capture("a", 0, 20), capture("a", 1, 5), capture("b", 0, 1), capture("b", 1, 12)},
+			autogold.Want("captures evaluated independently", "breach capture=b value=12 previous=1"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, ok := Evaluate(types.InsightAlert{Condition: tc.condition, Threshold: tc.threshold}, tc.points)
+			got := "no breach"
+			if ok {
+				got = "breach"
+				if b.Capture != nil {
+					got += " capture=" + *b.Capture
+				}
+				got += " value=" + formatFloat(b.Value) + " previous="
+				if b.Previous != nil {
+					got += formatFloat(*b.Previous)
+				} else {
+					got += "<nil>"
+				}
+			}
+			tc.want.Equal(t, got)
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	previous := 10.0
+	capture := "go"
+	alert := types.InsightAlert{SeriesID: "s1", SeriesLabel: "TODOs", Threshold: 50}
+
+	alert.Condition = types.AlertAbove
+	autogold.Want("above", "TODOs is 15, above the threshold of 50.").Equal(t, describe(Breach{Alert: alert, Value: 15}))
+	alert.Condition = types.AlertBelow
+	autogold.Want("below with capture", "TODOs (go) is 15, below the threshold of 50.").Equal(t, describe(Breach{Alert: alert, Value: 15, Capture: &capture}))
+	alert.Condition = types.AlertIncreasePercent
+	alert.SeriesLabel = ""
+	autogold.Want("increase without label", "s1 increased from 10 to 15.5, by more than 50%.").Equal(t, describe(Breach{Alert: alert, Value: 15.5, Previous: &previous}))
+}
+
+func TestNewNotificationData(t *testing.T) {
+	externalURL, _ := url.Parse("https://sourcegraph.example.com")
+	data := newNotificationData(Breach{
+		Alert: types.InsightAlert{
+			SeriesID:            "s1",
+			SeriesLabel:         "TODOs",
+			Condition:           types.AlertAbove,
+			Threshold:           10,
+			InsightViewUniqueID: "view1",
+			InsightViewTitle:    "My insight",
+		},
+		Time:  t0,
+		Value: 11,
+	}, externalURL)
+	autogold.Want("insight URL", "https://sourcegraph.example.com/insights/insight/aW5zaWdodF92aWV3OiJ2aWV3MSI=?utm_source=code-insights-alert").Equal(t, data.InsightURL)
+	autogold.Want("description", "TODOs is 11, above the threshold of 10.").Equal(t, data.Description)
+}
+
+type fakeAlertStore struct {
+	alerts  []types.InsightAlert
+	views   []types.InsightViewSeries
+	updates []string
+}
+
+func (s *fakeAlertStore) GetAll(_ context.Context, args store.InsightQueryArgs) ([]types.InsightViewSeries, error) {
+	return s.views, nil
+}
+
+func (s *fakeAlertStore) GetAlerts(_ context.Context, args store.GetAlertsArgs) ([]types.InsightAlert, error) {
+	return s.alerts, nil
+}
+
+func (s *fakeAlertStore) SetAlertBreached(_ context.Context, id int, breached bool, triggeredAt *time.Time) error {
+	update := "breached=false"
+	if breached {
+		update = "breached=true"
+	}
+	if triggeredAt != nil {
+		update += " triggered"
+	}
+	s.updates = append(s.updates, update)
+	return nil
+}
+
+type fakeSeriesStore struct {
+	store.Interface
+	points []store.SeriesPoint
+}
+
+func (s *fakeSeriesStore) SeriesPoints(context.Context, store.SeriesPointsOpts) ([]store.SeriesPoint, error) {
+	return s.points, nil
+}
+
+type fakeNotifier struct {
+	err      error
+	notified int
+}
+
+func (n *fakeNotifier) Notify(context.Context, Breach) error {
+	n.notified++
+	return n.err
+}
+
+func TestEvaluateSeries(t *testing.T) {
+	ctx := context.Background()
+	series := types.InsightSeries{SeriesID: "s1"}
+
+	testCases := []struct {
+		name         string
+		breached     bool
+		points       []store.SeriesPoint
+		notifyErr    error
+		ownerDeleted bool
+		viewHidden   bool
+		wantNotified int
+		wantUpdates  autogold.Value
+	}{
+		{"starts breaching", false, points(20), nil, false, false, 1, autogold.Want("starts breaching", []string{"breached=true triggered"})},
+		{"keeps breaching", true, points(20), nil, false, false, 0, autogold.Want("keeps breaching", []string(nil))},
+		{"stops breaching", true, points(5), nil, false, false, 0, autogold.Want("stops breaching", []string{"breached=false"})},
+		{"not breaching", false, points(5), nil, false, false, 0, autogold.Want("not breaching", []string(nil))},
+		{"notify fails", false, points(20), errors.New("boom"), false, false, 1, autogold.Want("notify fails", []string(nil))},
+		{"owner lost access to the view", false, points(20), nil, false, true, 0, autogold.Want("owner lost access to the view", []string(nil))},
+		{"owner deleted", false, points(20), nil, true, false, 0, autogold.Want("owner deleted", []string(nil))},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := database.NewMockUserStore()
+			users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*itypes.User, error) {
+				if tc.ownerDeleted {
+					return nil, database.NewUserNotFoundError(id)
+				}
+				return &itypes.User{ID: id}, nil
+			})
+			orgs := database.NewMockOrgStore()
+			orgs.GetByUserIDFunc.SetDefaultReturn([]*itypes.Org{{ID: 2}}, nil)
+			db := database.NewMockDB()
+			db.UsersFunc.SetDefaultReturn(users)
+			db.OrgsFunc.SetDefaultReturn(orgs)
+
+			alertStore := &fakeAlertStore{alerts: []types.InsightAlert{{ID: 1, InsightViewUniqueID: "view1", SeriesID: "s1", Condition: types.AlertAbove, Threshold: 10, UserID: 1, Breached: tc.breached}}}
+			if !tc.viewHidden {
+				alertStore.views = []types.InsightViewSeries{{UniqueID: "view1", SeriesID: "s1"}}
+			}
+			notifier := &fakeNotifier{err: tc.notifyErr}
+			evaluator := NewEvaluator(db, alertStore, &fakeSeriesStore{points: tc.points}, notifier, logtest.Scoped(t))
+
+			err := evaluator.EvaluateSeries(ctx, series)
+			if (err != nil) != (tc.notifyErr != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if notifier.notified != tc.wantNotified {
+				t.Errorf("expected %d notifications, got %d", tc.wantNotified, notifier.notified)
+			}
+			tc.wantUpdates.Equal(t, alertStore.updates)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/slack-go/slack"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// To avoid a circular dependency with the insights resolvers package we have to redeclare the
+// kind of insight view IDs.
+const insightViewKind = "insight_view"
+
+const emailSource = "code-insights-alert"
+
+// Notifier sends the notifications of a breached alert.
+type Notifier interface {
+	Notify(ctx context.Context, breach Breach) error
+}
+
+type notifier struct {
+	db   database.DB
+	doer httpcli.Doer
+}
+
+// NewNotifier returns a Notifier that notifies through the same email, Slack and webhook actions
+// as code monitors.
+func NewNotifier(db database.DB) Notifier {
+	return &notifier{db: db, doer: httpcli.ExternalDoer}
+}
+
+func (n *notifier) Notify(ctx context.Context, breach Breach) error {
+	externalURL, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return errors.Wrap(err, "parsing external URL")
+	}
+	data := newNotificationData(breach, externalURL)
+
+	// Every configured action is attempted, even if an earlier one fails.
+	var errs error
+	if breach.Alert.EmailEnabled {
+		if err := cmbackground.SendEmail(ctx, n.db, breach.Alert.UserID, emailSource, emailTemplates, data); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "email"))
+		}
+	}
+	if breach.Alert.SlackWebhookURL != nil {
+		if u, err := breach.Alert.SlackWebhookURL.Decrypt(ctx); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "decrypting Slack webhook URL"))
+		} else if err := cmbackground.PostSlackWebhook(ctx, n.doer, u, slackMessage(data)); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "Slack webhook"))
+		}
+	}
+	if breach.Alert.WebhookURL != nil {
+		if u, err := breach.Alert.WebhookURL.Decrypt(ctx); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "decrypting webhook URL"))
+		} else if err := cmbackground.PostWebhook(ctx, n.doer, u, data, cmbackground.WebhookOptions{}); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "webhook"))
+		}
+	}
+	return errs
+}
+
+// notificationData is the data of every notification: it is rendered in emails and Slack
+// messages, and sent as is to webhooks.
+type notificationData struct {
+	InsightTitle string    `json:"insightTitle"`
+	InsightURL   string    `json:"insightURL"`
+	SeriesID     string    `json:"seriesID"`
+	SeriesLabel  string    `json:"seriesLabel"`
+	Capture      *string   `json:"capture,omitempty"`
+	Condition    string    `json:"condition"`
+	Threshold    float64   `json:"threshold"`
+	Time         time.Time `json:"time"`
+	Value        float64   `json:"value"`
+	Previous     *float64  `json:"previousValue,omitempty"`
+
+	// Description is a human readable sentence describing the breach.
+	Description string `json:"description"`
+}
+
+func newNotificationData(b Breach, externalURL *url.URL) notificationData {
+	insightURL := externalURL.ResolveReference(&url.URL{Path: "insights/insight/" + string(relay.MarshalID(insightViewKind, b.Alert.InsightViewUniqueID))})
+	q := insightURL.Query()
+	q.Set("utm_source", emailSource)
+	insightURL.RawQuery = q.Encode()
+
+	return notificationData{
+		InsightTitle: b.Alert.InsightViewTitle,
+		InsightURL:   insightURL.String(),
+		SeriesID:     b.Alert.SeriesID,
+		SeriesLabel:  b.Alert.SeriesLabel,
+		Capture:      b.Capture,
+		Condition:    string(b.Alert.Condition),
+		Threshold:    b.Alert.Threshold,
+		Time:         b.Time,
+		Value:        b.Value,
+		Previous:     b.Previous,
+		Description:  describe(b),
+	}
+}
+
+func describe(b Breach) string {
+	series := b.Alert.SeriesLabel
+	if series == "" {
+		series = b.Alert.SeriesID
+	}
+	if b.Capture != nil {
+		series = fmt.Sprintf("%s (%s)", series, *b.Capture)
+	}
+	value := formatFloat(b.Value)
+	threshold := formatFloat(b.Alert.Threshold)
+
+	switch b.Alert.Condition {
+	case types.AlertAbove:
+		return fmt.Sprintf("%s is %s, above the threshold of %s.", series, value, threshold)
+	case types.AlertBelow:
+		return fmt.Sprintf("%s is %s, below the threshold of %s.", series, value, threshold)
+	case types.AlertIncreasePercent:
+		if b.Previous != nil {
+			return fmt.Sprintf("%s increased from %s to %s, by more than %s%%.", series, formatFloat(*b.Previous), value, threshold)
+		}
+	}
+	return fmt.Sprintf("%s is %s.", series, value)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+var emailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight alert: {{.InsightTitle}}`,
+	Text: `
+{{.Description}}
+
+Your Sourcegraph code insight, {{.InsightTitle}}, breached one of its alerts when it was last updated.
+
+View insight: {{.InsightURL}}
+
+__
+You are receiving this notification because you created an alert on this code insight.
+`,
+	HTML: `
+<p>{{.Description}}</p>
+
+<p>Your Sourcegraph code insight, <strong>{{.InsightTitle}}</strong>, breached one of its alerts when it was last updated.</p>
+
+<p><a href="{{.InsightURL}}">View insight</a></p>
+
+<p style="color:#5e6e8c">You are receiving this notification because you created an alert on this code insight.</p>
+`,
+})
+
+func slackMessage(data notificationData) *slack.WebhookMessage {
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: []slack.Block{
+		slack.NewSectionBlock(
+			slack.NewTextBlockObject("mrkdwn",
+				fmt.Sprintf("Sourcegraph code insight <%s|%s> breached an alert: %s", data.InsightURL, data.InsightTitle, data.Description),
+				false,
+				false,
+			),
+			nil,
+			nil,
+		),
+	}}}
+}
@@ -24,7 +24,7 @@ func stringifyStreamingError(messages []string, streamingType types.GenerationMe
 	if terminal {
 		retryable = " terminal"
 	}
-	if streamingType == types.SearchCompute {
+	if streamingType == types.SearchCompute || streamingType == types.NumericCompute {
 		return fmt.Sprintf("compute streaming search:%s errors: %v", retryable, messages)
 	}
 	return fmt.Sprintf("streaming search:%s errors: %v", retryable, messages)
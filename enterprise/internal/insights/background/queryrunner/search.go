@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
@@ -59,6 +60,7 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 	return map[types.GenerationMethod]InsightsHandler{
 		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
 		types.SearchCompute:  makeComputeHandler(computeSearchStream),
+		types.NumericCompute: makeNumericComputeHandler(computeSearchStream),
 		types.Search:         makeSearchHandler(searchStream),
 	}
 
@@ -132,6 +134,59 @@ func generateComputeRecordingsStream(ctx context.Context, job *SearchJob, record
 	return recordings, nil
 }
 
+// generateNumericRecordingsStream records a single point per repository whose value is the aggregation
+// of every number captured by the job's query in that repository. For averages, the point holds the sum
+// of the numbers along with how many there are.
+func generateNumericRecordingsStream(ctx context.Context, job *SearchJob, aggregation types.NumericAggregation, recordTime time.Time, provider streamComputeProvider, logger log.Logger) ([]store.RecordSeriesPointArgs, error) {
+	streamResults, err := provider(ctx, job.SearchQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(streamResults.SkippedReasons) > 0 {
+		logger.Error("numeric compute search encountered skipped events", log.String("seriesID", job.SeriesID), log.String("reasons", fmt.Sprintf("%v", streamResults.SkippedReasons)), log.String("query", job.SearchQuery))
+	}
+	if len(streamResults.Errors) > 0 {
+		return nil, classifiedError(streamResults.Errors, types.NumericCompute)
+	}
+	if len(streamResults.Alerts) > 0 {
+		return nil, errors.Errorf("compute streaming search: alerts: %v", streamResults.Alerts)
+	}
+
+	checker := authz.DefaultSubRepoPermsChecker
+	var recordings []store.RecordSeriesPointArgs
+
+	for _, match := range streamResults.RepoCounts {
+		subRepoEnabled, subRepoErr := authz.SubRepoEnabledForRepoID(ctx, checker, api.RepoID(match.RepositoryID))
+		if subRepoErr != nil {
+			logger.Error("sub-repo permissions check errored", log.String("seriesID", job.SeriesID), log.String("repo", match.RepositoryName), log.Error(subRepoErr))
+			continue
+		}
+		if subRepoEnabled {
+			continue
+		}
+
+		summary := query.SummarizeNumericValues(match.ValueCounts)
+		if summary.Count == 0 {
+			// None of the captured values in this repository are numbers.
+			continue
+		}
+		if aggregation != types.AverageAggregation {
+			recordings = append(recordings, toRecording(job, summary.Value(aggregation), recordTime, match.RepositoryName, api.RepoID(match.RepositoryID), nil)...)
+			continue
+		}
+
+		// Averages are recorded as the sum and number of values, so that they can be averaged
+		// over all values across repositories rather than over the averages of each repository.
+		count := summary.Count
+		for _, recording := range toRecording(job, summary.Sum, recordTime, match.RepositoryName, api.RepoID(match.RepositoryID), nil) {
+			recording.ValueCount = &count
+			recordings = append(recordings, recording)
+		}
+	}
+
+	return recordings, nil
+}
+
 func generateSearchRecordingsStream(ctx context.Context, job *SearchJob, recordTime time.Time, provider streamSearchProvider, logger log.Logger) ([]store.RecordSeriesPointArgs, error) {
 	tabulationResult, err := provider(ctx, job.SearchQuery)
 	if err != nil {
@@ -205,6 +260,20 @@ func makeMappingComputeHandler(provider streamComputeProvider) InsightsHandler {
 	}
 }
 
+func makeNumericComputeHandler(provider streamComputeProvider) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		aggregation := types.SumAggregation
+		if series.Aggregation != nil {
+			aggregation = types.NumericAggregation(*series.Aggregation)
+		}
+		recordings, err := generateNumericRecordingsStream(ctx, job, aggregation, recordTime, provider, log.Scoped("NumericComputeRecordingsGenerator", ""))
+		if err != nil {
+			return nil, errors.Wrapf(err, "numericComputeHandler")
+		}
+		return recordings, nil
+	}
+}
+
 func (r *workHandler) persistRecordings(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordings []store.RecordSeriesPointArgs, recordTime time.Time) (err error) {
 	tx, err := r.insightsStore.Transact(ctx)
 	if err != nil {
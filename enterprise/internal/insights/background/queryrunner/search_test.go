@@ -363,6 +363,67 @@ func TestGenerateComputeRecordingsStream(t *testing.T) {
 	})
 }
 
+func TestGenerateNumericRecordingsStream(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	job := SearchJob{
+		SeriesID:    "testseries1",
+		SearchQuery: "timeout_ms: (\\d+)",
+		RecordTime:  &date,
+		PersistMode: "record",
+	}
+
+	mocked := func(context.Context, string) (*streaming.ComputeTabulationResult, error) {
+		return &streaming.ComputeTabulationResult{
+			RepoCounts: map[string]*streaming.ComputeMatch{
+				"github.com/sourcegraph/sourcegraph": {
+					RepositoryID:   11,
+					RepositoryName: "github.com/sourcegraph/sourcegraph",
+					ValueCounts: map[string]int{
+						"100":  2,
+						"250":  1,
+						"soon": 4,
+					},
+				},
+				"github.com/sourcegraph/handbook": {
+					RepositoryID:   12,
+					RepositoryName: "github.com/sourcegraph/handbook",
+					ValueCounts: map[string]int{
+						"not a number": 1,
+					},
+				},
+			},
+		}, nil
+	}
+
+	for _, tc := range []struct {
+		aggregation    types.NumericAggregation
+		want           autogold.Value
+		wantValueCount int // 0 if no value count is recorded
+	}{
+		{types.SumAggregation, autogold.Want("sum", []string{"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC  450.000000"}), 0},
+		{types.MaxAggregation, autogold.Want("max", []string{"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC  250.000000"}), 0},
+		// Averages are recorded as the sum of the values and their number.
+		{types.AverageAggregation, autogold.Want("average", []string{"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC  450.000000"}), 3},
+	} {
+		t.Run(string(tc.aggregation), func(t *testing.T) {
+			recordings, err := generateNumericRecordingsStream(context.Background(), &job, tc.aggregation, date, mocked, logtest.Scoped(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.want.Equal(t, stringify(recordings))
+			for _, recording := range recordings {
+				valueCount := 0
+				if recording.ValueCount != nil {
+					valueCount = *recording.ValueCount
+				}
+				if valueCount != tc.wantValueCount {
+					t.Errorf("unexpected value count. want=%d have=%d", tc.wantValueCount, valueCount)
+				}
+			}
+		})
+	}
+}
+
 func TestGenerateSearchRecordingsStream(t *testing.T) {
 	t.Run("search stream job with no dependencies", func(t *testing.T) {
 		date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
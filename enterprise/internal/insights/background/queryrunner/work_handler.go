@@ -37,6 +37,15 @@ type workHandler struct {
 	seriesCache map[string]*types.InsightSeries
 
 	searchHandlers map[types.GenerationMethod]InsightsHandler
+
+	// alertEvaluator evaluates the alerts of a series after a snapshot is recorded. It is
+	// optional.
+	alertEvaluator AlertEvaluator
+}
+
+// AlertEvaluator evaluates the threshold alerts of a series, see alerts.Evaluator.
+type AlertEvaluator interface {
+	EvaluateSeries(ctx context.Context, series types.InsightSeries) error
 }
 
 type InsightsHandler func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error)
@@ -149,7 +158,19 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 		return err
 	}
 
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime); err != nil {
+		return err
+	}
+
+	// Snapshots are the most recent points of a series, so this is when alerts can start or stop
+	// breaching. Failing to evaluate alerts must not fail the job, which would record the snapshot
+	// again.
+	if r.alertEvaluator != nil && store.PersistMode(job.PersistMode) == store.SnapshotMode {
+		if err := r.alertEvaluator.EvaluateSeries(ctx, *series); err != nil {
+			logger.Error("failed to evaluate insight alerts", log.String("seriesID", series.SeriesID), log.Error(err))
+		}
+	}
+	return nil
 }
 
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
@@ -0,0 +1,283 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExportHandler streams the full recorded history of every series of an insight, broken down by
+// repository and capture group value, as CSV or OpenMetrics text.
+//
+// The insight is identified by the GraphQL ID of its view in the {id} route variable, and the output
+// format is chosen with the format query parameter: "csv" (the default) or "openmetrics".
+type ExportHandler struct {
+	logger          log.Logger
+	db              database.DB
+	insightStore    *store.InsightStore
+	timeseriesStore *store.Store
+}
+
+// NewExportHandler creates a new ExportHandler.
+func NewExportHandler(db database.DB, insightsDB edb.InsightsDB) *ExportHandler {
+	return &ExportHandler{
+		logger:          log.Scoped("ExportHandler", "code insights series export REST API handler"),
+		db:              db,
+		insightStore:    store.NewInsightStore(insightsDB),
+		timeseriesStore: store.New(insightsDB, store.NewInsightPermissionStore(db)),
+	}
+}
+
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format, err := parseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	insight, statusCode, err := h.getInsight(r)
+	if err != nil {
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.contentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", insight.UniqueID+format.extension()))
+	w.WriteHeader(http.StatusOK)
+
+	// Once we start writing the body we can no longer report an error with the status code, so errors
+	// from here on only end up in the logs and as a truncated response.
+	if err := h.export(r.Context(), w, format, insight); err != nil {
+		h.logger.Error("failed to export insight", log.String("insightViewID", insight.UniqueID), log.Error(err))
+	}
+}
+
+// getInsight returns the insight requested by r, if the current user is allowed to view it.
+func (h *ExportHandler) getInsight(r *http.Request) (_ types.Insight, statusCode int, err error) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Only authenticated users can export insights. Which insights they can see is
+	// checked below, and which repositories they can see by the timeseries store. 🚨
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return types.Insight{}, http.StatusUnauthorized, errors.New("authentication required")
+	}
+
+	var insightViewID string
+	if err := relay.UnmarshalSpec(graphql.ID(mux.Vars(r)["id"]), &insightViewID); err != nil {
+		return types.Insight{}, http.StatusBadRequest, errors.Wrap(err, "invalid insight view id")
+	}
+
+	orgs, err := h.db.Orgs().GetByUserID(ctx, a.UID)
+	if err != nil {
+		return types.Insight{}, http.StatusInternalServerError, errors.Wrap(err, "GetByUserID")
+	}
+	orgIDs := make([]int, 0, len(orgs))
+	for _, org := range orgs {
+		orgIDs = append(orgIDs, int(org.ID))
+	}
+
+	insights, err := h.insightStore.GetAllMapped(ctx, store.InsightQueryArgs{
+		UniqueID: insightViewID,
+		UserID:   []int{int(a.UID)},
+		OrgID:    orgIDs,
+	})
+	if err != nil {
+		return types.Insight{}, http.StatusInternalServerError, errors.Wrap(err, "GetAllMapped")
+	}
+	if len(insights) == 0 {
+		// Insights the user can't see are reported as missing so we don't leak their existence.
+		return types.Insight{}, http.StatusNotFound, errors.New("insight not found")
+	}
+	return insights[0], http.StatusOK, nil
+}
+
+func (h *ExportHandler) export(ctx context.Context, w io.Writer, format exportFormat, insight types.Insight) error {
+	ew := format.newWriter(w, insight)
+	for _, series := range insight.Series {
+		series := series
+		err := h.timeseriesStore.ExportSeriesPoints(ctx, store.SeriesPointsOpts{SeriesID: &series.SeriesID}, func(point store.ExportPoint) error {
+			return ew.Write(series, point)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "exporting series %s", series.SeriesID)
+		}
+	}
+	return ew.Close()
+}
+
+type exportFormat string
+
+const (
+	csvFormat         exportFormat = "csv"
+	openMetricsFormat exportFormat = "openmetrics"
+)
+
+func parseExportFormat(value string) (exportFormat, error) {
+	switch exportFormat(strings.ToLower(value)) {
+	case "", csvFormat:
+		return csvFormat, nil
+	case openMetricsFormat:
+		return openMetricsFormat, nil
+	default:
+		return "", errors.Errorf("unsupported export format %q, expected %q or %q", value, csvFormat, openMetricsFormat)
+	}
+}
+
+func (f exportFormat) contentType() string {
+	if f == openMetricsFormat {
+		return "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func (f exportFormat) extension() string {
+	if f == openMetricsFormat {
+		return ".txt"
+	}
+	return ".csv"
+}
+
+func (f exportFormat) newWriter(w io.Writer, insight types.Insight) exportWriter {
+	if f == openMetricsFormat {
+		return newOpenMetricsWriter(w, insight)
+	}
+	return newCSVWriter(w)
+}
+
+// exportWriter encodes exported points. Close must be called once all points are written.
+type exportWriter interface {
+	Write(series types.InsightViewSeries, point store.ExportPoint) error
+	Close() error
+}
+
+var csvHeader = []string{"series_id", "series_label", "query", "time", "repository", "repository_id", "capture", "value"}
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(series types.InsightViewSeries, point store.ExportPoint) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.w.Write([]string{
+		series.SeriesID,
+		series.Label,
+		series.Query,
+		point.Time.UTC().Format(time.RFC3339),
+		point.RepoName,
+		strconv.Itoa(int(point.RepoID)),
+		stringOrEmpty(point.Capture),
+		strconv.FormatFloat(point.Value, 'f', -1, 64),
+	})
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) Close() error {
+	// An insight without data still gets a header, so consumers can tell it apart from a failed export.
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// openMetricsName is the name of the single gauge metric family that all points are exported as.
+const openMetricsName = "src_insights_series_value"
+
+type openMetricsWriter struct {
+	w         *bufio.Writer
+	insight   types.Insight
+	wroteMeta bool
+}
+
+func newOpenMetricsWriter(w io.Writer, insight types.Insight) *openMetricsWriter {
+	return &openMetricsWriter{w: bufio.NewWriter(w), insight: insight}
+}
+
+func (o *openMetricsWriter) Write(series types.InsightViewSeries, point store.ExportPoint) error {
+	o.writeMetadata()
+
+	o.w.WriteString(openMetricsName)
+	o.w.WriteByte('{')
+	writeLabel(o.w, "insight_view_id", o.insight.UniqueID)
+	o.w.WriteByte(',')
+	writeLabel(o.w, "series_id", series.SeriesID)
+	o.w.WriteByte(',')
+	writeLabel(o.w, "series_label", series.Label)
+	o.w.WriteByte(',')
+	writeLabel(o.w, "repository", point.RepoName)
+	if point.Capture != nil {
+		o.w.WriteByte(',')
+		writeLabel(o.w, "capture", *point.Capture)
+	}
+	o.w.WriteString("} ")
+	o.w.WriteString(strconv.FormatFloat(point.Value, 'f', -1, 64))
+	o.w.WriteByte(' ')
+	// OpenMetrics timestamps are in seconds.
+	o.w.WriteString(strconv.FormatInt(point.Time.Unix(), 10))
+	_, err := o.w.WriteString("\n")
+	return err
+}
+
+func (o *openMetricsWriter) writeMetadata() {
+	if o.wroteMeta {
+		return
+	}
+	o.wroteMeta = true
+	fmt.Fprintf(o.w, "# TYPE %s gauge\n", openMetricsName)
+	fmt.Fprintf(o.w, "# HELP %s Value recorded for a code insights series in a repository.\n", openMetricsName)
+}
+
+func (o *openMetricsWriter) Close() error {
+	o.writeMetadata()
+	o.w.WriteString("# EOF\n")
+	return o.w.Flush()
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func writeLabel(w *bufio.Writer, name, value string) {
+	w.WriteString(name)
+	w.WriteString(`="`)
+	labelValueEscaper.WriteString(w, value)
+	w.WriteByte('"')
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,87 @@
+package httpapi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestExportWriters(t *testing.T) {
+	insight := types.Insight{UniqueID: "view1"}
+	series := []types.InsightViewSeries{
+		{SeriesID: "series1", Label: "TODOs", Query: "TODO"},
+		{SeriesID: "series2", Label: `say "hi"`, Query: `/hi (\w+)/`},
+	}
+	capture := "there"
+	points := []struct {
+		series types.InsightViewSeries
+		point  store.ExportPoint
+	}{
+		{series[0], store.ExportPoint{SeriesID: "series1", Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), RepoID: 1, RepoName: "github.com/sourcegraph/sourcegraph", Value: 10}},
+		{series[0], store.ExportPoint{SeriesID: "series1", Time: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), RepoID: 1, RepoName: "github.com/sourcegraph/sourcegraph", Value: 12.5}},
+		{series[1], store.ExportPoint{SeriesID: "series2", Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), RepoID: 2, RepoName: "github.com/sourcegraph/about", Capture: &capture, Value: 3}},
+	}
+
+	export := func(format exportFormat) string {
+		var b bytes.Buffer
+		w := format.newWriter(&b, insight)
+		for _, p := range points {
+			if err := w.Write(p.series, p.point); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		autogold.Want("csv", `series_id,series_label,query,time,repository,repository_id,capture,value
+series1,TODOs,TODO,2022-01-01T00:00:00Z,github.com/sourcegraph/sourcegraph,1,,10
+series1,TODOs,TODO,2022-02-01T00:00:00Z,github.com/sourcegraph/sourcegraph,1,,12.5
+series2,"say ""hi""",/hi (\w+)/,2022-01-01T00:00:00Z,github.com/sourcegraph/about,2,there,3
+`).Equal(t, export(csvFormat))
+	})
+
+	t.Run("openmetrics", func(t *testing.T) {
+		autogold.Want("openmetrics", `# TYPE src_insights_series_value gauge
+# HELP src_insights_series_value Value recorded for a code insights series in a repository.
+src_insights_series_value{insight_view_id="view1",series_id="series1",series_label="TODOs",repository="github.com/sourcegraph/sourcegraph"} 10 1640995200
+src_insights_series_value{insight_view_id="view1",series_id="series1",series_label="TODOs",repository="github.com/sourcegraph/sourcegraph"} 12.5 1643673600
+src_insights_series_value{insight_view_id="view1",series_id="series2",series_label="say \"hi\"",repository="github.com/sourcegraph/about",capture="there"} 3 1640995200
+# EOF
+`).Equal(t, export(openMetricsFormat))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		points = nil
+		autogold.Want("empty csv", "series_id,series_label,query,time,repository,repository_id,capture,value\n").Equal(t, export(csvFormat))
+	})
+}
+
+func TestParseExportFormat(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    exportFormat
+		wantErr bool
+	}{
+		{value: "", want: csvFormat},
+		{value: "CSV", want: csvFormat},
+		{value: "openmetrics", want: openMetricsFormat},
+		{value: "json", wantErr: true},
+	} {
+		got, err := parseExportFormat(tc.value)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("parseExportFormat(%q): unexpected error %v", tc.value, err)
+		}
+		if got != tc.want {
+			t.Errorf("parseExportFormat(%q) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
@@ -3,13 +3,16 @@ package query
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/compression"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -18,6 +21,8 @@ import (
 type ComputeExecutor struct {
 	previewExecutor
 	computeSearch func(ctx context.Context, query string) ([]GroupedResults, error)
+	// numericSearch returns the captured values of a numeric-compute series query.
+	numericSearch func(ctx context.Context, query string) ([]GroupedResults, error)
 }
 
 func NewComputeExecutor(postgres database.DB, clock func() time.Time) *ComputeExecutor {
@@ -29,6 +34,7 @@ func NewComputeExecutor(postgres database.DB, clock func() time.Time) *ComputeEx
 			clock:     clock,
 		},
 		computeSearch: streamTextExtraCompute,
+		numericSearch: streamCompute,
 	}
 
 	return &executor
@@ -99,6 +105,97 @@ func (c *ComputeExecutor) Execute(ctx context.Context, query, groupBy string, re
 	return sortAndLimitComputedGroups(timeSeries), nil
 }
 
+// ExecuteNumeric previews a numeric-compute series. Every value captured by query is parsed as a number
+// and combined with aggregation across all repositories, the same way recorded series points are.
+func (c *ComputeExecutor) ExecuteNumeric(ctx context.Context, query string, aggregation types.NumericAggregation, repositories []string) ([]GeneratedTimeSeries, error) {
+	var total NumericSummary
+	for _, repository := range repositories {
+		modifiedQuery := querybuilder.SingleRepoQueryIndexed(querybuilder.BasicQuery(query), repository)
+		grouped, err := c.numericSearch(ctx, modifiedQuery.String())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to execute numeric compute search for repository:"+repository)
+		}
+
+		valueCounts := make(map[string]int, len(grouped))
+		for _, group := range grouped {
+			valueCounts[group.Value] += group.Count
+		}
+		total = total.Merge(SummarizeNumericValues(valueCounts))
+	}
+	if total.Count == 0 {
+		return []GeneratedTimeSeries{}, nil
+	}
+
+	value := total.Value(aggregation)
+	return []GeneratedTimeSeries{{
+		Label:    string(aggregation),
+		SeriesId: "numeric-series-1",
+		Points: []TimeDataPoint{{
+			Time:  c.clock(),
+			Value: &value,
+		}},
+	}}, nil
+}
+
+// NumericSummary holds the sum, maximum and number of the numbers captured by a numeric-compute
+// series. Summaries of different repositories can be merged, so that an average is over all the
+// captured numbers rather than over the averages of each repository.
+type NumericSummary struct {
+	Sum   float64
+	Max   float64
+	Count int
+}
+
+// SummarizeNumericValues summarizes captured values, given as a map from value to the number of
+// times it was captured. Values that don't parse as numbers are ignored, and the count of the
+// summary is zero if there were none that did.
+func SummarizeNumericValues(valueCounts map[string]int) NumericSummary {
+	var s NumericSummary
+	for raw, n := range valueCounts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		if s.Count == 0 || value > s.Max {
+			s.Max = value
+		}
+		s.Sum += value * float64(n)
+		s.Count += n
+	}
+	return s
+}
+
+// Merge returns the summary of the numbers of both s and other.
+func (s NumericSummary) Merge(other NumericSummary) NumericSummary {
+	if other.Count == 0 {
+		return s
+	}
+	if s.Count == 0 {
+		return other
+	}
+	if other.Max > s.Max {
+		s.Max = other.Max
+	}
+	s.Sum += other.Sum
+	s.Count += other.Count
+	return s
+}
+
+// Value returns the value of the summarized numbers for the given aggregation.
+func (s NumericSummary) Value(aggregation types.NumericAggregation) float64 {
+	switch aggregation {
+	case types.MaxAggregation:
+		return s.Max
+	case types.AverageAggregation:
+		if s.Count == 0 {
+			return 0
+		}
+		return s.Sum / float64(s.Count)
+	default:
+		return s.Sum
+	}
+}
+
 // Simple sort/limit with reasonable defaults for v1.
 func sortAndLimitComputedGroups(timeSeries []GeneratedTimeSeries) []GeneratedTimeSeries {
 	descValueSort := func(i, j int) bool {
@@ -27,6 +27,8 @@ type GroupedResults struct {
 type TimeDataPoint struct {
 	Time  time.Time
 	Count int
+	// Value is set instead of Count for points of numeric series, which aren't whole numbers.
+	Value *float64
 }
 
 type ComputeMatchContext struct {
@@ -0,0 +1,289 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightAnnotationResolver = &insightAnnotationResolver{}
+var _ graphqlbackend.InsightAlertResolver = &insightAlertResolver{}
+
+const (
+	insightAnnotationKind = "InsightAnnotation"
+	insightAlertKind      = "InsightAlert"
+)
+
+// loadViewForEdit returns the insight behind a relay insight view ID, if the current user can see it.
+func (r *Resolver) loadViewForEdit(ctx context.Context, id graphql.ID) (types.Insight, error) {
+	var viewID string
+	if err := relay.UnmarshalSpec(id, &viewID); err != nil {
+		return types.Insight{}, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForView(ctx, viewID); err != nil {
+		return types.Insight{}, err
+	}
+
+	insights, err := r.insightStore.GetMapped(ctx, store.InsightQueryArgs{WithoutAuthorization: true, UniqueID: viewID})
+	if err != nil {
+		return types.Insight{}, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) != 1 {
+		return types.Insight{}, errors.New("Insight not found.")
+	}
+	return insights[0], nil
+}
+
+func hasSeries(insight types.Insight, seriesID string) bool {
+	for _, series := range insight.Series {
+		if series.SeriesID == seriesID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resolver) AddInsightAnnotation(ctx context.Context, args *graphqlbackend.AddInsightAnnotationArgs) (graphqlbackend.InsightAnnotationResolver, error) {
+	insight, err := r.loadViewForEdit(ctx, args.Input.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+	if args.Input.SeriesId != nil && !hasSeries(insight, *args.Input.SeriesId) {
+		return nil, errors.Newf("series %q is not a series of the insight", *args.Input.SeriesId)
+	}
+	if args.Input.Label == "" {
+		return nil, errors.New("annotation label must not be empty")
+	}
+
+	annotation := types.InsightAnnotation{
+		InsightViewID: insight.ViewID,
+		SeriesID:      args.Input.SeriesId,
+		Time:          args.Input.Time.Time,
+		Label:         args.Input.Label,
+	}
+	if uid := actor.FromContext(ctx).UID; uid != 0 {
+		annotation.CreatedBy = &uid
+	}
+	annotation, err = r.insightStore.CreateAnnotation(ctx, annotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAnnotation")
+	}
+	return &insightAnnotationResolver{annotation: annotation, db: r.postgresDB}, nil
+}
+
+func (r *Resolver) DeleteInsightAnnotation(ctx context.Context, args *graphqlbackend.DeleteInsightAnnotationArgs) (*graphqlbackend.EmptyResponse, error) {
+	insight, err := r.loadViewForEdit(ctx, args.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the annotation id")
+	}
+	if err := r.insightStore.DeleteAnnotation(ctx, insight.ViewID, id); err != nil {
+		return nil, errors.Wrap(err, "DeleteAnnotation")
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) AddInsightAlert(ctx context.Context, args *graphqlbackend.AddInsightAlertArgs) (graphqlbackend.InsightAlertResolver, error) {
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, errors.New("Unable to create an alert without an authenticated user.")
+	}
+	insight, err := r.loadViewForEdit(ctx, args.Input.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+	alert, err := alertFromInput(insight, uid, args.Input)
+	if err != nil {
+		return nil, err
+	}
+	alert, err = r.insightStore.CreateAlert(ctx, alert)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlert")
+	}
+	return &insightAlertResolver{alert: alert}, nil
+}
+
+func alertFromInput(insight types.Insight, userID int32, input graphqlbackend.AddInsightAlertInput) (types.InsightAlert, error) {
+	if !hasSeries(insight, input.SeriesId) {
+		return types.InsightAlert{}, errors.Newf("series %q is not a series of the insight", input.SeriesId)
+	}
+	condition := types.AlertCondition(input.Condition)
+	if !condition.IsValid() {
+		return types.InsightAlert{}, errors.Newf("invalid alert condition %q", input.Condition)
+	}
+	for _, u := range []*string{input.SlackWebhookURL, input.WebhookURL} {
+		if u == nil {
+			continue
+		}
+		if err := validateNotificationURL(*u); err != nil {
+			return types.InsightAlert{}, err
+		}
+	}
+	email := input.Email != nil && *input.Email
+	if !email && input.SlackWebhookURL == nil && input.WebhookURL == nil {
+		return types.InsightAlert{}, errors.New("an alert needs at least one notification")
+	}
+
+	return types.InsightAlert{
+		InsightViewID:   insight.ViewID,
+		SeriesID:        input.SeriesId,
+		Condition:       condition,
+		Threshold:       input.Threshold,
+		UserID:          userID,
+		EmailEnabled:    email,
+		SlackWebhookURL: unencryptedURL(input.SlackWebhookURL),
+		WebhookURL:      unencryptedURL(input.WebhookURL),
+	}, nil
+}
+
+func unencryptedURL(u *string) *encryption.Encryptable {
+	if u == nil {
+		return nil
+	}
+	return encryption.NewUnencrypted(*u)
+}
+
+func validateNotificationURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return errors.Wrapf(err, "invalid notification URL %q", raw)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Newf("invalid notification URL %q: must be an absolute http or https URL", raw)
+	}
+	return nil
+}
+
+func (r *Resolver) DeleteInsightAlert(ctx context.Context, args *graphqlbackend.DeleteInsightAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	insight, err := r.loadViewForEdit(ctx, args.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the alert id")
+	}
+
+	// 🚨 SECURITY: users can only delete their own alerts, even on insights they can edit.
+	alerts, err := r.insightStore.GetAlerts(ctx, store.GetAlertsArgs{InsightViewID: insight.ViewID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlerts")
+	}
+	uid := actor.FromContext(ctx).UID
+	for _, alert := range alerts {
+		if alert.ID == id && alert.UserID == uid {
+			if err := r.insightStore.DeleteAlert(ctx, insight.ViewID, id); err != nil {
+				return nil, errors.Wrap(err, "DeleteAlert")
+			}
+			return &graphqlbackend.EmptyResponse{}, nil
+		}
+	}
+	return nil, errors.New("alert not found")
+}
+
+type insightAnnotationResolver struct {
+	annotation types.InsightAnnotation
+	db         database.DB
+}
+
+func (a *insightAnnotationResolver) ID() graphql.ID {
+	return relay.MarshalID(insightAnnotationKind, a.annotation.ID)
+}
+
+func (a *insightAnnotationResolver) SeriesId() *string {
+	return a.annotation.SeriesID
+}
+
+func (a *insightAnnotationResolver) Time() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: a.annotation.Time}
+}
+
+func (a *insightAnnotationResolver) Label() string {
+	return a.annotation.Label
+}
+
+func (a *insightAnnotationResolver) CreatedBy(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if a.annotation.CreatedBy == nil {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, a.db, *a.annotation.CreatedBy)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+type insightAlertResolver struct {
+	alert types.InsightAlert
+}
+
+func (a *insightAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightAlertKind, a.alert.ID)
+}
+
+func (a *insightAlertResolver) SeriesId() string {
+	return a.alert.SeriesID
+}
+
+func (a *insightAlertResolver) Condition() string {
+	return string(a.alert.Condition)
+}
+
+func (a *insightAlertResolver) Threshold() float64 {
+	return a.alert.Threshold
+}
+
+func (a *insightAlertResolver) Email() bool {
+	return a.alert.EmailEnabled
+}
+
+func (a *insightAlertResolver) SlackWebhookURL(ctx context.Context) (*string, error) {
+	return decryptURL(ctx, a.alert.SlackWebhookURL)
+}
+
+func (a *insightAlertResolver) WebhookURL(ctx context.Context) (*string, error) {
+	return decryptURL(ctx, a.alert.WebhookURL)
+}
+
+func decryptURL(ctx context.Context, u *encryption.Encryptable) (*string, error) {
+	if u == nil {
+		return nil, nil
+	}
+	decrypted, err := u.Decrypt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &decrypted, nil
+}
+
+func (a *insightAlertResolver) Enabled() bool {
+	return a.alert.Enabled
+}
+
+func (a *insightAlertResolver) Breached() bool {
+	return a.alert.Breached
+}
+
+func (a *insightAlertResolver) LastTriggeredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(a.alert.LastTriggeredAt)
+}
+
+func (a *insightAlertResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: a.alert.CreatedAt}
+}
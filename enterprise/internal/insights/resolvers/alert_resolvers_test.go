@@ -0,0 +1,61 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestAlertFromInput(t *testing.T) {
+	insight := types.Insight{ViewID: 3, Series: []types.InsightViewSeries{{SeriesID: "s1"}}}
+	yes := true
+	slackURL := "https://hooks.slack.com/services/abc"
+	badURL := "ftp://example.com"
+
+	testCases := []struct {
+		name  string
+		input graphqlbackend.AddInsightAlertInput
+		want  autogold.Value
+	}{
+		{
+			"valid",
+			graphqlbackend.AddInsightAlertInput{SeriesId: "s1", Condition: "ABOVE", Threshold: 10, Email: &yes, SlackWebhookURL: &slackURL},
+			autogold.Want("valid", "<nil>"),
+		},
+		{
+			"unknown series",
+			graphqlbackend.AddInsightAlertInput{SeriesId: "s2", Condition: "ABOVE", Email: &yes},
+			autogold.Want("unknown series", `series "s2" is not a series of the insight`),
+		},
+		{
+			"invalid condition",
+			graphqlbackend.AddInsightAlertInput{SeriesId: "s1", Condition: "SIDEWAYS", Email: &yes},
+			autogold.Want("invalid condition", `invalid alert condition "SIDEWAYS"`),
+		},
+		{
+			"invalid webhook URL",
+			graphqlbackend.AddInsightAlertInput{SeriesId: "s1", Condition: "BELOW", WebhookURL: &badURL},
+			autogold.Want("invalid webhook URL", `invalid notification URL "ftp://example.com": must be an absolute http or https URL`),
+		},
+		{
+			"no notification",
+			graphqlbackend.AddInsightAlertInput{SeriesId: "s1", Condition: "INCREASE_PERCENT"},
+			autogold.Want("no notification", "an alert needs at least one notification"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alert, err := alertFromInput(insight, 7, tc.input)
+			got := "<nil>"
+			if err != nil {
+				got = err.Error()
+			} else if alert.InsightViewID != 3 || alert.UserID != 7 || alert.SeriesID != "s1" {
+				t.Errorf("unexpected alert: %+v", alert)
+			}
+			tc.want.Equal(t, got)
+		})
+	}
+}
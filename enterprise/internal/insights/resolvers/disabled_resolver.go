@@ -98,3 +98,19 @@ func (r *disabledResolver) ValidateScopedInsightQuery(ctx context.Context, args
 func (r *disabledResolver) PreviewRepositoriesFromQuery(ctx context.Context, args graphqlbackend.PreviewRepositoriesFromQueryArgs) (graphqlbackend.RepositoryPreviewPayloadResolver, error) {
 	return nil, errors.New(r.reason)
 }
+
+func (r *disabledResolver) AddInsightAnnotation(ctx context.Context, args *graphqlbackend.AddInsightAnnotationArgs) (graphqlbackend.InsightAnnotationResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightAnnotation(ctx context.Context, args *graphqlbackend.DeleteInsightAnnotationArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) AddInsightAlert(ctx context.Context, args *graphqlbackend.AddInsightAlertArgs) (graphqlbackend.InsightAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightAlert(ctx context.Context, args *graphqlbackend.DeleteInsightAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
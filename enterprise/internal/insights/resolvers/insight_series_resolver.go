@@ -248,7 +248,7 @@ func (p *precalculatedInsightSeriesResolver) Points(ctx context.Context, _ *grap
 	// Replacing capture group values if present
 	// Ignoring errors so it falls back to the entered query
 	query := p.series.Query
-	if p.series.GeneratedFromCaptureGroups && len(modifiedPoints) > 0 {
+	if p.series.GeneratedFromCaptureGroups && len(modifiedPoints) > 0 && modifiedPoints[0].Capture != nil {
 		replacer, _ := querybuilder.NewPatternReplacer(querybuilder.BasicQuery(query), searchquery.SearchTypeRegex)
 		if replacer != nil {
 			replaced, err := replacer.Replace(*modifiedPoints[0].Capture)
@@ -373,6 +373,9 @@ func getRecordedSeriesPointOpts(ctx context.Context, db database.DB, definition
 	opts.SeriesID = &seriesID
 	opts.ID = &definition.InsightSeriesID
 	opts.SupportsAugmentation = definition.SupportsAugmentation
+	if definition.GenerationMethod == types.NumericCompute && definition.Aggregation != nil {
+		opts.Aggregation = types.NumericAggregation(*definition.Aggregation)
+	}
 
 	// Default to last 12 points of data
 	frames := timeseries.BuildSampleTimes(12, timeseries.TimeInterval{
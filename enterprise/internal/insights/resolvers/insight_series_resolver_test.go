@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -98,6 +99,75 @@ func TestResolver_InsightSeries(t *testing.T) {
 	})
 }
 
+// TestResolver_NumericInsightSeries tests that numeric series, whose points are recorded without a
+// captured value, resolve to a single series of points.
+func TestResolver_NumericInsightSeries(t *testing.T) {
+	ctx := actor.WithInternalActor(context.Background())
+	now := time.Now().UTC().Truncate(time.Hour)
+	logger := logtest.Scoped(t)
+	clock := func() time.Time { return now }
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	resolver := newWithClock(insightsDB, postgres, clock)
+	resolver.insightMetadataStore = store.NewMockInsightMetadataStore()
+	insightStore := store.NewInsightStore(insightsDB)
+	tss := store.New(insightsDB, store.NewInsightPermissionStore(postgres))
+
+	view, err := insightStore.CreateView(ctx, types.InsightView{
+		Title:            "timeouts",
+		PresentationType: types.Line,
+	}, []store.InsightViewGrant{store.GlobalGrant()})
+	require.NoError(t, err)
+	aggregation := string(types.MaxAggregation)
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:                   "numeric",
+		Query:                      `timeout_ms: (\d+)`,
+		CreatedAt:                  now,
+		OldestHistoricalAt:         now,
+		LastRecordedAt:             now,
+		NextRecordingAfter:         now,
+		SampleIntervalUnit:         string(types.Month),
+		SampleIntervalValue:        1,
+		GeneratedFromCaptureGroups: true,
+		GenerationMethod:           types.NumericCompute,
+		Aggregation:                &aggregation,
+	})
+	require.NoError(t, err)
+	require.NoError(t, insightStore.AttachSeriesToView(ctx, series, view, types.InsightViewSeriesMetadata{Label: "max timeout"}))
+
+	record := func(repoID api.RepoID, repoName string, value float64) store.RecordSeriesPointArgs {
+		return store.RecordSeriesPointArgs{
+			SeriesID:    series.SeriesID,
+			Point:       store.SeriesPoint{Time: now, Value: value},
+			RepoName:    &repoName,
+			RepoID:      &repoID,
+			PersistMode: store.RecordMode,
+		}
+	}
+	require.NoError(t, tss.RecordSeriesPoints(ctx, []store.RecordSeriesPointArgs{
+		record(1, "github.com/sourcegraph/sourcegraph", 2.5),
+		record(2, "github.com/sourcegraph/handbook", 4.25),
+	}))
+
+	conn, err := resolver.InsightViews(ctx, &graphqlbackend.InsightViewQueryArgs{})
+	require.NoError(t, err)
+	nodes, err := conn.Nodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	dataSeries, err := nodes[0].DataSeries(ctx)
+	require.NoError(t, err)
+	require.Len(t, dataSeries, 1)
+	autogold.Want("numeric series label", "max timeout").Equal(t, dataSeries[0].Label())
+
+	points, err := dataSeries[0].Points(ctx, nil)
+	require.NoError(t, err)
+	var values []float64
+	for _, point := range points {
+		values = append(values, point.Value())
+	}
+	autogold.Want("numeric series values", []float64{4.25}).Equal(t, values)
+}
+
 func fakeStatusGetter(status *queryrunner.JobsStatus, err error) GetSeriesQueueStatusFunc {
 	return func(ctx context.Context, seriesID string) (*queryrunner.JobsStatus, error) {
 		return status, err
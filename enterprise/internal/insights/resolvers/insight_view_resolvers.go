@@ -139,6 +139,13 @@ func (i *insightViewResolver) registerDataSeriesGenerators() {
 	}
 
 	// create the known ways to resolve a data series
+	// numeric series capture values, but each of their points is a single number rather than one series per value
+	recordedNumericGenerator := newSeriesResolverGenerator(
+		func(series types.InsightViewSeries) bool {
+			return !series.JustInTime && series.GenerationMethod == types.NumericCompute
+		},
+		recordedSeries,
+	)
 	recordedCaptureGroupGenerator := newSeriesResolverGenerator(
 		func(series types.InsightViewSeries) bool {
 			return !series.JustInTime && series.GeneratedFromCaptureGroups
@@ -152,10 +159,11 @@ func (i *insightViewResolver) registerDataSeriesGenerators() {
 		recordedSeries,
 	)
 	// build the chain of generators
+	recordedNumericGenerator.SetNext(recordedCaptureGroupGenerator)
 	recordedCaptureGroupGenerator.SetNext(recordedGenerator)
 
 	// set the struct variable to the first generator in the chain
-	i.dataSeriesGenerator = recordedCaptureGroupGenerator
+	i.dataSeriesGenerator = recordedNumericGenerator
 }
 
 func (i *insightViewResolver) DataSeries(ctx context.Context) ([]graphqlbackend.InsightSeriesResolver, error) {
@@ -253,6 +261,35 @@ func (i *insightViewResolver) TimeScope(ctx context.Context) (graphqlbackend.Ins
 	}, nil
 }
 
+func (i *insightViewResolver) Annotations(ctx context.Context) ([]graphqlbackend.InsightAnnotationResolver, error) {
+	annotations, err := i.insightStore.GetAnnotations(ctx, i.view.ViewID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightAnnotationResolver, 0, len(annotations))
+	for _, annotation := range annotations {
+		resolvers = append(resolvers, &insightAnnotationResolver{annotation: annotation, db: i.postgresDB})
+	}
+	return resolvers, nil
+}
+
+func (i *insightViewResolver) Alerts(ctx context.Context) ([]graphqlbackend.InsightAlertResolver, error) {
+	alerts, err := i.insightStore.GetAlerts(ctx, store.GetAlertsArgs{InsightViewID: i.view.ViewID})
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: alerts hold the notification URLs of their owner, so only the alerts of the current
+	// user are returned.
+	uid := actor.FromContext(ctx).UID
+	resolvers := make([]graphqlbackend.InsightAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		if alert.UserID == uid {
+			resolvers = append(resolvers, &insightAlertResolver{alert: alert})
+		}
+	}
+	return resolvers, nil
+}
+
 func (i *insightViewResolver) Presentation(ctx context.Context) (graphqlbackend.InsightPresentation, error) {
 	if i.view.PresentationType == types.Pie {
 		pieChartPresentation := &pieChartInsightViewPresentation{view: i.view}
@@ -342,6 +379,10 @@ func (s *searchInsightDataSeriesDefinitionResolver) GroupBy() (*string, error) {
 	return s.series.GroupBy, nil
 }
 
+func (s *searchInsightDataSeriesDefinitionResolver) Aggregation() (*string, error) {
+	return s.series.Aggregation, nil
+}
+
 type insightIntervalTimeScopeResolver struct {
 	unit  string
 	value int32
@@ -731,6 +772,9 @@ func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSerie
 			return true
 		}
 	}
+	if emptyIfNil(new.Aggregation) != emptyIfNil(existing.Aggregation) {
+		return true
+	}
 	return emptyIfNil(new.GroupBy) != emptyIfNil(existing.GroupBy)
 }
 
@@ -1286,6 +1330,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			StepIntervalValue:         int(series.TimeScope.StepInterval.Value),
 			GenerateFromCaptureGroups: dynamic,
 			GroupBy:                   groupBy,
+			Aggregation:               series.Aggregation,
 		})
 		if err != nil {
 			return errors.Wrap(err, "FindMatchingSeries")
@@ -1308,6 +1353,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			NextRecordingAfter:         nextRecordingAfter,
 			OldestHistoricalAt:         oldestHistoricalAt,
 			RepositoryCriteria:         series.RepositoryScope.RepositoryCriteria,
+			Aggregation:                series.Aggregation,
 		})
 		if err != nil {
 			return errors.Wrap(err, "CreateSeries")
@@ -1335,6 +1381,10 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 }
 
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+	if series.Aggregation != nil {
+		// Numeric series use capture groups to find numbers, but aren't split into a series per captured value.
+		return types.NumericCompute
+	}
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
 			return types.MappingCompute
@@ -1541,6 +1591,24 @@ func minInt(a, b int32) int32 {
 	return b
 }
 
+// isValidAggregation checks that a numeric aggregation is only requested for series that capture
+// values, and that aren't also grouped by a field.
+func isValidAggregation(aggregation, groupBy *string, generatedFromCaptureGroups bool) error {
+	if aggregation == nil {
+		return nil
+	}
+	if !types.NumericAggregation(*aggregation).IsValid() {
+		return errors.Newf("unsupported aggregation %q", *aggregation)
+	}
+	if !generatedFromCaptureGroups {
+		return errors.New("numeric aggregation requires a series generated from capture groups")
+	}
+	if groupBy != nil {
+		return errors.New("numeric aggregation can not be combined with group by")
+	}
+	return nil
+}
+
 func lowercaseGroupBy(groupBy *string) *string {
 	if groupBy != nil {
 		temp := strings.ToLower(*groupBy)
@@ -1564,6 +1632,9 @@ func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSer
 	if !repoListSpecified && seriesInput.GroupBy != nil {
 		return errors.New("group by series require a list of repositories to be specified.")
 	}
+	if err := isValidAggregation(seriesInput.Aggregation, seriesInput.GroupBy, seriesInput.GeneratedFromCaptureGroups != nil && *seriesInput.GeneratedFromCaptureGroups); err != nil {
+		return err
+	}
 
 	if repoCriteriaSpecified {
 		plan, err := querybuilder.ParseQuery(*seriesInput.RepositoryScope.RepositoryCriteria, "literal")
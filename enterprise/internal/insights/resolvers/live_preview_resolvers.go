@@ -33,6 +33,7 @@ func (r *Resolver) SearchInsightLivePreview(ctx context.Context, args graphqlbac
 					Label:                      args.Input.Label,
 					GeneratedFromCaptureGroups: args.Input.GeneratedFromCaptureGroups,
 					GroupBy:                    args.Input.GroupBy,
+					Aggregation:                args.Input.Aggregation,
 				},
 			},
 		},
@@ -78,6 +79,15 @@ func (r *Resolver) SearchInsightPreview(ctx context.Context, args graphqlbackend
 				if err != nil {
 					return nil, err
 				}
+			} else if seriesArgs.Aggregation != nil {
+				executor := query.NewComputeExecutor(r.postgresDB, clock)
+				series, err = executor.ExecuteNumeric(ctx, seriesArgs.Query, types.NumericAggregation(*seriesArgs.Aggregation), repos)
+				if err != nil {
+					return nil, err
+				}
+				for i := range series {
+					series[i].Label = seriesArgs.Label
+				}
 			} else {
 				executor := query.NewCaptureGroupExecutor(r.postgresDB, clock)
 				series, err = executor.Execute(ctx, seriesArgs.Query, repos, interval)
@@ -97,7 +107,7 @@ func (r *Resolver) SearchInsightPreview(ctx context.Context, args graphqlbackend
 			// Replacing capture group values if present
 			// Ignoring errors so it falls back to the entered query
 			seriesQuery := seriesArgs.Query
-			if seriesArgs.GeneratedFromCaptureGroups && len(series[i].Points) > 0 {
+			if seriesArgs.GeneratedFromCaptureGroups && seriesArgs.Aggregation == nil && len(series[i].Points) > 0 {
 				replacer, _ := querybuilder.NewPatternReplacer(querybuilder.BasicQuery(seriesQuery), searchquery.SearchTypeRegex)
 				if replacer != nil {
 					replaced, err := replacer.Replace(series[i].Label)
@@ -144,6 +154,9 @@ func (s *searchInsightLivePreviewSeriesResolver) Points(ctx context.Context) ([]
 			Time:     s.series.Points[i].Time,
 			Value:    float64(s.series.Points[i].Count),
 		}
+		if s.series.Points[i].Value != nil {
+			point.Value = *s.series.Points[i].Value
+		}
 		var after *time.Time
 		if i > 0 {
 			after = &s.series.Points[i-1].Time
@@ -203,6 +216,12 @@ func isValidPreviewArgs(args graphqlbackend.SearchInsightPreviewArgs) error {
 		return errors.New("can not specify both a repository list and a repository search")
 	}
 
+	for _, series := range args.Input.Series {
+		if err := isValidAggregation(series.Aggregation, series.GroupBy, series.GeneratedFromCaptureGroups); err != nil {
+			return err
+		}
+	}
+
 	if hasRepoCriteria {
 		for i := 0; i < len(args.Input.Series); i++ {
 			if args.Input.Series[i].GroupBy != nil {
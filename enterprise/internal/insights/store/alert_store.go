@@ -0,0 +1,258 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// CreateAnnotation stores a new annotation on an insight view.
+func (s *InsightStore) CreateAnnotation(ctx context.Context, annotation types.InsightAnnotation) (types.InsightAnnotation, error) {
+	q := sqlf.Sprintf(createAnnotationSql,
+		annotation.InsightViewID,
+		annotation.SeriesID,
+		annotation.Time.UTC(),
+		annotation.Label,
+		annotation.CreatedBy,
+		s.Now().UTC(),
+	)
+	annotations, err := scanAnnotations(s.Query(ctx, q))
+	if err != nil {
+		return types.InsightAnnotation{}, errors.Wrap(err, "CreateAnnotation")
+	}
+	if len(annotations) == 0 {
+		return types.InsightAnnotation{}, errors.New("CreateAnnotation: no annotation was created")
+	}
+	return annotations[0], nil
+}
+
+const createAnnotationSql = `
+INSERT INTO insight_view_annotations (insight_view_id, series_id, annotation_time, label, created_by, created_at)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING id, insight_view_id, series_id, annotation_time, label, created_by, created_at;
+`
+
+// GetAnnotations returns the annotations of an insight view, ordered by time.
+func (s *InsightStore) GetAnnotations(ctx context.Context, insightViewID int) ([]types.InsightAnnotation, error) {
+	return scanAnnotations(s.Query(ctx, sqlf.Sprintf(getAnnotationsSql, insightViewID)))
+}
+
+const getAnnotationsSql = `
+SELECT id, insight_view_id, series_id, annotation_time, label, created_by, created_at
+FROM insight_view_annotations
+WHERE insight_view_id = %s
+ORDER BY annotation_time, id;
+`
+
+// DeleteAnnotation deletes an annotation of an insight view. Deleting an annotation that doesn't
+// exist, or that belongs to another view, is a no-op.
+func (s *InsightStore) DeleteAnnotation(ctx context.Context, insightViewID, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAnnotationSql, insightViewID, id))
+}
+
+const deleteAnnotationSql = `
+DELETE FROM insight_view_annotations WHERE insight_view_id = %s AND id = %s;
+`
+
+func scanAnnotations(rows *sql.Rows, queryErr error) (_ []types.InsightAnnotation, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	results := make([]types.InsightAnnotation, 0)
+	for rows.Next() {
+		var temp types.InsightAnnotation
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.InsightViewID,
+			&temp.SeriesID,
+			&temp.Time,
+			&temp.Label,
+			&temp.CreatedBy,
+			&temp.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, temp)
+	}
+	return results, nil
+}
+
+// CreateAlert stores a new threshold alert on a series of an insight view. Its notification URLs
+// are encrypted.
+func (s *InsightStore) CreateAlert(ctx context.Context, alert types.InsightAlert) (types.InsightAlert, error) {
+	key := s.getEncryptionKey()
+	var keyID string
+	encryptURL := func(url *encryption.Encryptable) (*string, error) {
+		if url == nil {
+			return nil, nil
+		}
+		encrypted, id, err := url.Encrypt(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		keyID = id
+		return &encrypted, nil
+	}
+	slackWebhookURL, err := encryptURL(alert.SlackWebhookURL)
+	if err != nil {
+		return types.InsightAlert{}, errors.Wrap(err, "encrypting Slack webhook URL")
+	}
+	webhookURL, err := encryptURL(alert.WebhookURL)
+	if err != nil {
+		return types.InsightAlert{}, errors.Wrap(err, "encrypting webhook URL")
+	}
+
+	q := sqlf.Sprintf(createAlertSql,
+		alert.InsightViewID,
+		alert.SeriesID,
+		alert.Condition,
+		alert.Threshold,
+		alert.UserID,
+		alert.EmailEnabled,
+		slackWebhookURL,
+		webhookURL,
+		dbutil.NullStringColumn(keyID),
+		s.Now().UTC(),
+	)
+	alerts, err := s.scanAlerts(s.Query(ctx, q))
+	if err != nil {
+		return types.InsightAlert{}, errors.Wrap(err, "CreateAlert")
+	}
+	if len(alerts) == 0 {
+		return types.InsightAlert{}, errors.New("CreateAlert: no alert was created")
+	}
+	return alerts[0], nil
+}
+
+const createAlertSql = `
+WITH a AS (
+	INSERT INTO insight_view_alerts (insight_view_id, series_id, condition, threshold, user_id, email_enabled, slack_webhook_url, webhook_url, encryption_key_id, created_at)
+	VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	RETURNING *
+)
+SELECT ` + alertColumns + `
+FROM a ` + alertViewJoins + `;
+`
+
+// GetAlertsArgs are the filters of GetAlerts. At least one of InsightViewID and SeriesID should be
+// set.
+type GetAlertsArgs struct {
+	InsightViewID int
+	SeriesID      string
+	// EnabledOnly filters out disabled alerts.
+	EnabledOnly bool
+}
+
+// GetAlerts returns the alerts matching args, ordered by ID.
+func (s *InsightStore) GetAlerts(ctx context.Context, args GetAlertsArgs) ([]types.InsightAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.InsightViewID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.insight_view_id = %s", args.InsightViewID))
+	}
+	if args.SeriesID != "" {
+		preds = append(preds, sqlf.Sprintf("a.series_id = %s", args.SeriesID))
+	}
+	if args.EnabledOnly {
+		preds = append(preds, sqlf.Sprintf("a.enabled"))
+	}
+	return s.scanAlerts(s.Query(ctx, sqlf.Sprintf(getAlertsSql, sqlf.Join(preds, "AND"))))
+}
+
+const getAlertsSql = `
+SELECT ` + alertColumns + `
+FROM insight_view_alerts a ` + alertViewJoins + `
+WHERE %s
+ORDER BY a.id;
+`
+
+// DeleteAlert deletes an alert of an insight view. Deleting an alert that doesn't exist, or that
+// belongs to another view, is a no-op.
+func (s *InsightStore) DeleteAlert(ctx context.Context, insightViewID, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAlertSql, insightViewID, id))
+}
+
+const deleteAlertSql = `
+DELETE FROM insight_view_alerts WHERE insight_view_id = %s AND id = %s;
+`
+
+// SetAlertBreached records the outcome of evaluating an alert. triggeredAt is the time a
+// notification was sent, if any; the previous trigger time is kept otherwise.
+func (s *InsightStore) SetAlertBreached(ctx context.Context, id int, breached bool, triggeredAt *time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(setAlertBreachedSql, breached, triggeredAt, id))
+}
+
+const setAlertBreachedSql = `
+UPDATE insight_view_alerts SET breached = %s, last_triggered_at = COALESCE(%s, last_triggered_at) WHERE id = %s;
+`
+
+const alertColumns = `a.id, a.insight_view_id, a.series_id, a.condition, a.threshold, a.user_id, a.email_enabled, a.slack_webhook_url,
+a.webhook_url, a.encryption_key_id, a.enabled, a.breached, a.last_triggered_at, a.created_at, iv.unique_id, COALESCE(iv.title, ''), COALESCE(ivs.label, '')`
+
+// alertViewJoins joins the view, and the series' metadata in that view, of the alerts aliased as a.
+const alertViewJoins = `
+JOIN insight_view iv ON iv.id = a.insight_view_id
+LEFT JOIN insight_series i ON i.series_id = a.series_id
+LEFT JOIN insight_view_series ivs ON ivs.insight_view_id = a.insight_view_id AND ivs.insight_series_id = i.id`
+
+func (s *InsightStore) scanAlerts(rows *sql.Rows, queryErr error) (_ []types.InsightAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	key := s.getEncryptionKey()
+	results := make([]types.InsightAlert, 0)
+	for rows.Next() {
+		var (
+			temp                               types.InsightAlert
+			slackWebhookURL, webhookURL, keyID string
+		)
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.InsightViewID,
+			&temp.SeriesID,
+			&temp.Condition,
+			&temp.Threshold,
+			&temp.UserID,
+			&temp.EmailEnabled,
+			&dbutil.NullString{S: &slackWebhookURL},
+			&dbutil.NullString{S: &webhookURL},
+			&dbutil.NullString{S: &keyID},
+			&temp.Enabled,
+			&temp.Breached,
+			&temp.LastTriggeredAt,
+			&temp.CreatedAt,
+			&temp.InsightViewUniqueID,
+			&temp.InsightViewTitle,
+			&temp.SeriesLabel,
+		); err != nil {
+			return nil, err
+		}
+		temp.SlackWebhookURL = newEncryptableURL(slackWebhookURL, keyID, key)
+		temp.WebhookURL = newEncryptableURL(webhookURL, keyID, key)
+		results = append(results, temp)
+	}
+	return results, nil
+}
+
+// newEncryptableURL wraps a notification URL read from the database, which is only encrypted if
+// keyID is set. Unset URLs are empty.
+func newEncryptableURL(url, keyID string, key encryption.Key) *encryption.Encryptable {
+	if url == "" {
+		return nil
+	}
+	if keyID == "" {
+		return encryption.NewUnencrypted(url)
+	}
+	return encryption.NewEncrypted(url, keyID, key)
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Microsecond).Round(0)
+	ctx := context.Background()
+
+	store := NewInsightStore(insightsDB)
+	store.Now = func() time.Time {
+		return now
+	}
+
+	view, err := store.CreateView(ctx, types.InsightView{UniqueID: "view1", PresentationType: types.Line}, []InsightViewGrant{GlobalGrant()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seriesID := "series1"
+	userID := int32(1)
+	later, err := store.CreateAnnotation(ctx, types.InsightAnnotation{
+		InsightViewID: view.ID,
+		SeriesID:      &seriesID,
+		Time:          now.Add(48 * time.Hour),
+		Label:         "migration finished",
+		CreatedBy:     &userID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	earlier, err := store.CreateAnnotation(ctx, types.InsightAnnotation{
+		InsightViewID: view.ID,
+		Time:          now.Add(24 * time.Hour),
+		Label:         "migration started",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.GetAnnotations(ctx, view.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.InsightAnnotation{earlier, later}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected annotations (want/got): %s", diff)
+	}
+	if got[1].Label != "migration finished" || *got[1].SeriesID != seriesID || *got[1].CreatedBy != userID {
+		t.Errorf("unexpected annotation: %+v", got[1])
+	}
+
+	// Annotations are scoped to their view.
+	if err := store.DeleteAnnotation(ctx, view.ID+1, earlier.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteAnnotation(ctx, view.ID, later.ID); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.GetAnnotations(ctx, view.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]types.InsightAnnotation{earlier}, got); diff != "" {
+		t.Errorf("unexpected annotations after delete (want/got): %s", diff)
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Microsecond).Round(0)
+	ctx := context.Background()
+
+	store := NewInsightStore(insightsDB)
+	store.Now = func() time.Time {
+		return now
+	}
+	store.key = et.TestKey{}
+
+	view, err := store.CreateView(ctx, types.InsightView{UniqueID: "view1", PresentationType: types.Line}, []InsightViewGrant{GlobalGrant()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slackURL := "https://hooks.slack.com/services/abc"
+	alert, err := store.CreateAlert(ctx, types.InsightAlert{
+		InsightViewID:   view.ID,
+		SeriesID:        "series1",
+		Condition:       types.AlertAbove,
+		Threshold:       100,
+		UserID:          1,
+		EmailEnabled:    true,
+		SlackWebhookURL: encryption.NewUnencrypted(slackURL),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.InsightAlert{
+		ID:              alert.ID,
+		InsightViewID:   view.ID,
+		SeriesID:        "series1",
+		Condition:       types.AlertAbove,
+		Threshold:       100,
+		UserID:          1,
+		EmailEnabled:    true,
+		SlackWebhookURL: encryption.NewUnencrypted(slackURL),
+		Enabled:         true,
+		CreatedAt:       now,
+
+		InsightViewUniqueID: "view1",
+	}
+	if diff := cmp.Diff(want, alert, et.CompareEncryptable); diff != "" {
+		t.Errorf("unexpected alert (want/got): %s", diff)
+	}
+
+	// The URL is stored encrypted.
+	var rawURL, keyID string
+	if err := store.QueryRow(ctx, sqlf.Sprintf("SELECT slack_webhook_url, encryption_key_id FROM insight_view_alerts WHERE id = %s", alert.ID)).Scan(&rawURL, &keyID); err != nil {
+		t.Fatal(err)
+	}
+	if rawURL == slackURL || keyID == "" {
+		t.Errorf("expected the Slack webhook URL to be encrypted, got %q with key %q", rawURL, keyID)
+	}
+
+	if err := store.SetAlertBreached(ctx, alert.ID, true, &now); err != nil {
+		t.Fatal(err)
+	}
+	// Clearing the breach keeps the last trigger time.
+	if err := store.SetAlertBreached(ctx, alert.ID, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.GetAlerts(ctx, GetAlertsArgs{SeriesID: "series1", EnabledOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.LastTriggeredAt = &now
+	if diff := cmp.Diff([]types.InsightAlert{want}, got, et.CompareEncryptable); diff != "" {
+		t.Errorf("unexpected alerts (want/got): %s", diff)
+	}
+
+	if err := store.DeleteAlert(ctx, view.ID, alert.ID); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.GetAlerts(ctx, GetAlertsArgs{InsightViewID: view.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no alerts after delete, got %v", got)
+	}
+}
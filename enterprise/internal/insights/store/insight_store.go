@@ -13,12 +13,17 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type InsightStore struct {
 	*basestore.Store
 	Now func() time.Time
+
+	// key encrypts the notification URLs of alerts, see getEncryptionKey.
+	key encryption.Key
 }
 
 // NewInsightStore returns a new InsightStore backed by the given Postgres db.
@@ -34,12 +39,21 @@ func NewInsightStoreWith(other basestore.ShareableStore) *InsightStore {
 // With creates a new InsightStore with the given basestore.Shareable store as the underlying basestore.Store.
 // Needed to implement the basestore.Store interface
 func (s *InsightStore) With(other basestore.ShareableStore) *InsightStore {
-	return &InsightStore{Store: s.Store.With(other), Now: s.Now}
+	return &InsightStore{Store: s.Store.With(other), Now: s.Now, key: s.key}
 }
 
 func (s *InsightStore) Transact(ctx context.Context) (*InsightStore, error) {
 	txBase, err := s.Store.Transact(ctx)
-	return &InsightStore{Store: txBase, Now: s.Now}, err
+	return &InsightStore{Store: txBase, Now: s.Now, key: s.key}, err
+}
+
+// getEncryptionKey returns the key used to encrypt the notification URLs of alerts. They are
+// encrypted with the same key as the webhook URLs of code monitors.
+func (s *InsightStore) getEncryptionKey() encryption.Key {
+	if s.key != nil {
+		return s.key
+	}
+	return keyring.Default().WebhookKey
 }
 
 // InsightQueryArgs contains query predicates for fetching viewable insight series. Any provided values will be
@@ -401,6 +415,7 @@ func scanDataSeries(rows *sql.Rows, queryErr error) (_ []types.InsightSeries, er
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.Aggregation,
 		); err != nil {
 			return []types.InsightSeries{}, err
 		}
@@ -474,6 +489,7 @@ func scanInsightViewSeries(rows *sql.Rows, queryErr error) (_ []types.InsightVie
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.Aggregation,
 		); err != nil {
 			return []types.InsightViewSeries{}, err
 		}
@@ -719,6 +735,7 @@ func (s *InsightStore) CreateSeries(ctx context.Context, series types.InsightSer
 		series.GenerationMethod,
 		series.GroupBy,
 		series.RepositoryCriteria,
+		series.Aggregation,
 	))
 	var id int
 	err := row.Scan(&id)
@@ -805,6 +822,7 @@ type MatchSeriesArgs struct {
 	StepIntervalValue         int
 	GenerateFromCaptureGroups bool
 	GroupBy                   *string
+	Aggregation               *string
 }
 
 func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesArgs) (_ types.InsightSeries, found bool, _ error) {
@@ -812,9 +830,13 @@ func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesA
 	if args.GroupBy != nil {
 		groupByClause = sqlf.Sprintf("group_by = %s", *args.GroupBy)
 	}
+	aggregationClause := sqlf.Sprintf("aggregation IS NULL")
+	if args.Aggregation != nil {
+		aggregationClause = sqlf.Sprintf("aggregation = %s", *args.Aggregation)
+	}
 	where := sqlf.Sprintf(
-		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s",
-		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause,
+		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s AND %s",
+		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause, aggregationClause,
 	)
 
 	q := sqlf.Sprintf(getInsightDataSeriesSql, where)
@@ -954,8 +976,8 @@ const createInsightSeriesSql = `
 INSERT INTO insight_series (series_id, query, created_at, oldest_historical_at, last_recorded_at,
                             next_recording_after, last_snapshot_at, next_snapshot_after, repositories,
 							sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-							just_in_time, generation_method, group_by, needs_migration, repository_criteria)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s)
+							just_in_time, generation_method, group_by, needs_migration, repository_criteria, aggregation)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s, %s)
 RETURNING id;`
 
 const getInsightByViewSql = `
@@ -965,7 +987,7 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, i.group_by, i.backfill_attempts,
-i.supports_augmentation, i.repository_criteria
+i.supports_augmentation, i.repository_criteria, i.aggregation
 FROM (%s) iv
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
          JOIN insight_series i ON ivs.insight_series_id = i.id
@@ -980,7 +1002,7 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, i.group_by, i.backfill_attempts,
-i.supports_augmentation, i.repository_criteria
+i.supports_augmentation, i.repository_criteria, i.aggregation
 FROM dashboard_insight_view as dbiv
 		 JOIN insight_view iv ON iv.id = dbiv.insight_view_id
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
@@ -994,7 +1016,7 @@ const getInsightDataSeriesSql = `
 SELECT id, series_id, query, created_at, oldest_historical_at, last_recorded_at, next_recording_after,
 last_snapshot_at, next_snapshot_after, (CASE WHEN deleted_at IS NULL THEN TRUE ELSE FALSE END) AS enabled,
 sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria
+just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria, aggregation
 FROM insight_series
 WHERE %s
 `
@@ -1021,7 +1043,7 @@ SELECT iv.id, 0 as dashboard_insight_id, iv.unique_id, iv.title, iv.description,
        i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 	   iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 	   default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, i.group_by, i.backfill_attempts,
-	   i.supports_augmentation, i.repository_criteria
+	   i.supports_augmentation, i.repository_criteria, i.aggregation
 
 FROM insight_view iv
 JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
@@ -127,6 +127,10 @@ type SeriesPointsOpts struct {
 	// Whether to augment the series points data with zero values.
 	SupportsAugmentation bool
 
+	// Aggregation, if non-empty, is how the per-repository values of a numeric-compute series are
+	// combined. Values are summed across repositories otherwise.
+	Aggregation types.NumericAggregation
+
 	// Limit is the number of data points to query, if non-zero.
 	Limit int
 }
@@ -151,7 +155,7 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 	}
 	opts.Excluded = append(opts.Excluded, denylist...)
 
-	q := seriesPointsQuery(fullVectorSeriesAggregation, opts)
+	q := seriesPointsQuery(fmt.Sprintf(fullVectorSeriesAggregation, vectorAggregateExpr(opts.Aggregation)), opts)
 	pointsMap := make(map[string]*SeriesPoint)
 	captureValues := make(map[string]struct{})
 	err = s.query(ctx, q, func(sc scanner) error {
@@ -200,10 +204,11 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 	}
 
 	type loadStruct struct {
-		Time    time.Time
-		Value   float64
-		RepoID  int
-		Capture *string
+		Time       time.Time
+		Value      float64
+		ValueCount *int
+		RepoID     int
+		Capture    *string
 	}
 	type captureMap map[string]*SeriesPoint
 	mapping := make(map[time.Time]captureMap)
@@ -230,7 +235,11 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 		return denyBitmap.Contains(uint32(id))
 	}
 
-	q := `select date_trunc('seconds', sp.time) AS interval_time, max(value), repo_id, capture FROM (
+	// counts tracks how many repositories contributed to each point for maximums, and how many
+	// values the sum of each point is made of for averages.
+	counts := make(map[*SeriesPoint]int)
+
+	q := `select date_trunc('seconds', sp.time) AS interval_time, max(value), max(value_count), repo_id, capture FROM (
 					select * from series_points
 					union all
 					select * from series_points_snapshots
@@ -245,6 +254,7 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 		err = sc.Scan(
 			&row.Time,
 			&row.Value,
+			&row.ValueCount,
 			&row.RepoID,
 			&row.Capture,
 		)
@@ -257,8 +267,21 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 
 		sp := getByKey(row.Time, row.Capture)
 		sp.Capture = row.Capture
-		sp.Value += row.Value
 		sp.Time = row.Time
+		switch opts.Aggregation {
+		case types.MaxAggregation:
+			if counts[sp] == 0 || row.Value > sp.Value {
+				sp.Value = row.Value
+			}
+			counts[sp]++
+		case types.AverageAggregation:
+			// Sum the values here, and divide them by the number of values they are made of once
+			// every row is loaded.
+			sp.Value += row.Value
+			counts[sp] += valueCountOrOne(row.ValueCount)
+		default:
+			sp.Value += row.Value
+		}
 
 		return nil
 	})
@@ -272,6 +295,9 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 
 	for _, pointTime := range mapping {
 		for _, point := range pointTime {
+			if opts.Aggregation == types.AverageAggregation && counts[point] > 0 {
+				point.Value /= float64(counts[point])
+			}
 			pt := SeriesPoint{
 				SeriesID: *opts.SeriesID,
 				Time:     point.Time,
@@ -299,6 +325,64 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 	return points, err
 }
 
+// ExportPoint is a single recorded value of a series in one repository, and for one capture group
+// value if the series has them.
+type ExportPoint struct {
+	SeriesID string
+	Time     time.Time
+	RepoID   api.RepoID
+	RepoName string
+	Capture  *string
+	Value    float64
+}
+
+// ExportSeriesPoints calls fn with every point recorded for a series, broken down by repository and capture
+// value. Points are ordered by repository and capture value and then by time, so that each repository and
+// capture pair forms a contiguous time series. Unlike SeriesPoints, the points are not aggregated across
+// repositories and are not augmented with zero values.
+//
+// Points are streamed from the database as they are read, and iteration stops at the first error returned
+// by fn.
+func (s *Store) ExportSeriesPoints(ctx context.Context, opts SeriesPointsOpts, fn func(ExportPoint) error) error {
+	// 🚨 SECURITY: Repositories the current user cannot see are excluded, see SeriesPoints. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	return s.query(ctx, seriesPointsQuery(exportSeriesPointsSql, opts), func(sc scanner) error {
+		var point ExportPoint
+		if err := sc.Scan(
+			&point.SeriesID,
+			&point.Time,
+			&point.RepoID,
+			&point.RepoName,
+			&point.Capture,
+			&point.Value,
+		); err != nil {
+			return err
+		}
+		return fn(point)
+	})
+}
+
+// The repo_names join is aliased so that it doesn't collide with the join seriesPointsQuery adds for repo
+// name filters. Points of averaged numeric series hold the sum of their values, so the value exported for
+// each repository is divided by the number of values.
+const exportSeriesPointsSql = `
+SELECT sp.series_id, date_trunc('seconds', sp.time) AS interval_time, sp.repo_id, names.name, sp.capture, MAX(sp.value) / COALESCE(MAX(sp.value_count), 1)
+FROM (  select * from series_points
+		union all
+		select * from series_points_snapshots
+) AS sp
+JOIN repo_names names ON sp.repo_name_id = names.id
+%s
+WHERE %s
+GROUP BY sp.series_id, interval_time, sp.repo_id, names.name, sp.capture
+ORDER BY sp.series_id, sp.repo_id, sp.capture NULLS FIRST, interval_time
+`
+
 // Delete will delete the time series data for a particular series_id. This will hard (permanently) delete the data.
 func (s *Store) Delete(ctx context.Context, seriesId string) (err error) {
 	tx, err := s.Transact(ctx)
@@ -330,16 +414,17 @@ DELETE FROM series_points_snapshots where series_id = %s;
 // Note: the inner query could return duplicate points on its own if we merely did a SUM(value) over
 // all desired repositories. By using the sub-query, we select the per-repository maximum (thus
 // eliminating duplicate points that might have been recorded in a given interval for a given repository)
-// and then SUM the result for each repository, giving us our final total number.
+// and then SUM the result for each repository, giving us our final total number. Numeric series may
+// combine the per-repository values with another aggregate expression, see vectorAggregateExpr.
 const fullVectorSeriesAggregation = `
-SELECT sub.series_id, sub.interval_time, SUM(sub.value) as value, sub.capture FROM (
-	SELECT sp.repo_name_id, sp.series_id, date_trunc('seconds', sp.time) AS interval_time, MAX(value) as value, capture
+SELECT sub.series_id, sub.interval_time, %s as value, sub.capture FROM (
+	SELECT sp.repo_name_id, sp.series_id, date_trunc('seconds', sp.time) AS interval_time, MAX(value) as value, MAX(value_count) as value_count, capture
 	FROM (  select * from series_points
 			union all
 			select * from series_points_snapshots
 	) AS sp
-	%s
-	WHERE %s
+	%%s
+	WHERE %%s
 	GROUP BY sp.series_id, interval_time, sp.repo_name_id, capture
 	ORDER BY sp.series_id, interval_time, sp.repo_name_id
 ) sub
@@ -347,6 +432,28 @@ GROUP BY sub.series_id, sub.interval_time, sub.capture
 ORDER BY sub.series_id, sub.interval_time ASC
 `
 
+// vectorAggregateExpr returns the SQL aggregate expression that combines the per-repository values of a
+// series at a point in time. Points of averaged series hold the sum and number of the values captured in
+// each repository, so that the average is over every captured value rather than over the averages of each
+// repository. Points recorded before value counts were stored count as a single value.
+func vectorAggregateExpr(aggregation types.NumericAggregation) string {
+	switch aggregation {
+	case types.MaxAggregation:
+		return "MAX(sub.value)"
+	case types.AverageAggregation:
+		return "SUM(sub.value) / SUM(COALESCE(sub.value_count, 1))"
+	default:
+		return "SUM(sub.value)"
+	}
+}
+
+func valueCountOrOne(count *int) int {
+	if count == nil {
+		return 1
+	}
+	return *count
+}
+
 // Note that the series_points table may contain duplicate points, or points recorded at irregular
 // intervals. In specific:
 //
@@ -554,6 +661,10 @@ type RecordSeriesPointArgs struct {
 	RepoName *string
 	RepoID   *api.RepoID
 
+	// ValueCount is the number of values that the value of the point is the sum of, for
+	// numeric series that average them. It is nil for other series.
+	ValueCount *int
+
 	PersistMode PersistMode
 }
 
@@ -566,7 +677,7 @@ func (s *Store) RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointA
 	}
 	defer func() { err = tx.Done(err) }()
 
-	tableColumns := []string{"series_id", "time", "value", "repo_id", "repo_name_id", "original_repo_name_id", "capture"}
+	tableColumns := []string{"series_id", "time", "value", "repo_id", "repo_name_id", "original_repo_name_id", "capture", "value_count"}
 
 	// In our current use cases we should only ever use one of these for one function call, but this could change.
 	inserters := map[PersistMode]*batch.Inserter{
@@ -607,6 +718,7 @@ func (s *Store) RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointA
 			repoNameID,          // repo_name_id
 			repoNameID,          // original_repo_name_id
 			pt.Point.Capture,    // capture
+			pt.ValueCount,       // value_count
 		); err != nil {
 			return errors.Wrap(err, "Insert")
 		}
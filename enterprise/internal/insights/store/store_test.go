@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
@@ -219,6 +220,114 @@ func TestCountData(t *testing.T) {
 	autogold.Want("third", int(5)).Equal(t, numDataPoints)
 }
 
+func TestExportSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	timeValue := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	records := []RecordSeriesPointArgs{
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: timeValue("2020-03-02T00:00:00Z"), Value: 2, Capture: optionalString("b")},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(1),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: timeValue("2020-03-01T00:00:00Z"), Value: 1, Capture: optionalString("b")},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(1),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: timeValue("2020-03-01T00:00:00Z"), Value: 3, Capture: optionalString("a")},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(1),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: timeValue("2020-03-01T00:00:00Z"), Value: 4, Capture: optionalString("a")},
+			RepoName:    optionalString("repo2"),
+			RepoID:      optionalRepoID(2),
+			PersistMode: SnapshotMode,
+		},
+		{
+			SeriesID:    "two",
+			Point:       SeriesPoint{Time: timeValue("2020-03-01T00:00:00Z"), Value: 5},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(1),
+			PersistMode: RecordMode,
+		},
+	}
+	if err := store.RecordSeriesPoints(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+
+	export := func(opts SeriesPointsOpts) []string {
+		var got []string
+		err := store.ExportSeriesPoints(ctx, opts, func(p ExportPoint) error {
+			capture := ""
+			if p.Capture != nil {
+				capture = *p.Capture
+			}
+			got = append(got, fmt.Sprintf("%s %s %d %s %q %v", p.SeriesID, p.Time.UTC().Format(time.RFC3339), p.RepoID, p.RepoName, capture, p.Value))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	seriesID := "one"
+	autogold.Want("series one", []string{
+		`one 2020-03-01T00:00:00Z 1 repo1 "a" 3`,
+		`one 2020-03-01T00:00:00Z 1 repo1 "b" 1`,
+		`one 2020-03-02T00:00:00Z 1 repo1 "b" 2`,
+		`one 2020-03-01T00:00:00Z 2 repo2 "a" 4`,
+	}).Equal(t, export(SeriesPointsOpts{SeriesID: &seriesID}))
+
+	autogold.Want("excluded repo", []string{
+		`one 2020-03-01T00:00:00Z 2 repo2 "a" 4`,
+	}).Equal(t, export(SeriesPointsOpts{SeriesID: &seriesID, Excluded: []api.RepoID{1}}))
+
+	t.Run("stops at first error", func(t *testing.T) {
+		want := errors.New("stop")
+		calls := 0
+		err := store.ExportSeriesPoints(ctx, SeriesPointsOpts{SeriesID: &seriesID}, func(ExportPoint) error {
+			calls++
+			return want
+		})
+		if !errors.Is(err, want) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("expected a single call, got %d", calls)
+		}
+	})
+}
+
 func TestRecordSeriesPoints(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -314,6 +423,67 @@ func TestRecordSeriesPoints(t *testing.T) {
 	autogold.Want("wanted points = gotten points", stringify(want)).Equal(t, stringify(points))
 }
 
+func TestAverageSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+	optionalInt := func(v int) *int { return &v }
+
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+
+	// Four values summing to 10 in the first repository, and a single value of 30 in the second.
+	records := []RecordSeriesPointArgs{
+		{
+			SeriesID:    "average",
+			Point:       SeriesPoint{Time: current, Value: 10},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			ValueCount:  optionalInt(4),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "average",
+			Point:       SeriesPoint{Time: current, Value: 30},
+			RepoName:    optionalString("repo2"),
+			RepoID:      optionalRepoID(4),
+			ValueCount:  optionalInt(1),
+			PersistMode: RecordMode,
+		},
+	}
+	if err := store.RecordSeriesPoints(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+
+	// The average is over the five values, not over the averages of both repositories.
+	opts := SeriesPointsOpts{SeriesID: optionalString("average"), Aggregation: types.AverageAggregation}
+	points, err := store.SeriesPoints(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || points[0].Value != 8 {
+		t.Errorf("unexpected points from SeriesPoints: %v", points)
+	}
+
+	points, err = store.LoadSeriesInMem(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || points[0].Value != 8 {
+		t.Errorf("unexpected points from LoadSeriesInMem: %v", points)
+	}
+}
+
 func TestRecordSeriesPointsSnapshotOnly(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -2,6 +2,8 @@ package types
 
 import (
 	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 )
 
 // InsightViewSeries is an abstraction of a complete Code Insight. This type materializes a view with any associated series.
@@ -42,6 +44,7 @@ type InsightViewSeries struct {
 	BackfillAttempts              int32
 	SupportsAugmentation          bool
 	RepositoryCriteria            *string
+	Aggregation                   *string
 }
 
 type Insight struct {
@@ -109,6 +112,7 @@ type InsightSeries struct {
 	BackfillAttempts           int32
 	SupportsAugmentation       bool
 	RepositoryCriteria         *string
+	Aggregation                *string
 }
 
 type IntervalUnit string
@@ -129,8 +133,27 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	NumericCompute GenerationMethod = "numeric-compute"
+)
+
+// NumericAggregation is how the numbers captured by a numeric-compute series are combined into a
+// single value, both within a repository and across repositories.
+type NumericAggregation string
+
+const (
+	SumAggregation     NumericAggregation = "SUM"
+	MaxAggregation     NumericAggregation = "MAX"
+	AverageAggregation NumericAggregation = "AVERAGE"
 )
 
+func (a NumericAggregation) IsValid() bool {
+	switch a {
+	case SumAggregation, MaxAggregation, AverageAggregation:
+		return true
+	}
+	return false
+}
+
 type Dashboard struct {
 	ID           int
 	Title        string
@@ -203,6 +226,69 @@ type RecordingTime struct {
 	Snapshot  bool
 }
 
+// InsightAnnotation marks an event, such as the start of a migration, at a point in time on an
+// insight view.
+type InsightAnnotation struct {
+	ID            int
+	InsightViewID int
+	// SeriesID is the series the annotation belongs to. If nil the annotation applies to every
+	// series of the view.
+	SeriesID  *string
+	Time      time.Time
+	Label     string
+	CreatedBy *int32
+	CreatedAt time.Time
+}
+
+// AlertCondition is how the latest value of a series is compared to the threshold of an alert.
+type AlertCondition string
+
+const (
+	// AlertAbove breaches when the latest value is greater than the threshold.
+	AlertAbove AlertCondition = "ABOVE"
+	// AlertBelow breaches when the latest value is less than the threshold.
+	AlertBelow AlertCondition = "BELOW"
+	// AlertIncreasePercent breaches when the latest value increased by at least threshold percent
+	// over the previous point.
+	AlertIncreasePercent AlertCondition = "INCREASE_PERCENT"
+)
+
+func (c AlertCondition) IsValid() bool {
+	switch c {
+	case AlertAbove, AlertBelow, AlertIncreasePercent:
+		return true
+	}
+	return false
+}
+
+// InsightAlert is a threshold rule on an insight series. It is evaluated after every snapshot of
+// the series, and notifies its owner by email, Slack or webhook when it starts breaching.
+type InsightAlert struct {
+	ID            int
+	InsightViewID int
+	SeriesID      string
+	Condition     AlertCondition
+	Threshold     float64
+	// UserID owns the alert. The series is evaluated with the repository permissions of this
+	// user, and emails are sent to them.
+	UserID       int32
+	EmailEnabled bool
+	// SlackWebhookURL and WebhookURL are stored encrypted, because the URLs themselves grant
+	// permission to post notifications.
+	SlackWebhookURL *encryption.Encryptable
+	WebhookURL      *encryption.Encryptable
+	Enabled         bool
+	Breached        bool
+	LastTriggeredAt *time.Time
+	CreatedAt       time.Time
+
+	// InsightViewUniqueID, InsightViewTitle and SeriesLabel are read from the view the alert
+	// belongs to, for use in notifications.
+	InsightViewUniqueID string
+	InsightViewTitle    string
+	SeriesLabel         string
+}
+
 type SearchAggregationMode string
 
 const (
@@ -0,0 +1,16 @@
+package comby
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+)
+
+func TestMatcherForFile(t *testing.T) {
+	autogold.Want("no language or extension", ".generic").Equal(t, MatcherForFile("", "README"))
+	autogold.Want("extension", ".go").Equal(t, MatcherForFile("", "cmd/main.go"))
+	autogold.Want("unsupported extension", ".generic").Equal(t, MatcherForFile("", "BUILD.bazel"))
+	autogold.Want("language overrides extension", ".py").Equal(t, MatcherForFile("Python", "main.go"))
+	autogold.Want("csharp language", ".cs").Equal(t, MatcherForFile("csharp", ""))
+	autogold.Want("unknown language", ".generic").Equal(t, MatcherForFile("cobol", "main.go"))
+}
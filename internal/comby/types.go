@@ -78,8 +78,17 @@ type Range struct {
 
 // Match represents a range of matched characters and the matched content
 type Match struct {
-	Range   Range  `json:"range"`
-	Matched string `json:"matched"`
+	Range       Range              `json:"range"`
+	Matched     string             `json:"matched"`
+	Environment []EnvironmentEntry `json:"environment,omitempty"`
+}
+
+// EnvironmentEntry is the value a hole in the match template (like :[x])
+// bound to for a match.
+type EnvironmentEntry struct {
+	Variable string `json:"variable"`
+	Value    string `json:"value"`
+	Range    Range  `json:"range"`
 }
 
 type ChunkMatch struct {
@@ -217,6 +217,18 @@ var siteConfigSecrets = []struct {
 	{readPath: `auth\.unlockAccountLinkSigningKey`, editPaths: []string{"auth.unlockAccountLinkSigningKey"}},
 	{readPath: `dotcom.srcCliVersionCache.github.token`, editPaths: []string{"dotcom", "srcCliVersionCache", "github", "token"}},
 	{readPath: `dotcom.srcCliVersionCache.github.webhookSecret`, editPaths: []string{"dotcom", "srcCliVersionCache", "github", "webhookSecret"}},
+	{readPath: `encryption\.keys.batchChangesCredentialKey.token`, editPaths: []string{"encryption.keys", "batchChangesCredentialKey", "token"}},
+	{readPath: `encryption\.keys.batchChangesCredentialKey.appRole.secretId`, editPaths: []string{"encryption.keys", "batchChangesCredentialKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.externalServiceKey.token`, editPaths: []string{"encryption.keys", "externalServiceKey", "token"}},
+	{readPath: `encryption\.keys.externalServiceKey.appRole.secretId`, editPaths: []string{"encryption.keys", "externalServiceKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.userExternalAccountKey.token`, editPaths: []string{"encryption.keys", "userExternalAccountKey", "token"}},
+	{readPath: `encryption\.keys.userExternalAccountKey.appRole.secretId`, editPaths: []string{"encryption.keys", "userExternalAccountKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.webhookKey.token`, editPaths: []string{"encryption.keys", "webhookKey", "token"}},
+	{readPath: `encryption\.keys.webhookKey.appRole.secretId`, editPaths: []string{"encryption.keys", "webhookKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.webhookLogKey.token`, editPaths: []string{"encryption.keys", "webhookLogKey", "token"}},
+	{readPath: `encryption\.keys.webhookLogKey.appRole.secretId`, editPaths: []string{"encryption.keys", "webhookLogKey", "appRole", "secretId"}},
+	{readPath: `encryption\.keys.executorSecretKey.token`, editPaths: []string{"encryption.keys", "executorSecretKey", "token"}},
+	{readPath: `encryption\.keys.executorSecretKey.appRole.secretId`, editPaths: []string{"encryption.keys", "executorSecretKey", "appRole", "secretId"}},
 }
 
 // UnredactSecrets unredacts unchanged secrets back to their original value for
@@ -250,6 +250,39 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 	assert.Equal(t, want, redacted.Site)
 }
 
+func TestRedactSecrets_VaultEncryptionKeys(t *testing.T) {
+	const cfgWithVaultKeys = `{
+  "encryption.keys": {
+    "externalServiceKey": {
+      "type": "vault",
+      "address": "https://vault.example.com",
+      "keyName": "sourcegraph",
+      "token": "%s"
+    },
+    "webhookKey": {
+      "type": "vault",
+      "address": "https://vault.example.com",
+      "keyName": "sourcegraph",
+      "appRole": {
+        "roleId": "sourcegraph",
+        "secretId": "%s"
+      }
+    }
+  }
+}`
+	site := fmt.Sprintf(cfgWithVaultKeys, "vault-token", "vault-secret-id")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfgWithVaultKeys, redactedSecret, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.NotContains(t, unredacted, redactedSecret)
+	assert.Contains(t, unredacted, `"token": "vault-token"`)
+	assert.Contains(t, unredacted, `"secretId": "vault-secret-id"`)
+}
+
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		executorsAccessToken,
@@ -20,11 +20,13 @@ func NewRecordEncrypter(db DB) *RecordEncrypter {
 	}
 }
 
+// Count returns the number of encrypted and unencrypted records. Records without a key ID
+// are unencrypted.
 func (s *RecordEncrypter) Count(ctx context.Context, config EncryptionConfig) (numEncrypted int, numUnencrypted int, _ error) {
 	countQuery := sqlf.Sprintf(`
 		SELECT
-			(SELECT COUNT(*) FROM %s WHERE %s NOT IN ('', %s)) AS   encrypted,
-			(SELECT COUNT(*) FROM %s WHERE %s     IN ('', %s)) AS unencrypted
+			(SELECT COUNT(*) FROM %s WHERE %s NOT IN ('', %s)) AS encrypted,
+			(SELECT COUNT(*) FROM %s WHERE COALESCE(%s, '') IN ('', %s)) AS unencrypted
 		`,
 		quote(config.TableName),
 		quote(config.KeyIDFieldName),
@@ -53,7 +55,7 @@ func (s *RecordEncrypter) EncryptBatch(ctx context.Context, config EncryptionCon
 	defer func() { err = tx.Done(err) }()
 
 	values, err := config.Scan(tx.Query(ctx, sqlf.Sprintf(
-		"SELECT %s FROM %s WHERE %s IN ('', %s) ORDER BY %s ASC LIMIT %s FOR UPDATE SKIP LOCKED",
+		"SELECT %s FROM %s WHERE COALESCE(%s, '') IN ('', %s) ORDER BY %s ASC LIMIT %s FOR UPDATE SKIP LOCKED",
 		fields(config),
 		quote(config.TableName),
 		quote(config.KeyIDFieldName),
@@ -129,6 +131,113 @@ func (s *RecordEncrypter) DecryptBatch(ctx context.Context, config EncryptionCon
 	return len(decryptedValues), nil
 }
 
+// CountStale returns the number of records encrypted with a version of the configured key
+// other than version, its current version, which ReencryptBatch re-encrypts.
+func (s *RecordEncrypter) CountStale(ctx context.Context, config EncryptionConfig, version encryption.KeyVersion) (numStale int, _ error) {
+	countQuery := sqlf.Sprintf(
+		"SELECT COUNT(*) FROM %s WHERE %s NOT IN ('', %s, %s)",
+		quote(config.TableName),
+		quote(config.KeyIDFieldName),
+		encryption.UnmigratedEncryptionKeyID,
+		version.JSON(),
+	)
+	if err := s.QueryRow(ctx, countQuery).Scan(&numStale); err != nil {
+		return 0, err
+	}
+
+	return numStale, nil
+}
+
+// ReencryptionBatch is the outcome of re-encrypting a batch of records.
+type ReencryptionBatch struct {
+	// Reencrypted is the number of records re-encrypted with the current key version.
+	Reencrypted int
+	// Failed is the number of records that could not be decrypted with the configured key
+	// or any of the configured decryption keys, and were left as is.
+	Failed int
+	// LastID is the highest ID of the records in the batch, from which the next batch
+	// resumes. It is zero if no stale record is left after the given ID.
+	LastID int
+}
+
+// ReencryptBatch re-encrypts a batch of records that are encrypted with a version of the
+// configured key other than version, its current version, such as after the key was
+// rotated. Callers resolve the version, which is a remote call for most keys, so that it
+// can be shared by all tables encrypted with the same key. Only records with an ID greater
+// than afterID are considered, so that callers can page past records that fail to decrypt.
+// Progress is recorded in the key ID of each record, so an interrupted re-encryption
+// resumes where it stopped.
+func (s *RecordEncrypter) ReencryptBatch(ctx context.Context, config EncryptionConfig, version encryption.KeyVersion, afterID int) (batch ReencryptionBatch, err error) {
+	key := config.Key()
+	if key == nil {
+		return batch, nil
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return batch, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	values, err := config.Scan(tx.Query(ctx, sqlf.Sprintf(
+		"SELECT %s FROM %s WHERE %s NOT IN ('', %s, %s) AND %s > %s ORDER BY %s ASC LIMIT %s FOR UPDATE SKIP LOCKED",
+		fields(config),
+		quote(config.TableName),
+		quote(config.KeyIDFieldName),
+		encryption.UnmigratedEncryptionKeyID,
+		version.JSON(),
+		quote(config.IDFieldName),
+		afterID,
+		quote(config.IDFieldName),
+		config.Limit,
+	)))
+	if err != nil {
+		return batch, err
+	}
+
+	for id := range values {
+		if id > batch.LastID {
+			batch.LastID = id
+		}
+	}
+
+	decryptedValues, err := decryptValues(ctx, key, values)
+	if err != nil {
+		// Decrypt the records one by one to skip those that fail to, which are likely
+		// encrypted with a key that is neither the current key nor one of the decryption
+		// keys.
+		decryptedValues = make(map[int][]string, len(values))
+		for id, ev := range values {
+			vs, err := decryptValues(ctx, key, map[int]Encrypted{id: ev})
+			if err != nil {
+				batch.Failed++
+				continue
+			}
+			decryptedValues[id] = vs[id]
+		}
+	}
+
+	encryptedValues, err := encryptValues(ctx, key, decryptedValues)
+	if err != nil {
+		return ReencryptionBatch{}, err
+	}
+
+	for id, ev := range encryptedValues {
+		if err := tx.Exec(ctx, sqlf.Sprintf(
+			"UPDATE %s SET %s WHERE %s = %s",
+			quote(config.TableName),
+			updatePairs(config, ev),
+			quote(config.IDFieldName),
+			id,
+		)); err != nil {
+			return ReencryptionBatch{}, err
+		}
+		batch.Reencrypted++
+	}
+
+	return batch, nil
+}
+
 func fields(c EncryptionConfig) *sqlf.Query {
 	names := make([]*sqlf.Query, 0, len(c.EncryptedFieldNames)+2)
 	names = append(names, quote(c.IDFieldName), quote(c.KeyIDFieldName))
@@ -7,9 +7,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
 type EncryptionConfig struct {
+	// Name identifies the config in metrics and logs. It defaults to the table name,
+	// and only needs to be set for tables with more than one config.
+	Name                string
 	TableName           string
 	IDFieldName         string
 	KeyIDFieldName      string
@@ -20,6 +24,14 @@ type EncryptionConfig struct {
 	Limit               int
 }
 
+// Label returns the name of the config, which defaults to its table name.
+func (c EncryptionConfig) Label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.TableName
+}
+
 var EncryptionConfigs = []EncryptionConfig{
 	externalServicesEncryptionConfig,
 	userExternalAccountsEncryptionConfig,
@@ -27,6 +39,12 @@ var EncryptionConfigs = []EncryptionConfig{
 	batchChangesSiteCredentialsEncryptionConfig,
 	webhooklogsEncryptionConfig,
 	executorSecretsEncryptionConfig,
+	webhooksEncryptionConfig,
+	codeMonitorWebhooksEncryptionConfig,
+	codeMonitorWebhookURLsEncryptionConfig,
+	codeMonitorSlackWebhooksEncryptionConfig,
+	codeMonitorTeamsWebhooksEncryptionConfig,
+	codeMonitorChatWebhooksEncryptionConfig,
 }
 
 var externalServicesEncryptionConfig = EncryptionConfig{
@@ -92,6 +110,113 @@ var executorSecretsEncryptionConfig = EncryptionConfig{
 	Limit:               5,
 }
 
+var webhooksEncryptionConfig = EncryptionConfig{
+	TableName:           "webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"secret"},
+	Scan:                basestore.NewMapScanner(scanEncryptedNullString),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	Limit:               100,
+}
+
+// Code monitor actions are defined in enterprise, but their tables are part of the
+// frontend schema and their values are encrypted with the webhook key.
+
+var codeMonitorWebhooksEncryptionConfig = EncryptionConfig{
+	TableName:           "cm_webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"headers", "secret"},
+	Scan:                basestore.NewMapScanner(scanEncryptedNullStringPair),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	Limit:               100,
+}
+
+// Webhook URLs have a key ID of their own, as they were stored in plaintext next to
+// encrypted headers and secrets before.
+var codeMonitorWebhookURLsEncryptionConfig = EncryptionConfig{
+	Name:                "cm_webhooks_url",
+	TableName:           "cm_webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "url_encryption_key_id",
+	EncryptedFieldNames: []string{"url"},
+	Scan:                basestore.NewMapScanner(scanEncryptedNullString),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	Limit:               100,
+}
+
+var codeMonitorSlackWebhooksEncryptionConfig = EncryptionConfig{
+	TableName:           "cm_slack_webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"url"},
+	Scan:                basestore.NewMapScanner(scanEncryptedNullString),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	Limit:               100,
+}
+
+var codeMonitorTeamsWebhooksEncryptionConfig = EncryptionConfig{
+	TableName:           "cm_teams_webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"url"},
+	Scan:                basestore.NewMapScanner(scanEncryptedNullString),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	Limit:               100,
+}
+
+var codeMonitorChatWebhooksEncryptionConfig = EncryptionConfig{
+	TableName:           "cm_chat_webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"url"},
+	Scan:                basestore.NewMapScanner(scanEncryptedNullString),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	Limit:               100,
+}
+
+// EncryptedDatabase is a database other than the frontend database whose tables
+// hold encrypted records, such as the codeinsights database.
+type EncryptedDatabase struct {
+	// Name identifies the database in the names of the routines handling it.
+	Name string
+	// Open connects to the database.
+	Open func(observationCtx *observation.Context) (DB, error)
+	// Configs are the encrypted tables of the database.
+	Configs []EncryptionConfig
+}
+
+var encryptedDatabases []EncryptedDatabase
+
+// RegisterEncryptedDatabase registers a database other than the frontend database,
+// so that the record encrypter of the worker handles its encrypted tables too. It
+// must be called before the worker creates the routines of its jobs.
+func RegisterEncryptedDatabase(db EncryptedDatabase) {
+	encryptedDatabases = append(encryptedDatabases, db)
+}
+
+// EncryptedDatabases returns the databases registered with RegisterEncryptedDatabase.
+func EncryptedDatabases() []EncryptedDatabase {
+	return encryptedDatabases
+}
+
+// CodeInsightsEncryptionConfigs are the encrypted tables of the codeinsights
+// database, which the enterprise worker registers with RegisterEncryptedDatabase.
+var CodeInsightsEncryptionConfigs = []EncryptionConfig{
+	insightViewAlertsEncryptionConfig,
+}
+
+var insightViewAlertsEncryptionConfig = EncryptionConfig{
+	TableName:           "insight_view_alerts",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"slack_webhook_url", "webhook_url"},
+	Scan:                basestore.NewMapScanner(scanEncryptedNullStringPair),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	Limit:               100,
+}
+
 func scanEncryptedString(scanner dbutil.Scanner) (id int, e Encrypted, err error) {
 	e.Values = make([]string, 1)
 	err = scanner.Scan(&id, &e.KeyID, &e.Values[0])
@@ -110,3 +235,17 @@ func scanEncryptedBytea(scanner dbutil.Scanner) (id int, e Encrypted, err error)
 	e.Values = []string{string(bs)}
 	return
 }
+
+// scanEncryptedNullString scans a record whose key ID and value are nullable. NULL
+// values are scanned as empty strings.
+func scanEncryptedNullString(scanner dbutil.Scanner) (id int, e Encrypted, err error) {
+	e.Values = make([]string, 1)
+	err = scanner.Scan(&id, &dbutil.NullString{S: &e.KeyID}, &dbutil.NullString{S: &e.Values[0]})
+	return
+}
+
+func scanEncryptedNullStringPair(scanner dbutil.Scanner) (id int, e Encrypted, err error) {
+	e.Values = make([]string, 2)
+	err = scanner.Scan(&id, &dbutil.NullString{S: &e.KeyID}, &dbutil.NullString{S: &e.Values[0]}, &dbutil.NullString{S: &e.Values[1]})
+	return
+}
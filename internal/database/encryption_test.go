@@ -137,6 +137,207 @@ func TestRecordEncrypter(t *testing.T) {
 	}
 }
 
+func TestRecordEncrypter_ReencryptBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	key := &versionedBase64Key{version: "1"}
+	encrypter := NewRecordEncrypter(db)
+
+	if err := encrypter.Exec(ctx, sqlf.Sprintf("CREATE TABLE test_encryptable (id int, encryption_key_id text, data text)")); err != nil {
+		t.Fatalf("failed to create test table: %s", err)
+	}
+
+	oldKeyID := testEncryptionKeyID(key)
+	for i := 0; i < 12; i++ {
+		data := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("data-%d", i)))
+		if i == 5 {
+			// Not encrypted with the key, so it cannot be decrypted
+			data = "!invalid"
+		}
+		if err := encrypter.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (%s, %s, %s)", i+1, oldKeyID, data)); err != nil {
+			t.Fatalf("failed to insert test data: %s", err)
+		}
+	}
+
+	config := EncryptionConfig{
+		TableName:           "test_encryptable",
+		IDFieldName:         "id",
+		KeyIDFieldName:      "encryption_key_id",
+		EncryptedFieldNames: []string{"data"},
+		Scan:                basestore.NewMapScanner(scanEncryptedString),
+		Key:                 func() encryption.Key { return key },
+		Limit:               5,
+	}
+
+	// Nothing to re-encrypt until the key is rotated
+	version, err := key.Version(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error getting key version: %s", err)
+	}
+	numStale, err := encrypter.CountStale(ctx, config, version)
+	if err != nil {
+		t.Fatalf("unexpected error counting records: %s", err)
+	}
+	if numStale != 0 {
+		t.Errorf("unexpected numStale. want=%d have=%d", 0, numStale)
+	}
+
+	key.version = "2"
+	if version, err = key.Version(ctx); err != nil {
+		t.Fatalf("unexpected error getting key version: %s", err)
+	}
+	var batches []ReencryptionBatch
+	for afterID := 0; ; {
+		batch, err := encrypter.ReencryptBatch(ctx, config, version, afterID)
+		if err != nil {
+			t.Fatalf("unexpected error re-encrypting batch: %s", err)
+		}
+		batches = append(batches, batch)
+		if batch.LastID == 0 {
+			break
+		}
+		afterID = batch.LastID
+	}
+	want := []ReencryptionBatch{
+		{Reencrypted: 4, Failed: 1, LastID: 5},
+		{Reencrypted: 5, LastID: 10},
+		{Reencrypted: 2, LastID: 12},
+		{},
+	}
+	if diff := cmp.Diff(want, batches); diff != "" {
+		t.Errorf("unexpected batches (-want +got):\n%s", diff)
+	}
+
+	numStale, err = encrypter.CountStale(ctx, config, version)
+	if err != nil {
+		t.Fatalf("unexpected error counting records: %s", err)
+	}
+	if numStale != 1 {
+		t.Errorf("unexpected numStale. want=%d have=%d", 1, numStale)
+	}
+
+	values, err := config.Scan(encrypter.Query(ctx, sqlf.Sprintf("SELECT id, encryption_key_id, data FROM test_encryptable")))
+	if err != nil {
+		t.Fatalf("failed to query data: %s", err)
+	}
+	for id, ev := range values {
+		if id == 6 {
+			if ev.KeyID != oldKeyID {
+				t.Errorf("unexpected key identifier for record that failed to decrypt. want=%q have=%q", oldKeyID, ev.KeyID)
+			}
+			continue
+		}
+		if want := testEncryptionKeyID(key); ev.KeyID != want {
+			t.Errorf("unexpected key identifier. want=%q have=%q", want, ev.KeyID)
+		}
+		decrypted, err := encryption.MaybeDecrypt(ctx, key, ev.Values[0], ev.KeyID)
+		if err != nil {
+			t.Fatalf("failed to decrypt record %d: %s", id, err)
+		}
+		if want := fmt.Sprintf("data-%d", id-1); decrypted != want {
+			t.Errorf("unexpected data. want=%q have=%q", want, decrypted)
+		}
+	}
+}
+
+func TestEncryptValuesBatch(t *testing.T) {
+	ctx := context.Background()
+	key := &batchBase64Key{}
+
+	encrypted, err := encryptValues(ctx, key, map[int][]string{
+		1: {"foo", ""},
+		2: {"bar", "baz"},
+		3: {""},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error encrypting values: %s", err)
+	}
+	keyID := testEncryptionKeyID(key)
+	wantEncrypted := map[int]Encrypted{
+		1: {Values: []string{"Zm9v", ""}, KeyID: keyID},
+		2: {Values: []string{"YmFy", "YmF6"}, KeyID: keyID},
+		3: {Values: []string{""}, KeyID: keyID},
+	}
+	if diff := cmp.Diff(wantEncrypted, encrypted); diff != "" {
+		t.Errorf("unexpected encrypted values (-want +got):\n%s", diff)
+	}
+
+	decrypted, err := decryptValues(ctx, key, encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error decrypting values: %s", err)
+	}
+	wantDecrypted := map[int][]string{
+		1: {"foo", ""},
+		2: {"bar", "baz"},
+		3: {""},
+	}
+	if diff := cmp.Diff(wantDecrypted, decrypted); diff != "" {
+		t.Errorf("unexpected decrypted values (-want +got):\n%s", diff)
+	}
+
+	// All set values are encrypted and decrypted in a single call.
+	if key.encryptBatchCalls != 1 || key.decryptBatchCalls != 1 {
+		t.Errorf("unexpected batch calls. want=1,1 have=%d,%d", key.encryptBatchCalls, key.decryptBatchCalls)
+	}
+
+	// Values that fail to decrypt in a batch are reported as by MaybeDecrypt.
+	encrypted[2] = Encrypted{Values: []string{"!invalid"}, KeyID: `{"Type":"other"}`}
+	if _, err := decryptValues(ctx, key, encrypted); err == nil || err.Error() != "key mismatch: value is encrypted with an encryption key distinct from the one available in site-config" {
+		t.Errorf("unexpected error decrypting values: %v", err)
+	}
+}
+
+// batchBase64Key is a base64Key that counts the batches it encrypts and decrypts.
+type batchBase64Key struct {
+	base64Key
+	encryptBatchCalls int
+	decryptBatchCalls int
+}
+
+func (k *batchBase64Key) EncryptBatch(ctx context.Context, plaintexts [][]byte) ([][]byte, error) {
+	k.encryptBatchCalls++
+	ciphertexts := make([][]byte, 0, len(plaintexts))
+	for _, plaintext := range plaintexts {
+		ciphertext, err := k.Encrypt(ctx, plaintext)
+		if err != nil {
+			return nil, err
+		}
+		ciphertexts = append(ciphertexts, ciphertext)
+	}
+	return ciphertexts, nil
+}
+
+func (k *batchBase64Key) DecryptBatch(ctx context.Context, ciphertexts [][]byte) ([]*encryption.Secret, error) {
+	k.decryptBatchCalls++
+	secrets := make([]*encryption.Secret, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		secret, err := k.Decrypt(ctx, ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+type versionedBase64Key struct {
+	base64Key
+	version string
+}
+
+func (k *versionedBase64Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
+	return encryption.KeyVersion{
+		Type:    "base64",
+		Name:    "base64",
+		Version: k.version,
+	}, nil
+}
+
 type base64Key struct{}
 
 func (k *base64Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type Encrypted struct {
@@ -11,30 +12,105 @@ type Encrypted struct {
 	KeyID  string
 }
 
+// valuePosition identifies a value of a record.
+type valuePosition struct {
+	id    int
+	index int
+}
+
+// encryptValues encrypts the set values of all records at once, which is a single request
+// for keys that support batches.
 func encryptValues(ctx context.Context, key encryption.Key, m map[int][]string) (map[int]Encrypted, error) {
-	encryptedMap := make(map[int]Encrypted, len(m))
+	var (
+		positions  []valuePosition
+		plaintexts []string
+	)
 	for id, vs := range m {
-		var (
-			keyID           string
-			encryptedValues = make([]string, 0, len(vs))
-		)
-		for _, v := range vs {
-			ev, id, err := encryption.MaybeEncrypt(ctx, key, v)
-			if err != nil {
-				return nil, err
+		for i, v := range vs {
+			if v == "" {
+				// Keep unset values empty, as readers of some tables tell unset and
+				// encrypted values apart by their emptiness.
+				continue
 			}
-
-			keyID = id
-			encryptedValues = append(encryptedValues, ev)
+			positions = append(positions, valuePosition{id: id, index: i})
+			plaintexts = append(plaintexts, v)
 		}
+	}
+
+	encryptedValues, keyID, err := encryption.MaybeEncryptBatch(ctx, key, plaintexts)
+	if err != nil {
+		return nil, err
+	}
 
-		encryptedMap[id] = Encrypted{Values: encryptedValues, KeyID: keyID}
+	encryptedMap := make(map[int]Encrypted, len(m))
+	for id, vs := range m {
+		encryptedMap[id] = Encrypted{Values: append(make([]string, 0, len(vs)), vs...)}
+	}
+	set := make(map[int]bool, len(m))
+	for i, p := range positions {
+		ev := encryptedMap[p.id]
+		ev.Values[p.index] = encryptedValues[i]
+		ev.KeyID = keyID
+		encryptedMap[p.id] = ev
+		set[p.id] = true
+	}
+
+	if key != nil && len(set) < len(m) {
+		// Mark records with only unset values as migrated all the same.
+		version, err := key.Version(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get encryption key version")
+		}
+		for id, ev := range encryptedMap {
+			if !set[id] {
+				ev.KeyID = version.JSON()
+				encryptedMap[id] = ev
+			}
+		}
 	}
 
 	return encryptedMap, nil
 }
 
+// decryptValues decrypts the encrypted values of all records at once, which is a single
+// request for keys that support batches.
 func decryptValues(ctx context.Context, key encryption.Key, m map[int]Encrypted) (map[int][]string, error) {
+	var (
+		positions   []valuePosition
+		ciphertexts [][]byte
+	)
+	decryptedMap := make(map[int][]string, len(m))
+	for id, ev := range m {
+		decryptedMap[id] = append(make([]string, 0, len(ev.Values)), ev.Values...)
+		if ev.KeyID == "" || ev.KeyID == encryption.UnmigratedEncryptionKeyID {
+			continue
+		}
+		for i, v := range ev.Values {
+			if v != "" {
+				positions = append(positions, valuePosition{id: id, index: i})
+				ciphertexts = append(ciphertexts, []byte(v))
+			}
+		}
+	}
+	if len(ciphertexts) == 0 {
+		return decryptedMap, nil
+	}
+
+	if key != nil {
+		if secrets, err := encryption.DecryptBatch(ctx, key, ciphertexts); err == nil {
+			for i, p := range positions {
+				decryptedMap[p.id][p.index] = secrets[i].Secret()
+			}
+			return decryptedMap, nil
+		}
+	}
+
+	// Decrypt values one by one, so that errors such as key mismatches are reported
+	// as MaybeDecrypt tells them apart.
+	return decryptEachValue(ctx, key, m)
+}
+
+func decryptEachValue(ctx context.Context, key encryption.Key, m map[int]Encrypted) (map[int][]string, error) {
 	decryptedMap := make(map[int][]string, len(m))
 	for id, ev := range m {
 		decryptedValues := make([]string, 0, len(ev.Values))
@@ -2,12 +2,16 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/sourcegraph/log"
 
@@ -142,6 +146,10 @@ func (s *userExternalAccountsStore) LookupUserAndSave(ctx context.Context, spec
 			return 0, err
 		}
 	}
+	loginSHA256, err := externalAccountLoginSHA256(ctx, spec.ServiceType, data.Data)
+	if err != nil {
+		return 0, err
+	}
 
 	err = s.Handle().QueryRowContext(ctx, `
 UPDATE user_external_accounts
@@ -149,6 +157,7 @@ SET
 	auth_data = $5,
 	account_data = $6,
 	encryption_key_id = $7,
+	login_sha256 = $8,
 	updated_at = now(),
 	expired_at = NULL
 WHERE
@@ -158,7 +167,7 @@ AND client_id = $3
 AND account_id = $4
 AND deleted_at IS NULL
 RETURNING user_id
-`, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID, encryptedAuthData, encryptedAccountData, keyID).Scan(&userID)
+`, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID, encryptedAuthData, encryptedAccountData, keyID, loginSHA256).Scan(&userID)
 	if err == sql.ErrNoRows {
 		err = userExternalAccountNotFoundError{[]any{spec}}
 	}
@@ -217,6 +226,10 @@ AND deleted_at IS NULL
 			return err
 		}
 	}
+	loginSHA256, err := externalAccountLoginSHA256(ctx, spec.ServiceType, data.Data)
+	if err != nil {
+		return err
+	}
 
 	// Update the external account (it exists).
 	res, err := tx.ExecResult(ctx, sqlf.Sprintf(`
@@ -225,6 +238,7 @@ SET
 	auth_data = %s,
 	account_data = %s,
 	encryption_key_id = %s,
+	login_sha256 = %s,
 	updated_at = now(),
 	expired_at = NULL
 WHERE
@@ -234,7 +248,7 @@ AND client_id = %s
 AND account_id = %s
 AND user_id = %s
 AND deleted_at IS NULL
-`, encryptedAuthData, encryptedAccountData, keyID, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID, userID))
+`, encryptedAuthData, encryptedAccountData, keyID, loginSHA256, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID, userID))
 	if err != nil {
 		return err
 	}
@@ -281,11 +295,15 @@ func (s *userExternalAccountsStore) Insert(ctx context.Context, userID int32, sp
 			return err
 		}
 	}
+	loginSHA256, err := externalAccountLoginSHA256(ctx, spec.ServiceType, data.Data)
+	if err != nil {
+		return err
+	}
 
 	return s.Exec(ctx, sqlf.Sprintf(`
-INSERT INTO user_external_accounts (user_id, service_type, service_id, client_id, account_id, auth_data, account_data, encryption_key_id)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
-`, userID, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID, encryptedAuthData, encryptedAccountData, keyID))
+INSERT INTO user_external_accounts (user_id, service_type, service_id, client_id, account_id, auth_data, account_data, encryption_key_id, login_sha256)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+`, userID, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID, encryptedAuthData, encryptedAccountData, keyID, loginSHA256))
 }
 
 func (s *userExternalAccountsStore) TouchExpired(ctx context.Context, ids ...int32) error {
@@ -371,6 +389,11 @@ type ExternalAccountsListOptions struct {
 	ClientID    string
 	AccountID   string
 
+	// Logins, if set, restricts the list to code host accounts whose login, as found in
+	// their account data, is one of the given logins, compared case-insensitively. Logins
+	// are only known for GitHub, GitLab, Bitbucket Server and Bitbucket Cloud accounts.
+	Logins []string
+
 	// Only one of these should be set
 	ExcludeExpired bool
 	OnlyExpired    bool
@@ -393,11 +416,23 @@ func (s *userExternalAccountsStore) List(ctx context.Context, opt ExternalAccoun
 		tr.Finish()
 	}()
 
+	if len(opt.Logins) > 0 {
+		if err := s.hashMissingLogins(ctx, opt.ServiceType); err != nil {
+			return nil, err
+		}
+	}
+
 	conds := s.listSQL(opt)
 	return s.listBySQL(ctx, sqlf.Sprintf("WHERE %s ORDER BY id ASC %s", sqlf.Join(conds, "AND"), opt.LimitOffset.SQL()))
 }
 
 func (s *userExternalAccountsStore) Count(ctx context.Context, opt ExternalAccountsListOptions) (int, error) {
+	if len(opt.Logins) > 0 {
+		if err := s.hashMissingLogins(ctx, opt.ServiceType); err != nil {
+			return 0, err
+		}
+	}
+
 	conds := s.listSQL(opt)
 	q := sqlf.Sprintf("SELECT COUNT(*) FROM user_external_accounts WHERE %s", sqlf.Join(conds, "AND"))
 	var count int
@@ -483,6 +518,13 @@ func (s *userExternalAccountsStore) listSQL(opt ExternalAccountsListOptions) (co
 	if opt.AccountID != "" {
 		conds = append(conds, sqlf.Sprintf("account_id=%s", opt.AccountID))
 	}
+	if len(opt.Logins) > 0 {
+		hashes := make([]string, 0, len(opt.Logins))
+		for _, login := range opt.Logins {
+			hashes = append(hashes, hashLogin(login))
+		}
+		conds = append(conds, sqlf.Sprintf("login_sha256 = ANY(%s)", pq.Array(hashes)))
+	}
 	if opt.ExcludeExpired {
 		conds = append(conds, sqlf.Sprintf("expired_at IS NULL"))
 	}
@@ -492,3 +534,97 @@ func (s *userExternalAccountsStore) listSQL(opt ExternalAccountsListOptions) (co
 
 	return conds
 }
+
+// hashMissingLoginsBatchSize is the number of accounts hashMissingLogins decrypts at once.
+const hashMissingLoginsBatchSize = 500
+
+// hashMissingLogins stores the login hashes of the code host accounts of given service
+// type, or of all types if empty, that do not have one yet. Those are the accounts saved
+// with encrypted data before logins were hashed, which the migration adding the hashes
+// could not read. Each of them is decrypted once, and is matched by its hash afterwards.
+func (s *userExternalAccountsStore) hashMissingLogins(ctx context.Context, serviceType string) error {
+	var serviceTypes []string
+	for t := range externalAccountLoginFields {
+		if serviceType == "" || serviceType == t {
+			serviceTypes = append(serviceTypes, t)
+		}
+	}
+	if len(serviceTypes) == 0 {
+		return nil
+	}
+
+	for {
+		accounts, err := s.listBySQL(ctx, sqlf.Sprintf(
+			"WHERE login_sha256 IS NULL AND deleted_at IS NULL AND service_type = ANY(%s) ORDER BY id ASC LIMIT %d",
+			pq.Array(serviceTypes),
+			hashMissingLoginsBatchSize,
+		))
+		if err != nil || len(accounts) == 0 {
+			return err
+		}
+
+		ids := make([]int32, 0, len(accounts))
+		hashes := make([]string, 0, len(accounts))
+		for _, account := range accounts {
+			hash, err := externalAccountLoginSHA256(ctx, account.ServiceType, account.Data)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, account.ID)
+			hashes = append(hashes, hash)
+		}
+
+		// Accounts saved since they were listed already have the hash of their new data.
+		if err := s.Exec(ctx, sqlf.Sprintf(`
+UPDATE user_external_accounts a
+SET login_sha256 = h.login_sha256
+FROM unnest(%s::integer[], %s::text[]) AS h(id, login_sha256)
+WHERE a.id = h.id AND a.login_sha256 IS NULL
+`, pq.Array(ids), pq.Array(hashes))); err != nil {
+			return err
+		}
+
+		if len(accounts) < hashMissingLoginsBatchSize {
+			return nil
+		}
+	}
+}
+
+// externalAccountLoginFields are the fields of the account data of code host
+// accounts that hold their login, by service type.
+var externalAccountLoginFields = map[string]string{
+	extsvc.TypeGitHub:          "login",
+	extsvc.TypeGitLab:          "username",
+	extsvc.TypeBitbucketServer: "slug",
+	extsvc.TypeBitbucketCloud:  "username",
+}
+
+// externalAccountLoginSHA256 returns the hash of the login found in the account data,
+// which is stored so that accounts can be matched by login without decrypting their
+// data. It returns an empty string if the account does not carry a login.
+func externalAccountLoginSHA256(ctx context.Context, serviceType string, data *extsvc.EncryptableData) (string, error) {
+	field, ok := externalAccountLoginFields[serviceType]
+	if !ok || data == nil {
+		return "", nil
+	}
+	serialized, err := data.Encryptable.Decrypt(ctx)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(serialized), &fields); err != nil {
+		// Account data that is not a JSON object carries no login.
+		return "", nil
+	}
+	login, _ := fields[field].(string)
+	if login == "" {
+		return "", nil
+	}
+	return hashLogin(login), nil
+}
+
+// hashLogin returns the hex SHA-256 of the lowercase login.
+func hashLogin(login string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(login)))
+	return hex.EncodeToString(sum[:])
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/log/logtest"
@@ -337,6 +339,70 @@ func TestExternalAccounts_List(t *testing.T) {
 	}
 }
 
+func TestExternalAccounts_ListLogins(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	accounts := []struct {
+		serviceType string
+		data        string
+		store       UserExternalAccountsStore
+	}{
+		{serviceType: extsvc.TypeGitHub, data: `{"login":"Alice"}`, store: db.UserExternalAccounts()},
+		{serviceType: extsvc.TypeGitLab, data: `{"username":"alice"}`, store: db.UserExternalAccounts()},
+		{serviceType: extsvc.TypeGitHub, data: `{"login":"bob"}`, store: db.UserExternalAccounts()},
+		{serviceType: extsvc.TypeBitbucketServer, data: `{"name":"alice","slug":"carol"}`, store: db.UserExternalAccounts()},
+		{serviceType: extsvc.TypeGitHub, data: `{"login":"dave"}`, store: db.UserExternalAccounts().WithEncryptionKey(et.TestKey{})},
+		{serviceType: extsvc.TypeGitLab, data: `{"username":"Carol"}`, store: db.UserExternalAccounts().WithEncryptionKey(et.TestKey{})},
+		{serviceType: extsvc.TypeGitHub, data: `{"login":"alice"}`, store: db.UserExternalAccounts().WithEncryptionKey(et.TestKey{})},
+	}
+	userIDs := make([]int32, 0, len(accounts))
+	for i, a := range accounts {
+		spec := extsvc.AccountSpec{ServiceType: a.serviceType, ServiceID: "https://example.com/", ClientID: "c", AccountID: strconv.Itoa(i)}
+		data := extsvc.AccountData{Data: extsvc.NewUnencryptedData(json.RawMessage(a.data))}
+		id, err := a.store.CreateUserAndSave(ctx, NewUser{Username: fmt.Sprintf("u%d", i)}, spec, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	// Encrypted accounts saved before logins were hashed are hashed when first listed.
+	if _, err := db.UserExternalAccounts().ExecResult(ctx, sqlf.Sprintf("UPDATE user_external_accounts SET login_sha256 = NULL WHERE user_id = %s", userIDs[6])); err != nil {
+		t.Fatal(err)
+	}
+
+	store := db.UserExternalAccounts().WithEncryptionKey(et.TestKey{})
+	// The second listing matches the account by the hash stored by the first.
+	for i := 0; i < 2; i++ {
+		got, err := store.List(ctx, ExternalAccountsListOptions{Logins: []string{"ALICE", "carol"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotUserIDs := make([]int32, 0, len(got))
+		for _, a := range got {
+			gotUserIDs = append(gotUserIDs, a.UserID)
+		}
+		want := []int32{userIDs[0], userIDs[1], userIDs[3], userIDs[5], userIDs[6]}
+		if diff := cmp.Diff(want, gotUserIDs); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	}
+
+	count, err := store.Count(ctx, ExternalAccountsListOptions{ServiceType: extsvc.TypeGitHub, Logins: []string{"dave"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("got count %d, want 1", count)
+	}
+}
+
 func TestExternalAccounts_Encryption(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -151,6 +151,9 @@ type OrgsListOptions struct {
 	// Query specifies a search query for organizations.
 	Query string
 
+	// Names, if set, restricts the list to organizations with one of the given names.
+	Names []string
+
 	*LimitOffset
 }
 
@@ -168,6 +171,13 @@ func (*orgStore) listSQL(opt OrgsListOptions) *sqlf.Query {
 		query := "%" + opt.Query + "%"
 		conds = append(conds, sqlf.Sprintf("name ILIKE %s OR display_name ILIKE %s", query, query))
 	}
+	if len(opt.Names) > 0 {
+		items := make([]*sqlf.Query, len(opt.Names))
+		for i := range opt.Names {
+			items[i] = sqlf.Sprintf("%s", opt.Names[i])
+		}
+		conds = append(conds, sqlf.Sprintf("name IN (%s)", sqlf.Join(items, ",")))
+	}
 	return sqlf.Sprintf("(%s)", sqlf.Join(conds, ") AND ("))
 }
 
@@ -112,6 +112,27 @@ func TestOrgs_Count(t *testing.T) {
 	}
 }
 
+func TestOrgs_ListNames(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := db.Orgs().Create(ctx, name, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	orgs, err := db.Orgs().List(ctx, &OrgsListOptions{Names: []string{"A", "c", "d"}})
+	require.NoError(t, err)
+	var names []string
+	for _, org := range orgs {
+		names = append(names, org.Name)
+	}
+	require.Equal(t, []string{"a", "c"}, names)
+}
+
 func TestOrgs_Delete(t *testing.T) {
 	t.Parallel()
 	logger := logtest.Scoped(t)
@@ -0,0 +1,52 @@
+package encryption
+
+import (
+	"context"
+)
+
+// BatchKey is a Key that can encrypt and decrypt many values at once, such as a key of
+// a remote service that accepts batch requests.
+type BatchKey interface {
+	Key
+
+	// EncryptBatch encrypts plaintexts, and returns their ciphertexts in the same order.
+	EncryptBatch(ctx context.Context, plaintexts [][]byte) ([][]byte, error)
+	// DecryptBatch decrypts ciphertexts, and returns their secrets in the same order.
+	DecryptBatch(ctx context.Context, ciphertexts [][]byte) ([]*Secret, error)
+}
+
+// EncryptBatch encrypts plaintexts with key, in a single call if key is a BatchKey, and
+// returns their ciphertexts in the same order.
+func EncryptBatch(ctx context.Context, key Key, plaintexts [][]byte) ([][]byte, error) {
+	if batchKey, ok := key.(BatchKey); ok {
+		return batchKey.EncryptBatch(ctx, plaintexts)
+	}
+
+	ciphertexts := make([][]byte, 0, len(plaintexts))
+	for _, plaintext := range plaintexts {
+		ciphertext, err := key.Encrypt(ctx, plaintext)
+		if err != nil {
+			return nil, err
+		}
+		ciphertexts = append(ciphertexts, ciphertext)
+	}
+	return ciphertexts, nil
+}
+
+// DecryptBatch decrypts ciphertexts with key, in a single call if key is a BatchKey, and
+// returns their secrets in the same order.
+func DecryptBatch(ctx context.Context, key Key, ciphertexts [][]byte) ([]*Secret, error) {
+	if batchKey, ok := key.(BatchKey); ok {
+		return batchKey.DecryptBatch(ctx, ciphertexts)
+	}
+
+	secrets := make([]*Secret, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		secret, err := key.Decrypt(ctx, ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
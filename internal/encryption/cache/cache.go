@@ -9,6 +9,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
 )
 
+var _ encryption.BatchKey = &Key{}
+
 // New returns a cache.Key with an LRU cache of `size` values, wrapping the passed key.
 func New(k encryption.Key, size int) (*Key, error) {
 	c, err := lru.NewWithEvict(size, func(key, value any) { evictTotal.WithLabelValues().Inc() })
@@ -51,6 +53,48 @@ func (k *Key) Decrypt(ctx context.Context, ciphertext []byte) (*encryption.Secre
 	return &s, nil
 }
 
+// EncryptBatch encrypts plaintexts with the underlying key implementation.
+func (k *Key) EncryptBatch(ctx context.Context, plaintexts [][]byte) ([][]byte, error) {
+	return encryption.EncryptBatch(ctx, k.Key, plaintexts)
+}
+
+// DecryptBatch finds the decrypted ciphertexts in the cache, and decrypts those that are
+// not found with the underlying key implementation in a single batch, adding the results
+// to the cache.
+func (k *Key) DecryptBatch(ctx context.Context, ciphertexts [][]byte) ([]*encryption.Secret, error) {
+	secrets := make([]*encryption.Secret, len(ciphertexts))
+	var missing []int
+	for i, ciphertext := range ciphertexts {
+		v, found := k.cache.Get(hash(ciphertext))
+		if s, ok := v.(encryption.Secret); ok && found {
+			hitTotal.WithLabelValues().Inc()
+			secrets[i] = &s
+			continue
+		}
+		missTotal.WithLabelValues().Inc()
+		missing = append(missing, i)
+	}
+	if len(missing) == 0 {
+		return secrets, nil
+	}
+
+	missingCiphertexts := make([][]byte, 0, len(missing))
+	for _, i := range missing {
+		missingCiphertexts = append(missingCiphertexts, ciphertexts[i])
+	}
+	decrypted, err := encryption.DecryptBatch(ctx, k.Key, missingCiphertexts)
+	if err != nil {
+		loadErrorTotal.WithLabelValues().Inc()
+		return nil, err
+	}
+	for j, i := range missing {
+		loadSuccessTotal.WithLabelValues().Inc()
+		k.cache.Add(hash(ciphertexts[i]), *decrypted[j])
+		secrets[i] = decrypted[j]
+	}
+	return secrets, nil
+}
+
 func hash(v []byte) uint64 {
 	h := fnv.New64()
 	h.Write(v)
@@ -45,6 +45,39 @@ func TestCacheKey(t *testing.T) {
 	assert.Equal(t, m["foobaz"], 1)
 }
 
+func TestCacheKeyDecryptBatch(t *testing.T) {
+	m := make(map[string]int)
+	k := &testKey{
+		Key: &encryption.NoopKey{},
+		fn: func(b []byte) {
+			m[string(b)] = m[string(b)] + 1
+		},
+	}
+
+	cached, err := New(k, 10)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// first call, decrypt value
+	_, err = cached.Decrypt(ctx, []byte("foobar"))
+	require.NoError(t, err)
+
+	// foobar is returned from the cache, foobaz is decrypted
+	secrets, err := cached.DecryptBatch(ctx, [][]byte{[]byte("foobar"), []byte("foobaz")})
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+	assert.Equal(t, "foobar", secrets[0].Secret())
+	assert.Equal(t, "foobaz", secrets[1].Secret())
+
+	// both values are returned from the cache
+	_, err = cached.DecryptBatch(ctx, [][]byte{[]byte("foobar"), []byte("foobaz")})
+	require.NoError(t, err)
+
+	assert.Equal(t, m["foobar"], 1)
+	assert.Equal(t, m["foobaz"], 1)
+}
+
 type testKey struct {
 	encryption.Key
 	fn func([]byte)
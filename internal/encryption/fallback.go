@@ -0,0 +1,61 @@
+package encryption
+
+import (
+	"context"
+)
+
+var _ BatchKey = &FallbackKey{}
+
+// NewFallbackKey returns a key that encrypts values with current, and decrypts values
+// with the first of current and previous that can decrypt them. It is used to keep
+// values encrypted with keys that were replaced readable until they are re-encrypted.
+func NewFallbackKey(current Key, previous ...Key) *FallbackKey {
+	return &FallbackKey{Key: current, previous: previous}
+}
+
+// FallbackKey wraps the current key, which it encrypts values with and reports the
+// version of, and decryption-only keys it was changed from.
+type FallbackKey struct {
+	Key
+
+	previous []Key
+}
+
+// Decrypt decrypts ciphertext with the current key, or with the previous keys if the
+// current key fails to. If no key can decrypt ciphertext, the error of the current key
+// is returned.
+func (k *FallbackKey) Decrypt(ctx context.Context, ciphertext []byte) (*Secret, error) {
+	secret, err := k.Key.Decrypt(ctx, ciphertext)
+	if err == nil {
+		return secret, nil
+	}
+	for _, previous := range k.previous {
+		if secret, previousErr := previous.Decrypt(ctx, ciphertext); previousErr == nil {
+			return secret, nil
+		}
+	}
+	return nil, err
+}
+
+// EncryptBatch encrypts plaintexts with the current key.
+func (k *FallbackKey) EncryptBatch(ctx context.Context, plaintexts [][]byte) ([][]byte, error) {
+	return EncryptBatch(ctx, k.Key, plaintexts)
+}
+
+// DecryptBatch decrypts ciphertexts with the current key in a single call, or one by one
+// like Decrypt if the current key fails to decrypt any of them.
+func (k *FallbackKey) DecryptBatch(ctx context.Context, ciphertexts [][]byte) ([]*Secret, error) {
+	if secrets, err := DecryptBatch(ctx, k.Key, ciphertexts); err == nil {
+		return secrets, nil
+	}
+
+	secrets := make([]*Secret, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		secret, err := k.Decrypt(ctx, ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
@@ -0,0 +1,96 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// prefixKey "encrypts" values by prefixing them, and fails to decrypt values
+// without its prefix.
+type prefixKey string
+
+func (k prefixKey) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	return append([]byte(k), plaintext...), nil
+}
+
+func (k prefixKey) Decrypt(ctx context.Context, ciphertext []byte) (*Secret, error) {
+	if !strings.HasPrefix(string(ciphertext), string(k)) {
+		return nil, errors.Newf("not encrypted with %s", k)
+	}
+	s := NewSecret(strings.TrimPrefix(string(ciphertext), string(k)))
+	return &s, nil
+}
+
+func (k prefixKey) Version(ctx context.Context) (KeyVersion, error) {
+	return KeyVersion{Type: "prefix", Name: string(k)}, nil
+}
+
+func TestFallbackKey(t *testing.T) {
+	ctx := context.Background()
+	key := NewFallbackKey(prefixKey("new:"), prefixKey("old:"), prefixKey("older:"))
+
+	encrypted, err := key.Encrypt(ctx, []byte("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := string(encrypted), "new:foo"; have != want {
+		t.Errorf("unexpected encrypted value. want=%q have=%q", want, have)
+	}
+
+	version, err := key.Version(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (KeyVersion{Type: "prefix", Name: "new:"}); version != want {
+		t.Errorf("unexpected version. want=%v have=%v", want, version)
+	}
+
+	for _, ciphertext := range []string{"new:foo", "old:foo", "older:foo"} {
+		decrypted, err := key.Decrypt(ctx, []byte(ciphertext))
+		if err != nil {
+			t.Fatalf("unexpected error decrypting %q: %s", ciphertext, err)
+		}
+		if have, want := decrypted.Secret(), "foo"; have != want {
+			t.Errorf("unexpected decrypted value. want=%q have=%q", want, have)
+		}
+	}
+
+	// The error of the current key is returned if no key can decrypt the value.
+	if _, err := key.Decrypt(ctx, []byte("other:foo")); err == nil || err.Error() != "not encrypted with new:" {
+		t.Errorf("unexpected error. want=%q have=%v", "not encrypted with new:", err)
+	}
+}
+
+func TestFallbackKeyBatch(t *testing.T) {
+	ctx := context.Background()
+	key := NewFallbackKey(prefixKey("new:"), prefixKey("old:"))
+
+	encrypted, err := key.EncryptBatch(ctx, [][]byte{[]byte("foo"), []byte("bar")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := string(bytes.Join(encrypted, []byte(","))), "new:foo,new:bar"; have != want {
+		t.Errorf("unexpected encrypted values. want=%q have=%q", want, have)
+	}
+
+	// Values the current key cannot decrypt are decrypted one by one with the previous keys.
+	secrets, err := key.DecryptBatch(ctx, [][]byte{[]byte("new:foo"), []byte("old:bar")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decrypted []string
+	for _, secret := range secrets {
+		decrypted = append(decrypted, secret.Secret())
+	}
+	if have, want := strings.Join(decrypted, ","), "foo,bar"; have != want {
+		t.Errorf("unexpected decrypted values. want=%q have=%q", want, have)
+	}
+
+	if _, err := key.DecryptBatch(ctx, [][]byte{[]byte("new:foo"), []byte("other:bar")}); err == nil || err.Error() != "not encrypted with new:" {
+		t.Errorf("unexpected error. want=%q have=%v", "not encrypted with new:", err)
+	}
+}
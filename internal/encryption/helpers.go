@@ -37,6 +37,43 @@ func MaybeEncrypt(ctx context.Context, key Key, data string) (_, keyIdent string
 	return string(encrypted), version.JSON(), nil
 }
 
+// MaybeEncryptBatch encrypts data with the given key like MaybeEncrypt, in a single call if
+// the key is a BatchKey. If the given key is nil, this function no-ops.
+func MaybeEncryptBatch(ctx context.Context, key Key, data []string) (_ []string, keyIdent string, err error) {
+	if key == nil || len(data) == 0 {
+		return data, "", nil
+	}
+	if os.Getenv("ALLOW_DECRYPTION") == "true" {
+		// Do not encrypt new values while the worker is decrypting the database
+		return data, "", nil
+	}
+
+	plaintexts := make([][]byte, 0, len(data))
+	for _, v := range data {
+		plaintexts = append(plaintexts, []byte(v))
+	}
+
+	span, ctx := ot.StartSpanFromContext(ctx, "key.EncryptBatch") //nolint:staticcheck // OT is deprecated
+	ciphertexts, err := EncryptBatch(ctx, key, plaintexts)
+	span.Finish()
+	if err != nil {
+		return nil, "", err
+	}
+
+	span, ctx = ot.StartSpanFromContext(ctx, "key.Version") //nolint:staticcheck // OT is deprecated
+	version, err := key.Version(ctx)
+	span.Finish()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to get encryption key version")
+	}
+
+	encrypted := make([]string, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		encrypted = append(encrypted, string(ciphertext))
+	}
+	return encrypted, version.JSON(), nil
+}
+
 // MaybeDecrypt decrypts data given key. If the value is not encrypted, this function no-ops. If the given
 // key cannot decrypt the data, an error is returned.
 func MaybeDecrypt(ctx context.Context, key Key, data, keyIdent string) (string, error) {
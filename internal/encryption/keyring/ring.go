@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/cache"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/cloudkms"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/mounted"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/vault"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -127,6 +128,37 @@ func NewRing(ctx context.Context, keyConfig *schema.EncryptionKeys) (*Ring, erro
 		}
 	}
 
+	if len(keyConfig.DecryptionKeys) > 0 {
+		previous := make([]encryption.Key, 0, len(keyConfig.DecryptionKeys))
+		for i := range keyConfig.DecryptionKeys {
+			k := &keyConfig.DecryptionKeys[i]
+			if k.Noop != nil {
+				// A noop key "decrypts" any value, which would hide values it cannot read.
+				return nil, errors.New("noop keys cannot be used as decryption keys")
+			}
+			key, err := NewKey(ctx, k, keyConfig)
+			if err != nil {
+				return nil, errors.Wrap(err, "decryption key")
+			}
+			previous = append(previous, key)
+		}
+
+		// Values encrypted with any of the keys the current keys were changed from
+		// stay readable until they are re-encrypted with the current keys.
+		for _, key := range []*encryption.Key{
+			&r.BatchChangesCredentialKey,
+			&r.ExternalServiceKey,
+			&r.UserExternalAccountKey,
+			&r.WebhookKey,
+			&r.WebhookLogKey,
+			&r.ExecutorSecretKey,
+		} {
+			if *key != nil {
+				*key = encryption.NewFallbackKey(*key, previous...)
+			}
+		}
+	}
+
 	return &r, nil
 }
 
@@ -152,6 +184,8 @@ func NewKey(ctx context.Context, k *schema.EncryptionKey, config *schema.Encrypt
 		key, err = cloudkms.NewKey(ctx, *k.Cloudkms)
 	case k.Awskms != nil:
 		key, err = awskms.NewKey(ctx, *k.Awskms)
+	case k.Vault != nil:
+		key, err = vault.NewKey(ctx, *k.Vault)
 	case k.Mounted != nil:
 		key, err = mounted.NewKey(ctx, *k.Mounted)
 	case k.Noop != nil:
@@ -0,0 +1,327 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	defaultMountPath        = "transit"
+	defaultAppRoleMountPath = "approle"
+)
+
+func NewKey(ctx context.Context, keyConfig schema.VaultEncryptionKey) (*Key, error) {
+	return newKey(ctx, keyConfig, httpcli.ExternalDoer)
+}
+
+func newKey(ctx context.Context, keyConfig schema.VaultEncryptionKey, client httpcli.Doer) (*Key, error) {
+	address, err := url.Parse(keyConfig.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing vault address")
+	}
+	if address.Scheme == "" || address.Host == "" {
+		return nil, errors.Errorf("invalid vault address %q", keyConfig.Address)
+	}
+	if keyConfig.KeyName == "" {
+		return nil, errors.New("vault key name must be set")
+	}
+	if (keyConfig.Token == "") == (keyConfig.AppRole == nil) {
+		return nil, errors.New("exactly one of token and appRole must be set to authenticate to vault")
+	}
+
+	k := &Key{
+		client:    client,
+		address:   address,
+		mountPath: strings.Trim(keyConfig.MountPath, "/"),
+		keyName:   keyConfig.KeyName,
+		namespace: keyConfig.Namespace,
+		token:     keyConfig.Token,
+		appRole:   keyConfig.AppRole,
+	}
+	if k.mountPath == "" {
+		k.mountPath = defaultMountPath
+	}
+	// Test client connection.
+	_, err = k.Version(ctx)
+	return k, err
+}
+
+// Key is an encryption.Key implementation that uses the transit secrets engine of
+// HashiCorp Vault. Vault encrypts values with the latest version of the key, and
+// returns ciphertexts that are stored as is: they embed the version of the key
+// they were encrypted with, so they can be decrypted after the key is rotated.
+type Key struct {
+	client    httpcli.Doer
+	address   *url.URL
+	mountPath string
+	keyName   string
+	namespace string
+
+	// appRole is set if the key authenticates to Vault with AppRole, in which
+	// case token is the token of the last login.
+	appRole *schema.VaultAppRole
+
+	mu             sync.Mutex
+	token          string
+	tokenExpiresAt time.Time
+}
+
+var _ encryption.BatchKey = &Key{}
+
+func (k *Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
+	var resp struct {
+		Data struct {
+			LatestVersion int `json:"latest_version"`
+		} `json:"data"`
+	}
+	if err := k.do(ctx, http.MethodGet, path.Join(k.mountPath, "keys", k.keyName), nil, &resp); err != nil {
+		return encryption.KeyVersion{}, errors.Wrap(err, "getting key version")
+	}
+	return encryption.KeyVersion{
+		Type:    "vault",
+		Name:    path.Join(k.mountPath, k.keyName),
+		Version: strconv.Itoa(resp.Data.LatestVersion),
+	}, nil
+}
+
+func (k *Key) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	ciphertexts, err := k.EncryptBatch(ctx, [][]byte{plaintext})
+	if err != nil {
+		return nil, err
+	}
+	return ciphertexts[0], nil
+}
+
+func (k *Key) Decrypt(ctx context.Context, ciphertext []byte) (*encryption.Secret, error) {
+	secrets, err := k.DecryptBatch(ctx, [][]byte{ciphertext})
+	if err != nil {
+		return nil, err
+	}
+	return secrets[0], nil
+}
+
+type batchResult struct {
+	Plaintext  string `json:"plaintext"`
+	Ciphertext string `json:"ciphertext"`
+	Error      string `json:"error"`
+}
+
+type batchResponse struct {
+	Data struct {
+		BatchResults []batchResult `json:"batch_results"`
+	} `json:"data"`
+}
+
+// EncryptBatch encrypts values in a single request to Vault, and returns their
+// ciphertexts in the same order.
+func (k *Key) EncryptBatch(ctx context.Context, plaintexts [][]byte) ([][]byte, error) {
+	type input struct {
+		Plaintext string `json:"plaintext"`
+	}
+	inputs := make([]input, 0, len(plaintexts))
+	for _, plaintext := range plaintexts {
+		inputs = append(inputs, input{Plaintext: base64.StdEncoding.EncodeToString(plaintext)})
+	}
+
+	results, err := k.batch(ctx, "encrypt", inputs, len(inputs))
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertexts := make([][]byte, 0, len(results))
+	for _, result := range results {
+		ciphertexts = append(ciphertexts, []byte(result.Ciphertext))
+	}
+	return ciphertexts, nil
+}
+
+// DecryptBatch decrypts values encrypted with the key in a single request to
+// Vault, and returns them in the same order.
+func (k *Key) DecryptBatch(ctx context.Context, ciphertexts [][]byte) ([]*encryption.Secret, error) {
+	type input struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	inputs := make([]input, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		inputs = append(inputs, input{Ciphertext: string(ciphertext)})
+	}
+
+	results, err := k.batch(ctx, "decrypt", inputs, len(inputs))
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*encryption.Secret, 0, len(results))
+	for _, result := range results {
+		plaintext, err := base64.StdEncoding.DecodeString(result.Plaintext)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding plaintext")
+		}
+		secret := encryption.NewSecret(string(plaintext))
+		secrets = append(secrets, &secret)
+	}
+	return secrets, nil
+}
+
+// batch performs a batch operation of the transit secrets engine on n inputs, and
+// returns its results in the order of the inputs. It fails if any input fails.
+func (k *Key) batch(ctx context.Context, operation string, inputs any, n int) ([]batchResult, error) {
+	if n == 0 {
+		return nil, nil
+	}
+
+	var resp batchResponse
+	req := map[string]any{"batch_input": inputs}
+	if err := k.do(ctx, http.MethodPost, path.Join(k.mountPath, operation, k.keyName), req, &resp); err != nil {
+		return nil, errors.Wrapf(err, "vault %s", operation)
+	}
+
+	results := resp.Data.BatchResults
+	if len(results) != n {
+		return nil, errors.Errorf("vault %s: got %d results for %d values", operation, len(results), n)
+	}
+	for i, result := range results {
+		if result.Error != "" {
+			return nil, errors.Errorf("vault %s: value %d: %s", operation, i, result.Error)
+		}
+	}
+	return results, nil
+}
+
+// do sends a request to the Vault API, and decodes the response into out. When
+// authenticating with AppRole, it logs in again once if the token was rejected.
+func (k *Key) do(ctx context.Context, method, apiPath string, in, out any) error {
+	token, err := k.getToken(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	err = k.request(ctx, method, apiPath, token, in, out)
+	if k.appRole != nil && errors.Is(err, errPermissionDenied) {
+		if token, err = k.getToken(ctx, true); err != nil {
+			return err
+		}
+		err = k.request(ctx, method, apiPath, token, in, out)
+	}
+	return err
+}
+
+// tokenRenewalMargin is how long before it expires a token obtained with AppRole is
+// replaced by logging in again.
+const tokenRenewalMargin = time.Minute
+
+// getToken returns the token to authenticate to Vault with, logging in with AppRole
+// if needed.
+func (k *Key) getToken(ctx context.Context, forceLogin bool) (string, error) {
+	if k.appRole == nil {
+		return k.token, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if !forceLogin && k.token != "" && (k.tokenExpiresAt.IsZero() || time.Now().Add(tokenRenewalMargin).Before(k.tokenExpiresAt)) {
+		return k.token, nil
+	}
+
+	mountPath := strings.Trim(k.appRole.MountPath, "/")
+	if mountPath == "" {
+		mountPath = defaultAppRoleMountPath
+	}
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	login := map[string]string{
+		"role_id":   k.appRole.RoleId,
+		"secret_id": k.appRole.SecretId,
+	}
+	if err := k.request(ctx, http.MethodPost, path.Join("auth", mountPath, "login"), "", login, &resp); err != nil {
+		return "", errors.Wrap(err, "logging in to vault with approle")
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", errors.New("logging in to vault with approle: no token returned")
+	}
+
+	k.token = resp.Auth.ClientToken
+	k.tokenExpiresAt = time.Time{}
+	if resp.Auth.LeaseDuration > 0 {
+		k.tokenExpiresAt = time.Now().Add(time.Duration(resp.Auth.LeaseDuration) * time.Second)
+	}
+	return k.token, nil
+}
+
+var errPermissionDenied = errors.New("permission denied")
+
+func (k *Key) request(ctx context.Context, method, apiPath, token string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buf)
+	}
+
+	u := k.address.JoinPath("v1", apiPath)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if k.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", k.namespace)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return responseError(resp.StatusCode, data)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// responseError returns the error of a failed Vault API request.
+func responseError(statusCode int, body []byte) error {
+	var resp struct {
+		Errors []string `json:"errors"`
+	}
+	message := http.StatusText(statusCode)
+	if err := json.Unmarshal(body, &resp); err == nil && len(resp.Errors) > 0 {
+		message = strings.Join(resp.Errors, "; ")
+	}
+	if statusCode == http.StatusForbidden {
+		return errors.Wrap(errPermissionDenied, message)
+	}
+	return errors.Newf("%d %s", statusCode, message)
+}
@@ -0,0 +1,344 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	vault := newFakeVault(t, "transit", "sourcegraph")
+	vault.tokens["root-token"] = true
+
+	k, err := newKey(ctx, schema.VaultEncryptionKey{
+		Type:    "vault",
+		Address: vault.URL,
+		KeyName: "sourcegraph",
+		Token:   "root-token",
+	}, vault.Client())
+	require.NoError(t, err)
+
+	version, err := k.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, encryption.KeyVersion{Type: "vault", Name: "transit/sourcegraph", Version: "1"}, version)
+
+	ciphertext, err := k.Encrypt(ctx, []byte("hello world"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(ciphertext), "vault:v1:"), "unexpected ciphertext %q", ciphertext)
+
+	secret, err := k.Decrypt(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", secret.Secret())
+
+	// Values encrypted before the key is rotated can still be decrypted.
+	vault.rotate("sourcegraph")
+
+	version, err = k.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2", version.Version)
+
+	rotated, err := k.Encrypt(ctx, []byte("hello world"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(rotated), "vault:v2:"), "unexpected ciphertext %q", rotated)
+
+	for _, c := range [][]byte{ciphertext, rotated} {
+		secret, err := k.Decrypt(ctx, c)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", secret.Secret())
+	}
+
+	_, err = k.Decrypt(ctx, []byte("vault:v1:bm90IGEgY2lwaGVydGV4dA=="))
+	assert.Error(t, err)
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	vault := newFakeVault(t, "secrets/transit", "sourcegraph")
+	vault.tokens["root-token"] = true
+
+	k, err := newKey(ctx, schema.VaultEncryptionKey{
+		Type:      "vault",
+		Address:   vault.URL,
+		MountPath: "/secrets/transit/",
+		KeyName:   "sourcegraph",
+		Token:     "root-token",
+	}, vault.Client())
+	require.NoError(t, err)
+
+	plaintexts := [][]byte{[]byte("a"), []byte(""), []byte("c")}
+	ciphertexts, err := k.EncryptBatch(ctx, plaintexts)
+	require.NoError(t, err)
+	require.Len(t, ciphertexts, len(plaintexts))
+	assert.Equal(t, 1, vault.batchRequests, "expected a single request to encrypt the batch")
+
+	secrets, err := k.DecryptBatch(ctx, ciphertexts)
+	require.NoError(t, err)
+	require.Len(t, secrets, len(plaintexts))
+	for i, secret := range secrets {
+		assert.Equal(t, string(plaintexts[i]), secret.Secret())
+	}
+	assert.Equal(t, 2, vault.batchRequests, "expected a single request to decrypt the batch")
+
+	// A batch fails if any of its values fails.
+	_, err = k.DecryptBatch(ctx, [][]byte{ciphertexts[0], []byte("invalid")})
+	assert.Error(t, err)
+
+	ciphertexts, err = k.EncryptBatch(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, ciphertexts)
+}
+
+func TestAppRole(t *testing.T) {
+	ctx := context.Background()
+	vault := newFakeVault(t, "transit", "sourcegraph")
+	vault.namespace = "team"
+
+	config := schema.VaultEncryptionKey{
+		Type:      "vault",
+		Address:   vault.URL,
+		KeyName:   "sourcegraph",
+		Namespace: "team",
+		AppRole: &schema.VaultAppRole{
+			RoleId:   "role",
+			SecretId: "secret",
+		},
+	}
+	k, err := newKey(ctx, config, vault.Client())
+	require.NoError(t, err)
+	assert.Equal(t, 1, vault.logins)
+
+	ciphertext, err := k.Encrypt(ctx, []byte("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, vault.logins, "expected the token to be reused")
+
+	// Logs in again when the token is revoked.
+	vault.revokeTokens()
+	secret, err := k.Decrypt(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", secret.Secret())
+	assert.Equal(t, 2, vault.logins)
+
+	config.AppRole.SecretId = "wrong"
+	_, err = newKey(ctx, config, vault.Client())
+	assert.Error(t, err)
+}
+
+func TestNewKeyInvalidConfig(t *testing.T) {
+	vault := newFakeVault(t, "transit", "sourcegraph")
+	vault.tokens["root-token"] = true
+
+	for name, config := range map[string]schema.VaultEncryptionKey{
+		"invalid address": {Address: "vault:8200", KeyName: "sourcegraph", Token: "root-token"},
+		"no key name":     {Address: vault.URL, Token: "root-token"},
+		"no auth":         {Address: vault.URL, KeyName: "sourcegraph"},
+		"token and appRole": {
+			Address: vault.URL,
+			KeyName: "sourcegraph",
+			Token:   "root-token",
+			AppRole: &schema.VaultAppRole{RoleId: "role", SecretId: "secret"},
+		},
+		"invalid token": {Address: vault.URL, KeyName: "sourcegraph", Token: "invalid"},
+		"unknown key":   {Address: vault.URL, KeyName: "unknown", Token: "root-token"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config.Type = "vault"
+			_, err := newKey(context.Background(), config, vault.Client())
+			assert.Error(t, err)
+		})
+	}
+}
+
+// fakeVault is an HTTP server implementing the parts of the Vault API used by Key:
+// the transit secrets engine, and the AppRole auth method.
+type fakeVault struct {
+	*httptest.Server
+	t         *testing.T
+	mountPath string
+	namespace string
+
+	mu            sync.Mutex
+	keys          map[string][]cipher.AEAD
+	tokens        map[string]bool
+	logins        int
+	batchRequests int
+}
+
+func newFakeVault(t *testing.T, mountPath string, keyNames ...string) *fakeVault {
+	v := &fakeVault{
+		t:         t,
+		mountPath: mountPath,
+		keys:      map[string][]cipher.AEAD{},
+		tokens:    map[string]bool{},
+	}
+	for _, name := range keyNames {
+		v.rotate(name)
+	}
+	v.Server = httptest.NewServer(http.HandlerFunc(v.serveHTTP))
+	t.Cleanup(v.Close)
+	return v
+}
+
+// rotate adds a new version to a key.
+func (v *fakeVault) rotate(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	require.NoError(v.t, err)
+	block, err := aes.NewCipher(secret)
+	require.NoError(v.t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(v.t, err)
+	v.keys[name] = append(v.keys[name], aead)
+}
+
+func (v *fakeVault) revokeTokens() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tokens = map[string]bool{}
+}
+
+func (v *fakeVault) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Namespace") != v.namespace {
+		writeErrors(w, http.StatusNotFound, "no handler for route")
+		return
+	}
+
+	var body map[string]any
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if r.URL.Path == "/v1/auth/approle/login" && r.Method == http.MethodPost {
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			writeErrors(w, http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+		v.logins++
+		token := fmt.Sprintf("approle-token-%d", v.logins)
+		v.tokens[token] = true
+		writeJSON(w, map[string]any{"auth": map[string]any{"client_token": token, "lease_duration": 3600}})
+		return
+	}
+
+	if !v.tokens[r.Header.Get("X-Vault-Token")] {
+		writeErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"+v.mountPath+"/"), "/")
+	if len(parts) != 2 {
+		writeErrors(w, http.StatusNotFound, "no handler for route")
+		return
+	}
+	operation, versions := parts[0], v.keys[parts[1]]
+	if len(versions) == 0 {
+		writeErrors(w, http.StatusBadRequest, "encryption key not found")
+		return
+	}
+
+	switch {
+	case operation == "keys" && r.Method == http.MethodGet:
+		writeJSON(w, map[string]any{"data": map[string]any{"latest_version": len(versions)}})
+
+	case (operation == "encrypt" || operation == "decrypt") && r.Method == http.MethodPost:
+		v.batchRequests++
+		var results []map[string]string
+		for _, item := range body["batch_input"].([]any) {
+			item := item.(map[string]any)
+			var (
+				result map[string]string
+				err    error
+			)
+			if operation == "encrypt" {
+				result, err = encrypt(versions, item["plaintext"].(string))
+			} else {
+				result, err = decrypt(versions, item["ciphertext"].(string))
+			}
+			if err != nil {
+				result = map[string]string{"error": err.Error()}
+			}
+			results = append(results, result)
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{"batch_results": results}})
+
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, "unsupported operation")
+	}
+}
+
+func encrypt(versions []cipher.AEAD, plaintext string) (map[string]string, error) {
+	value, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	aead := versions[len(versions)-1]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := aead.Seal(nonce, nonce, value, nil)
+	return map[string]string{
+		"ciphertext": fmt.Sprintf("vault:v%d:%s", len(versions), base64.StdEncoding.EncodeToString(sealed)),
+	}, nil
+}
+
+func decrypt(versions []cipher.AEAD, ciphertext string) (map[string]string, error) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" || !strings.HasPrefix(parts[1], "v") {
+		return nil, errors.New("invalid ciphertext: no prefix")
+	}
+	version, err := strconv.Atoi(parts[1][1:])
+	if err != nil || version < 1 || version > len(versions) {
+		return nil, errors.New("invalid key version")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	aead := versions[version-1]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("invalid ciphertext: too short")
+	}
+	value, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("cipher: message authentication failed")
+	}
+	return map[string]string{"plaintext": base64.StdEncoding.EncodeToString(value)}, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeErrors(w http.ResponseWriter, statusCode int, errs ...string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errors": errs})
+}
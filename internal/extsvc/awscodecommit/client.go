@@ -44,13 +44,20 @@ func (c *Client) cacheKeyPrefix(ctx context.Context) (string, error) {
 	return hex.EncodeToString(key[:]), nil
 }
 
+// Region returns the AWS region the client is configured for.
+func (c *Client) Region() string {
+	return c.aws.Region
+}
+
 // ErrNotFound is when the requested AWS CodeCommit repository is not found.
 var ErrNotFound = errors.New("AWS CodeCommit repository not found")
 
 // IsNotFound reports whether err is a AWS CodeCommit API not-found error or the
 // equivalent cached response error.
 func IsNotFound(err error) bool {
-	return errors.Is(err, ErrNotFound) || errors.HasType(err, &codecommittypes.RepositoryDoesNotExistException{})
+	return errors.Is(err, ErrNotFound) ||
+		errors.HasType(err, &codecommittypes.RepositoryDoesNotExistException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestDoesNotExistException{})
 }
 
 // IsUnauthorized reports whether err is a AWS CodeCommit API unauthorized error.
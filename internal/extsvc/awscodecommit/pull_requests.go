@@ -0,0 +1,301 @@
+package awscodecommit
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	codecommittypes "github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PullRequestStatus is the status of an AWS CodeCommit pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = "OPEN"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
+)
+
+// PullRequest is an AWS CodeCommit pull request. Pull requests created by
+// Sourcegraph always have a single target, so the fields of the target are
+// inlined.
+type PullRequest struct {
+	ID               string            // the system-generated ID of the pull request
+	Title            string            // the title of the pull request
+	Description      string            // the description of the pull request
+	Status           PullRequestStatus // the status of the pull request
+	AuthorARN        string            // the ARN of the user who created the pull request
+	CreationDate     time.Time         // the date the pull request was created
+	LastActivityDate time.Time         // the date of the most recent activity on the pull request
+	RevisionID       string            // the ID of the current revision, which changes on every push
+
+	RepositoryName       string // the name of the target repository
+	SourceReference      string // the branch to be merged, e.g. refs/heads/my-branch
+	DestinationReference string // the branch to merge into, e.g. refs/heads/main
+	SourceCommit         string // the commit ID of the tip of the source branch
+	DestinationCommit    string // the commit ID of the tip of the destination branch
+	MergeBase            string // the commit ID of the merge base
+	IsMerged             bool   // whether the pull request has been merged
+	MergeCommitID        string // the commit ID of the merge, if merged
+}
+
+// ApprovalState is the state of an approval on a pull request.
+type ApprovalState string
+
+const (
+	ApprovalStateApprove ApprovalState = "APPROVE"
+	ApprovalStateRevoke  ApprovalState = "REVOKE"
+)
+
+// Approval is the approval state of a single user on a pull request revision.
+type Approval struct {
+	UserARN string        // the ARN of the approving user
+	State   ApprovalState // the approval state of the user
+}
+
+// ApprovalEvaluation is the evaluation of the approval rules of a pull
+// request revision.
+type ApprovalEvaluation struct {
+	Approved                  bool     // whether all approval rules are satisfied
+	Overridden                bool     // whether the approval rules were overridden
+	ApprovalRulesSatisfied    []string // the names of the satisfied approval rules
+	ApprovalRulesNotSatisfied []string // the names of the approval rules that aren't satisfied yet
+}
+
+// CreatePullRequestInput defines the options to create a pull request.
+type CreatePullRequestInput struct {
+	RepositoryName       string
+	Title                string
+	Description          string
+	SourceReference      string
+	DestinationReference string
+}
+
+// CreatePullRequest creates a pull request in the given repository.
+func (c *Client) CreatePullRequest(ctx context.Context, input CreatePullRequestInput) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.CreatePullRequest(ctx, &codecommit.CreatePullRequestInput{
+		Title:       aws.String(input.Title),
+		Description: aws.String(input.Description),
+		Targets: []codecommittypes.Target{{
+			RepositoryName:       aws.String(input.RepositoryName),
+			SourceReference:      aws.String(input.SourceReference),
+			DestinationReference: aws.String(input.DestinationReference),
+		}},
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// GetPullRequest gets the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: aws.String(id)})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// ListOpenPullRequestIDs returns the IDs of all open pull requests in the
+// given repository.
+func (c *Client) ListOpenPullRequestIDs(ctx context.Context, repositoryName string) ([]string, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+
+	var ids []string
+	var nextToken *string
+	for {
+		result, err := svc.ListPullRequests(ctx, &codecommit.ListPullRequestsInput{
+			RepositoryName:    aws.String(repositoryName),
+			PullRequestStatus: codecommittypes.PullRequestStatusEnumOpen,
+			NextToken:         nextToken,
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		ids = append(ids, result.PullRequestIds...)
+
+		if result.NextToken == nil || *result.NextToken == "" {
+			return ids, nil
+		}
+		nextToken = result.NextToken
+	}
+}
+
+// UpdatePullRequestTitle updates the title of the pull request.
+func (c *Client) UpdatePullRequestTitle(ctx context.Context, id, title string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{
+		PullRequestId: aws.String(id),
+		Title:         aws.String(title),
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// UpdatePullRequestDescription updates the description of the pull request.
+func (c *Client) UpdatePullRequestDescription(ctx context.Context, id, description string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{
+		PullRequestId: aws.String(id),
+		Description:   aws.String(description),
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// ClosePullRequest closes the pull request. Closed pull requests cannot be
+// reopened.
+func (c *Client) ClosePullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{
+		PullRequestId:     aws.String(id),
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumClosed,
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// MergePullRequest merges the pull request, if the tip of its source branch
+// still is the source commit of pr. If squash is true, the changes are
+// squashed into a single commit, otherwise a merge commit is created.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+
+	var result *codecommittypes.PullRequest
+	if squash {
+		out, err := svc.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  aws.String(pr.ID),
+			RepositoryName: aws.String(pr.RepositoryName),
+			SourceCommitId: aws.String(pr.SourceCommit),
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		result = out.PullRequest
+	} else {
+		out, err := svc.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+			PullRequestId:  aws.String(pr.ID),
+			RepositoryName: aws.String(pr.RepositoryName),
+			SourceCommitId: aws.String(pr.SourceCommit),
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		result = out.PullRequest
+	}
+	return fromPullRequest(result), nil
+}
+
+// GetPullRequestApprovals returns the approval states of all users that
+// approved or revoked their approval of the given pull request revision.
+func (c *Client) GetPullRequestApprovals(ctx context.Context, id, revisionID string) ([]*Approval, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.GetPullRequestApprovalStates(ctx, &codecommit.GetPullRequestApprovalStatesInput{
+		PullRequestId: aws.String(id),
+		RevisionId:    aws.String(revisionID),
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+
+	approvals := make([]*Approval, 0, len(result.Approvals))
+	for _, a := range result.Approvals {
+		approvals = append(approvals, &Approval{
+			UserARN: aws.ToString(a.UserArn),
+			State:   ApprovalState(a.ApprovalState),
+		})
+	}
+	return approvals, nil
+}
+
+// EvaluatePullRequestApprovalRules evaluates whether the given pull request
+// revision meets all the approval rules that apply to it.
+func (c *Client) EvaluatePullRequestApprovalRules(ctx context.Context, id, revisionID string) (*ApprovalEvaluation, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.EvaluatePullRequestApprovalRules(ctx, &codecommit.EvaluatePullRequestApprovalRulesInput{
+		PullRequestId: aws.String(id),
+		RevisionId:    aws.String(revisionID),
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	if result.Evaluation == nil {
+		return &ApprovalEvaluation{}, nil
+	}
+
+	return &ApprovalEvaluation{
+		Approved:                  result.Evaluation.Approved,
+		Overridden:                result.Evaluation.Overridden,
+		ApprovalRulesSatisfied:    result.Evaluation.ApprovalRulesSatisfied,
+		ApprovalRulesNotSatisfied: result.Evaluation.ApprovalRulesNotSatisfied,
+	}, nil
+}
+
+// CreatePullRequestComment posts a general comment on the current revision of
+// the pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, content string) error {
+	svc := codecommit.NewFromConfig(c.aws)
+	_, err := svc.PostCommentForPullRequest(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  aws.String(pr.ID),
+		RepositoryName: aws.String(pr.RepositoryName),
+		BeforeCommitId: aws.String(pr.DestinationCommit),
+		AfterCommitId:  aws.String(pr.SourceCommit),
+		Content:        aws.String(content),
+	})
+	if err != nil {
+		return &wrappedError{err: err}
+	}
+	return nil
+}
+
+// IsNotMergeable reports whether err is an AWS CodeCommit API error that is
+// returned when a pull request cannot be merged in its current state.
+func IsNotMergeable(err error) bool {
+	return errors.HasType(err, &codecommittypes.ManualMergeRequiredException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestApprovalRulesNotSatisfiedException{}) ||
+		errors.HasType(err, &codecommittypes.TipOfSourceReferenceIsDifferentException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestAlreadyClosedException{})
+}
+
+func fromPullRequest(m *codecommittypes.PullRequest) *PullRequest {
+	pr := PullRequest{
+		ID:          aws.ToString(m.PullRequestId),
+		Title:       aws.ToString(m.Title),
+		Description: aws.ToString(m.Description),
+		Status:      PullRequestStatus(m.PullRequestStatus),
+		AuthorARN:   aws.ToString(m.AuthorArn),
+		RevisionID:  aws.ToString(m.RevisionId),
+	}
+	if m.CreationDate != nil {
+		pr.CreationDate = *m.CreationDate
+	}
+	if m.LastActivityDate != nil {
+		pr.LastActivityDate = *m.LastActivityDate
+	}
+	if len(m.PullRequestTargets) > 0 {
+		t := m.PullRequestTargets[0]
+		pr.RepositoryName = aws.ToString(t.RepositoryName)
+		pr.SourceReference = aws.ToString(t.SourceReference)
+		pr.DestinationReference = aws.ToString(t.DestinationReference)
+		pr.SourceCommit = aws.ToString(t.SourceCommit)
+		pr.DestinationCommit = aws.ToString(t.DestinationCommit)
+		pr.MergeBase = aws.ToString(t.MergeBase)
+		if t.MergeMetadata != nil {
+			pr.IsMerged = t.MergeMetadata.IsMerged
+			pr.MergeCommitID = aws.ToString(t.MergeMetadata.MergeCommitId)
+		}
+	}
+	return &pr
+}
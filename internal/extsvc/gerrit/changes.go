@@ -0,0 +1,287 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Labels used by the default Gerrit review workflow.
+const (
+	LabelCodeReview = "Code-Review"
+	LabelVerified   = "Verified"
+)
+
+// Change is a Gerrit change, as returned by the changes REST API, see
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type Change struct {
+	ID              string                 `json:"id"`
+	Project         string                 `json:"project"`
+	Branch          string                 `json:"branch"`
+	Topic           string                 `json:"topic,omitempty"`
+	ChangeID        string                 `json:"change_id"`
+	Subject         string                 `json:"subject"`
+	Status          ChangeStatus           `json:"status"`
+	Created         Timestamp              `json:"created"`
+	Updated         Timestamp              `json:"updated"`
+	Number          int                    `json:"_number"`
+	Owner           Account                `json:"owner"`
+	WorkInProgress  bool                   `json:"work_in_progress,omitempty"`
+	Labels          map[string]ChangeLabel `json:"labels,omitempty"`
+	CurrentRevision string                 `json:"current_revision,omitempty"`
+	Revisions       map[string]Revision    `json:"revisions,omitempty"`
+}
+
+// CurrentCommit returns the commit of the current revision of the change, if
+// the change was requested with the current revision and commit included.
+func (c *Change) CurrentCommit() *Commit {
+	if rev, ok := c.Revisions[c.CurrentRevision]; ok {
+		return &rev.Commit
+	}
+	return nil
+}
+
+// ChangeLabel is the state of a review label on a change. Votes are only
+// included when detailed labels are requested.
+type ChangeLabel struct {
+	Approved *Account   `json:"approved,omitempty"`
+	Rejected *Account   `json:"rejected,omitempty"`
+	Optional bool       `json:"optional,omitempty"`
+	All      []Approval `json:"all,omitempty"`
+}
+
+// Approval is a single vote on a review label.
+type Approval struct {
+	Account
+	Value int `json:"value"`
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Number int    `json:"_number"`
+	Ref    string `json:"ref"`
+	Commit Commit `json:"commit"`
+}
+
+// Commit is the commit of a patch set.
+type Commit struct {
+	Parents []ParentCommit `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// ParentCommit is a parent of a patch set commit.
+type ParentCommit struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// timestampLayout is the format of timestamps in the Gerrit REST API, which
+// are always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a time as formatted by the Gerrit REST API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := time.Parse(timestampLayout, *s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// ChangeInput defines the options to create a change.
+type ChangeInput struct {
+	Project string `json:"project"`
+	Branch  string `json:"branch"`
+	// Subject is the full commit message of the change. Gerrit adds a
+	// Change-Id footer to it.
+	Subject        string      `json:"subject"`
+	Topic          string      `json:"topic,omitempty"`
+	WorkInProgress bool        `json:"work_in_progress,omitempty"`
+	Merge          *MergeInput `json:"merge,omitempty"`
+}
+
+// MergeInput defines a source to be merged into a change.
+type MergeInput struct {
+	// Source is the ref or commit to merge.
+	Source string `json:"source"`
+}
+
+// MergePatchSetInput defines the options to create a new merge patch set on
+// an existing change.
+type MergePatchSetInput struct {
+	Merge MergeInput `json:"merge"`
+}
+
+// ReviewInput defines a review to post on a change.
+type ReviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+}
+
+// changeDetails are the options requested for every loaded change, so that
+// its review labels and current commit are included.
+var changeDetails = []string{"CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_LABELS", "DETAILED_ACCOUNTS"}
+
+// GetChange returns the change with the given ID, including its review
+// labels and current commit.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	qs := url.Values{"o": changeDetails}
+	req, err := http.NewRequest("GET", "a/changes/"+changeID+"?"+qs.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// QueryChanges returns the changes matching the given Gerrit search query,
+// including their review labels and current commit.
+func (c *Client) QueryChanges(ctx context.Context, query string) ([]*Change, error) {
+	qs := url.Values{"q": []string{query}, "o": changeDetails}
+	req, err := http.NewRequest("GET", "a/changes/?"+qs.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*Change
+	if _, err = c.do(ctx, req, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CreateChange creates a new change.
+func (c *Client) CreateChange(ctx context.Context, input ChangeInput) (*Change, error) {
+	var change Change
+	if err := c.post(ctx, "a/changes/", input, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// CreateMergePatchSet creates a new patch set on the change, that merges the
+// given source into the destination branch of the change.
+func (c *Client) CreateMergePatchSet(ctx context.Context, changeID string, input MergePatchSetInput) (*Change, error) {
+	var change Change
+	if err := c.post(ctx, "a/changes/"+changeID+"/merge", input, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// SetCommitMessage creates a new patch set on the change with the given
+// commit message. The Change-Id footer is retained.
+func (c *Client) SetCommitMessage(ctx context.Context, changeID, message string) error {
+	return c.send(ctx, "PUT", "a/changes/"+changeID+"/message", map[string]string{"message": message}, nil)
+}
+
+// MoveChange moves the change to the given destination branch.
+func (c *Client) MoveChange(ctx context.Context, changeID, branch string) (*Change, error) {
+	var change Change
+	if err := c.post(ctx, "a/changes/"+changeID+"/move", map[string]string{"destination_branch": branch}, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the change.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) (*Change, error) {
+	var change Change
+	if err := c.post(ctx, "a/changes/"+changeID+"/abandon", struct{}{}, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// RestoreChange restores an abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) (*Change, error) {
+	var change Change
+	if err := c.post(ctx, "a/changes/"+changeID+"/restore", struct{}{}, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// SubmitChange submits the change, merging it into its destination branch.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) (*Change, error) {
+	var change Change
+	if err := c.post(ctx, "a/changes/"+changeID+"/submit", struct{}{}, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// SetReadyForReview marks a work-in-progress change as ready for review.
+func (c *Client) SetReadyForReview(ctx context.Context, changeID string) error {
+	return c.post(ctx, "a/changes/"+changeID+"/ready", struct{}{}, nil)
+}
+
+// SetReview posts a review on the current revision of the change.
+func (c *Client) SetReview(ctx context.Context, changeID string, input ReviewInput) error {
+	var result json.RawMessage
+	return c.post(ctx, "a/changes/"+changeID+"/revisions/current/review", input, &result)
+}
+
+func (c *Client) post(ctx context.Context, urlPath string, payload, result any) error {
+	return c.send(ctx, "POST", urlPath, payload, result)
+}
+
+func (c *Client) send(ctx context.Context, method, urlPath string, payload, result any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, urlPath, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	_, err = c.do(ctx, req, result)
+	return err
+}
+
+// ChangeQuery quotes the given value to be used as an operator value in
+// a change search query.
+func ChangeQuery(operator, value string) string {
+	return operator + ":\"" + strings.ReplaceAll(value, "\"", "\\\"") + "\""
+}
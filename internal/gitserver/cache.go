@@ -0,0 +1,338 @@
+package gitserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	clientCacheDir    = env.Get("SRC_GITSERVER_CLIENT_CACHE_DIR", "", "If set, cache the results of gitserver client calls on immutable commits, such as file contents, in this directory.")
+	clientCacheSizeMB = env.MustGetInt("SRC_GITSERVER_CLIENT_CACHE_SIZE_MB", 1000, "The maximum size of SRC_GITSERVER_CLIENT_CACHE_DIR in megabytes.")
+)
+
+// BlobCache is a content-addressed, read-through cache for the results of
+// gitserver client calls on immutable commits. Since keys include the commit
+// SHA, cached values never need to be invalidated.
+type BlobCache interface {
+	// GetOrFetch returns the value cached with key. If there is none, it calls
+	// fetch and caches its result. Errors returned by fetch are not cached.
+	GetOrFetch(ctx context.Context, key string, fetch func(context.Context) ([]byte, error)) ([]byte, error)
+}
+
+// NewDiskBlobCache returns a BlobCache backed by the given on-disk cache. The
+// caller is responsible for evicting entries from store.
+func NewDiskBlobCache(store diskcache.Store) BlobCache {
+	return &diskBlobCache{store: store}
+}
+
+type diskBlobCache struct {
+	store diskcache.Store
+}
+
+func (c *diskBlobCache) GetOrFetch(ctx context.Context, key string, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	f, err := c.store.Open(ctx, []string{key}, func(ctx context.Context) (io.ReadCloser, error) {
+		data, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// ObjectStore is the subset of uploadstore.Store used by an object store backed
+// BlobCache.
+type ObjectStore interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Upload(ctx context.Context, key string, r io.Reader) (int64, error)
+}
+
+// NewObjectStoreBlobCache returns a BlobCache backed by an object store, such
+// as an uploadstore.Store. Entries can be expired with the lifecycle
+// configuration of the bucket.
+func NewObjectStoreBlobCache(store ObjectStore, logger sglog.Logger) BlobCache {
+	return &objectStoreBlobCache{store: store, logger: logger}
+}
+
+type objectStoreBlobCache struct {
+	store  ObjectStore
+	logger sglog.Logger
+}
+
+func (c *objectStoreBlobCache) GetOrFetch(ctx context.Context, key string, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	if rc, err := c.store.Get(ctx, key); err == nil {
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err == nil {
+			return data, nil
+		}
+	}
+
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.store.Upload(ctx, key, bytes.NewReader(data)); err != nil {
+		// A failure to fill the cache shouldn't fail the request.
+		c.logger.Warn("failed to upload gitserver cache entry", sglog.String("key", key), sglog.Error(err))
+	}
+	return data, nil
+}
+
+// NewCachingClient returns a client that caches the results of ReadFile,
+// ReadDir and GetCommit in cache when they are called with an absolute commit
+// SHA. Calls on other revisions and calls subject to sub-repo permissions are
+// passed through to client.
+func NewCachingClient(client Client, cache BlobCache) Client {
+	return &cachingClient{Client: client, cache: cache}
+}
+
+type cachingClient struct {
+	Client
+	cache BlobCache
+}
+
+var _ Client = &cachingClient{}
+
+func (c *cachingClient) ReadFile(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string) ([]byte, error) {
+	if !cacheable(checker, commit) {
+		return c.Client.ReadFile(ctx, checker, repo, commit, name)
+	}
+	return c.getOrFetch(ctx, "ReadFile", blobCacheKey("blob", repo, commit, name), func(ctx context.Context) ([]byte, error) {
+		return c.Client.ReadFile(ctx, checker, repo, commit, name)
+	})
+}
+
+func (c *cachingClient) ReadDir(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, path string, recurse bool) ([]fs.FileInfo, error) {
+	if !cacheable(checker, commit) {
+		return c.Client.ReadDir(ctx, checker, repo, commit, path, recurse)
+	}
+	data, err := c.getOrFetch(ctx, "ReadDir", blobCacheKey("tree", repo, commit, path, strconv.FormatBool(recurse)), func(ctx context.Context) ([]byte, error) {
+		fis, err := c.Client.ReadDir(ctx, checker, repo, commit, path, recurse)
+		if err != nil {
+			return nil, err
+		}
+		return marshalFileInfos(fis)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalFileInfos(data)
+}
+
+func (c *cachingClient) GetCommit(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, id api.CommitID, opt ResolveRevisionOptions) (*gitdomain.Commit, error) {
+	if !cacheable(checker, id) {
+		return c.Client.GetCommit(ctx, checker, repo, id, opt)
+	}
+	data, err := c.getOrFetch(ctx, "GetCommit", blobCacheKey("commit", repo, id), func(ctx context.Context) ([]byte, error) {
+		commit, err := c.Client.GetCommit(ctx, checker, repo, id, opt)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(commit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var commit gitdomain.Commit
+	if err := json.Unmarshal(data, &commit); err != nil {
+		return nil, err
+	}
+	return &commit, nil
+}
+
+func (c *cachingClient) getOrFetch(ctx context.Context, op, key string, fetch func(context.Context) ([]byte, error)) (data []byte, err error) {
+	// The cache may call fetch from another goroutine.
+	var fetched atomic.Bool
+	data, err = c.cache.GetOrFetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+		fetched.Store(true)
+		return fetch(ctx)
+	})
+	if err == nil {
+		clientCacheRequests.WithLabelValues(op, strconv.FormatBool(!fetched.Load())).Inc()
+	}
+	return data, err
+}
+
+var clientCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_client_cache_requests_total",
+	Help: "The number of gitserver client calls served by the read-through cache, by whether they were cache hits",
+}, []string{"op", "hit"})
+
+// cacheable returns whether a call on commit can be cached. Only absolute
+// commit SHAs are immutable, and results filtered by sub-repo permissions
+// depend on the actor.
+func cacheable(checker authz.SubRepoPermissionChecker, commit api.CommitID) bool {
+	return !authz.SubRepoEnabled(checker) && gitdomain.EnsureAbsoluteCommit(commit) == nil
+}
+
+// blobCacheKey returns the cache key of an object of the given kind. The repo
+// is part of the key, so that repositories that share commits, such as forks,
+// don't share entries.
+func blobCacheKey(kind string, repo api.RepoName, commit api.CommitID, parts ...string) string {
+	h := sha256.New()
+	h.Write([]byte(repo))
+	for _, part := range parts {
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return "gitserver/" + kind + "/" + string(commit) + "/" + hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedFileInfo is the serialized form of the fs.FileInfo values returned by
+// ReadDir.
+type cachedFileInfo struct {
+	Name      string
+	Mode      os.FileMode
+	Size      int64
+	Submodule *gitdomain.Submodule `json:",omitempty"`
+}
+
+func marshalFileInfos(fis []fs.FileInfo) ([]byte, error) {
+	cached := make([]cachedFileInfo, 0, len(fis))
+	for _, fi := range fis {
+		cfi := cachedFileInfo{Name: fi.Name(), Mode: fi.Mode(), Size: fi.Size()}
+		if sm, ok := fi.Sys().(gitdomain.Submodule); ok {
+			cfi.Submodule = &sm
+		}
+		cached = append(cached, cfi)
+	}
+	return json.Marshal(cached)
+}
+
+func unmarshalFileInfos(data []byte) ([]fs.FileInfo, error) {
+	var cached []cachedFileInfo
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+	fis := make([]fs.FileInfo, 0, len(cached))
+	for _, cfi := range cached {
+		fi := &fileutil.FileInfo{Name_: cfi.Name, Mode_: cfi.Mode, Size_: cfi.Size}
+		if cfi.Submodule != nil {
+			fi.Sys_ = *cfi.Submodule
+		}
+		fis = append(fis, fi)
+	}
+	return fis, nil
+}
+
+var (
+	defaultBlobCacheOnce  sync.Once
+	defaultBlobCacheStore diskcache.Store
+)
+
+// getDefaultBlobCacheStore returns the on-disk cache configured with
+// SRC_GITSERVER_CLIENT_CACHE_DIR, or nil if none is configured.
+func getDefaultBlobCacheStore() diskcache.Store {
+	defaultBlobCacheOnce.Do(func() {
+		if clientCacheDir == "" {
+			return
+		}
+		defaultBlobCacheStore = diskcache.NewStore(clientCacheDir, "gitserver-client")
+	})
+	return defaultBlobCacheStore
+}
+
+// getDefaultBlobCache returns a BlobCache backed by the on-disk cache
+// configured with SRC_GITSERVER_CLIENT_CACHE_DIR, or nil if none is configured.
+func getDefaultBlobCache() BlobCache {
+	store := getDefaultBlobCacheStore()
+	if store == nil {
+		return nil
+	}
+	return NewDiskBlobCache(store)
+}
+
+// ClientCacheRoutines returns the background routines that maintain the on-disk
+// cache configured with SRC_GITSERVER_CLIENT_CACHE_DIR. Services that create
+// clients with NewClient must run them, otherwise the cache grows without
+// bounds. It returns no routines if no cache is configured.
+func ClientCacheRoutines() []goroutine.BackgroundRoutine {
+	store := getDefaultBlobCacheStore()
+	if store == nil {
+		return nil
+	}
+	return []goroutine.BackgroundRoutine{
+		newClientCacheEvicter(store, int64(clientCacheSizeMB)*1024*1024, time.Minute),
+	}
+}
+
+func newClientCacheEvicter(store diskcache.Store, maxCacheSizeBytes int64, interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), "gitserver.client-cache-evicter", "evicts entries from the gitserver client cache",
+		interval, &clientCacheEvicter{
+			store:             store,
+			maxCacheSizeBytes: maxCacheSizeBytes,
+			logger:            sglog.Scoped("gitserverClientCacheEvicter", "evicts entries from the gitserver client cache"),
+		},
+	)
+}
+
+type clientCacheEvicter struct {
+	store             diskcache.Store
+	maxCacheSizeBytes int64
+	logger            sglog.Logger
+}
+
+var (
+	_ goroutine.Handler      = &clientCacheEvicter{}
+	_ goroutine.ErrorHandler = &clientCacheEvicter{}
+)
+
+// Handle evicts entries from the cache until it is smaller than
+// maxCacheSizeBytes.
+func (e *clientCacheEvicter) Handle(ctx context.Context) error {
+	stats, err := e.store.Evict(e.maxCacheSizeBytes)
+	if err != nil {
+		return errors.Wrap(err, "evicting gitserver client cache entries")
+	}
+	clientCacheSizeBytes.Set(float64(stats.CacheSize))
+	clientCacheEvictions.Add(float64(stats.Evicted))
+	return nil
+}
+
+func (e *clientCacheEvicter) HandleError(err error) {
+	clientCacheEvictionErrors.Inc()
+	e.logger.Error("failed to evict gitserver client cache", sglog.Error(err))
+}
+
+var (
+	clientCacheSizeBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_client_cache_size_bytes",
+		Help: "The total size of the entries of the on-disk gitserver client cache",
+	})
+	clientCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_client_cache_evictions_total",
+		Help: "The number of entries evicted from the on-disk gitserver client cache",
+	})
+	clientCacheEvictionErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_client_cache_eviction_errors_total",
+		Help: "The number of failures to evict entries from the on-disk gitserver client cache",
+	})
+)
@@ -0,0 +1,184 @@
+package gitserver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const cacheTestCommit = api.CommitID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+
+func TestCachingClient(t *testing.T) {
+	ctx := context.Background()
+	repo := api.RepoName("github.com/test/test")
+
+	newClient := func(t *testing.T) (*MockClient, Client) {
+		mock := NewMockClient()
+		store := diskcache.NewStore(t.TempDir(), "test")
+		return mock, NewCachingClient(mock, NewDiskBlobCache(store))
+	}
+
+	t.Run("ReadFile", func(t *testing.T) {
+		mock, client := newClient(t)
+		mock.ReadFileFunc.SetDefaultReturn([]byte("hello"), nil)
+
+		for i := 0; i < 2; i++ {
+			data, err := client.ReadFile(ctx, nil, repo, cacheTestCommit, "README.md")
+			require.NoError(t, err)
+			require.Equal(t, "hello", string(data))
+		}
+		require.Len(t, mock.ReadFileFunc.History(), 1)
+
+		// A different path or repo is a different entry
+		_, err := client.ReadFile(ctx, nil, repo, cacheTestCommit, "LICENSE")
+		require.NoError(t, err)
+		_, err = client.ReadFile(ctx, nil, "github.com/test/fork", cacheTestCommit, "README.md")
+		require.NoError(t, err)
+		require.Len(t, mock.ReadFileFunc.History(), 3)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		mock, client := newClient(t)
+		mock.ReadFileFunc.PushReturn(nil, errors.New("boom"))
+		mock.ReadFileFunc.PushReturn([]byte("hello"), nil)
+
+		_, err := client.ReadFile(ctx, nil, repo, cacheTestCommit, "README.md")
+		require.Error(t, err)
+		data, err := client.ReadFile(ctx, nil, repo, cacheTestCommit, "README.md")
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(data))
+	})
+
+	t.Run("uncacheable calls are passed through", func(t *testing.T) {
+		mock, client := newClient(t)
+		mock.ReadFileFunc.SetDefaultReturn([]byte("hello"), nil)
+
+		checker := authz.NewMockSubRepoPermissionChecker()
+		checker.EnabledFunc.SetDefaultReturn(true)
+
+		for i := 0; i < 2; i++ {
+			_, err := client.ReadFile(ctx, nil, repo, "HEAD", "README.md")
+			require.NoError(t, err)
+			_, err = client.ReadFile(ctx, checker, repo, cacheTestCommit, "README.md")
+			require.NoError(t, err)
+		}
+		require.Len(t, mock.ReadFileFunc.History(), 4)
+	})
+
+	t.Run("ReadDir", func(t *testing.T) {
+		mock, client := newClient(t)
+		submodule := gitdomain.Submodule{URL: "https://github.com/test/sub", Path: "sub", CommitID: cacheTestCommit}
+		want := []fs.FileInfo{
+			&fileutil.FileInfo{Name_: "README.md", Mode_: 0o644, Size_: 5},
+			&fileutil.FileInfo{Name_: "sub", Mode_: gitdomain.ModeSubmodule, Sys_: submodule},
+		}
+		mock.ReadDirFunc.SetDefaultReturn(want, nil)
+
+		for i := 0; i < 2; i++ {
+			fis, err := client.ReadDir(ctx, nil, repo, cacheTestCommit, "", true)
+			require.NoError(t, err)
+			require.Equal(t, want, fis)
+		}
+		require.Len(t, mock.ReadDirFunc.History(), 1)
+
+		// Non-recursive listings are cached separately
+		_, err := client.ReadDir(ctx, nil, repo, cacheTestCommit, "", false)
+		require.NoError(t, err)
+		require.Len(t, mock.ReadDirFunc.History(), 2)
+	})
+
+	t.Run("GetCommit", func(t *testing.T) {
+		mock, client := newClient(t)
+		want := &gitdomain.Commit{ID: cacheTestCommit, Message: "initial commit", Author: gitdomain.Signature{Name: "a", Email: "a@example.com"}}
+		mock.GetCommitFunc.SetDefaultReturn(want, nil)
+
+		for i := 0; i < 2; i++ {
+			commit, err := client.GetCommit(ctx, nil, repo, cacheTestCommit, ResolveRevisionOptions{})
+			require.NoError(t, err)
+			require.Equal(t, want, commit)
+		}
+		require.Len(t, mock.GetCommitFunc.History(), 1)
+	})
+}
+
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeObjectStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeObjectStore) Upload(_ context.Context, key string, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+	return int64(len(data)), nil
+}
+
+func TestClientCacheEvicter(t *testing.T) {
+	ctx := context.Background()
+	store := diskcache.NewStore(t.TempDir(), "gitserver-client")
+	cache := NewDiskBlobCache(store)
+
+	fetches := 0
+	fetch := func(context.Context) ([]byte, error) {
+		fetches++
+		return []byte("hello"), nil
+	}
+	for i := 0; i < 2; i++ {
+		_, err := cache.GetOrFetch(ctx, "key", fetch)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, fetches)
+
+	evicter := &clientCacheEvicter{store: store, maxCacheSizeBytes: 0, logger: logtest.Scoped(t)}
+	require.NoError(t, evicter.Handle(ctx))
+
+	_, err := cache.GetOrFetch(ctx, "key", fetch)
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches, "expected the entry to be evicted")
+}
+
+func TestObjectStoreBlobCache(t *testing.T) {
+	store := &fakeObjectStore{objects: map[string][]byte{}}
+	cache := NewObjectStoreBlobCache(store, logtest.Scoped(t))
+
+	fetches := 0
+	fetch := func(context.Context) ([]byte, error) {
+		fetches++
+		return []byte("hello"), nil
+	}
+	for i := 0; i < 2; i++ {
+		data, err := cache.GetOrFetch(context.Background(), "key", fetch)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(data))
+	}
+	require.Equal(t, 1, fetches)
+	require.Equal(t, []byte("hello"), store.objects["key"])
+}
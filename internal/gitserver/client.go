@@ -74,7 +74,7 @@ var _ Client = &clientImplementor{}
 
 // NewClient returns a new gitserver.Client.
 func NewClient(db database.DB) Client {
-	return &clientImplementor{
+	var c Client = &clientImplementor{
 		logger: sglog.Scoped("NewClient", "returns a new gitserver.Client"),
 		addrs: func() []string {
 			return conf.Get().ServiceConnections().GitServers
@@ -89,6 +89,10 @@ func NewClient(db database.DB) Client {
 		userAgent:  filepath.Base(os.Args[0]),
 		operations: getOperations(),
 	}
+	if cache := getDefaultBlobCache(); cache != nil {
+		c = NewCachingClient(c, cache)
+	}
+	return c
 }
 
 // NewTestClient returns a test client that will use the given hard coded list of
@@ -2,7 +2,9 @@ package codeowners
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -14,7 +16,34 @@ import (
 // Parse parses CODEOWNERS file given as a Reader and returns the proto
 // representation of all rules within. The rules are in the same order
 // as in the file, since this matters for evaluation.
+//
+// Parsing is strict, that is, it fails on the first line that cannot
+// be parsed. Use ParseLenient to retain as much of a broken file as possible.
 func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
+	return parse(codeownersFile, false)
+}
+
+// ParseLenient parses CODEOWNERS file given as a Reader like Parse does,
+// but it does not give up on lines it cannot parse. Instead, every valid
+// rule is retained, and the problems found are recorded as diagnostics
+// on the returned file. Rules that can never be applied, since they are
+// duplicated or shadowed by a later rule, are also reported.
+// An error is only returned if reading the file fails.
+func ParseLenient(codeownersFile io.Reader) (*codeownerspb.File, error) {
+	return parse(codeownersFile, true)
+}
+
+// Validate returns all the diagnostics reported by ParseLenient
+// for the given CODEOWNERS file. No diagnostics means the file is valid.
+func Validate(codeownersFile io.Reader) ([]*codeownerspb.Diagnostic, error) {
+	file, err := ParseLenient(codeownersFile)
+	if err != nil {
+		return nil, err
+	}
+	return file.GetDiagnostic(), nil
+}
+
+func parse(codeownersFile io.Reader, lenient bool) (*codeownerspb.File, error) {
 	scanner := bufio.NewScanner(codeownersFile)
 	var rs []*codeownerspb.Rule
 	p := new(parsing)
@@ -28,14 +57,22 @@ func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 		}
 		pattern, owners, ok := p.matchRule()
 		if !ok {
-			return nil, errors.Errorf("failed to match rule: %s", p.line)
+			if !lenient {
+				return nil, errors.Errorf("failed to match rule on line %d: %s", p.lineNumber, p.line)
+			}
+			p.report(codeownerspb.Diagnostic_UNPARSEABLE_LINE, "failed to match rule")
+			continue
 		}
-		// Need to handle this error once, codeownerspb.File supports
-		// error metadata.
 		r := codeownerspb.Rule{
 			Pattern:     unescape(pattern),
 			SectionName: strings.TrimSpace(strings.ToLower(p.section)),
 		}
+		if lenient {
+			if err := codeownerspb.ValidatePattern(r.Pattern); err != nil {
+				p.report(codeownerspb.Diagnostic_INVALID_PATTERN, "invalid pattern %q: %s", r.Pattern, err)
+				continue
+			}
+		}
 		for _, ownerText := range owners {
 			var o codeownerspb.Owner
 			if strings.HasPrefix(ownerText, "@") {
@@ -45,14 +82,61 @@ func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 				// start with an `@` which would make it a handle.
 				o.Email = ownerText
 			}
+			if lenient && !isValidOwner(&o) {
+				p.report(codeownerspb.Diagnostic_UNKNOWN_OWNER_SYNTAX, "owner %q is neither a @handle nor an e-mail address", ownerText)
+				continue
+			}
 			r.Owner = append(r.Owner, &o)
 		}
 		rs = append(rs, &r)
+		p.ruleLines = append(p.ruleLines, p.currentLine())
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
-	return &codeownerspb.File{Rule: rs}, nil
+	file := &codeownerspb.File{Rule: rs}
+	if lenient {
+		p.reportIneffectiveRules(rs)
+		sort.SliceStable(p.diagnostics, func(i, j int) bool {
+			return p.diagnostics[i].LineNumber < p.diagnostics[j].LineNumber
+		})
+		file.Diagnostic = p.diagnostics
+	}
+	return file, nil
+}
+
+// isValidOwner returns true if the owner has a non-empty handle,
+// or an e-mail address which at least contains a local part and a domain.
+func isValidOwner(o *codeownerspb.Owner) bool {
+	if o.GetHandle() != "" {
+		return true
+	}
+	at := strings.LastIndex(o.GetEmail(), "@")
+	return at > 0 && at < len(o.GetEmail())-1
+}
+
+// reportIneffectiveRules reports rules that are never applied, because
+// a rule further down the file either has the same pattern, or matches
+// every path that the rule matches. Like FindRule, this disregards
+// sections: the rule furthest down the file always takes precedence.
+// The rules are expected in the same order as parsed, so that
+// they correspond to p.ruleLines.
+func (p *parsing) reportIneffectiveRules(rules []*codeownerspb.Rule) {
+	for i, r := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			later := rules[j]
+			if later.GetPattern() == r.GetPattern() {
+				p.reportAt(p.ruleLines[i], codeownerspb.Diagnostic_DUPLICATE_PATTERN,
+					"pattern %q is repeated on line %d", r.GetPattern(), p.ruleLines[j].number)
+				break
+			}
+			if codeownerspb.PatternCovers(later.GetPattern(), r.GetPattern()) {
+				p.reportAt(p.ruleLines[i], codeownerspb.Diagnostic_SHADOWED_RULE,
+					"rule is shadowed by pattern %q on line %d", later.GetPattern(), p.ruleLines[j].number)
+				break
+			}
+		}
+	}
 }
 
 // parsing implements matching and parsing primitives for CODEOWNERS files
@@ -62,13 +146,47 @@ type parsing struct {
 	// in such a way that for syntactic purposes, every line can be considered
 	// in isolation.
 	line string
+	// lineNumber is the number of the current line, starting from 1.
+	lineNumber int
 	// The most recently defined section, or "" if none.
 	section string
+	// ruleLines holds the line for every rule parsed so far,
+	// in the same order as rules are parsed.
+	ruleLines []line
+	// diagnostics are the problems reported so far when parsing leniently.
+	diagnostics []*codeownerspb.Diagnostic
+}
+
+// line is a single line of a CODEOWNERS file along with its number.
+type line struct {
+	number int
+	text   string
 }
 
 // nextLine advances parsing to focus on the next line.
 func (p *parsing) nextLine(line string) {
 	p.line = line
+	p.lineNumber++
+}
+
+// currentLine returns the line that is currently being parsed.
+func (p *parsing) currentLine() line {
+	return line{number: p.lineNumber, text: p.line}
+}
+
+// report records a diagnostic for the current line.
+func (p *parsing) report(kind codeownerspb.Diagnostic_Kind, format string, args ...any) {
+	p.reportAt(p.currentLine(), kind, format, args...)
+}
+
+// reportAt records a diagnostic for the given line.
+func (p *parsing) reportAt(l line, kind codeownerspb.Diagnostic_Kind, format string, args ...any) {
+	p.diagnostics = append(p.diagnostics, &codeownerspb.Diagnostic{
+		Kind:       kind,
+		LineNumber: int32(l.number),
+		Line:       l.text,
+		Message:    fmt.Sprintf(format, args...),
+	})
 }
 
 // rulePattern is expected to match a rule line like:
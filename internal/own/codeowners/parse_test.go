@@ -498,3 +498,94 @@ func TestParseSectionWithComment(t *testing.T) {
 	}
 	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
 }
+
+func TestParseLenientRetainsValidRules(t *testing.T) {
+	got, err := codeowners.ParseLenient(strings.NewReader(`# Valid rules are retained.
+*.md @docs-team
+/src/foo**/bar @invalid-pattern
+/src/ @src-team not-an-email @
+/src/ @src-team-again
+[Tests]
+/test/ @qa
+*.go @golang
+`))
+	require.NoError(t, err)
+	wantRules := `/src/ @src-team
+/src/ @src-team-again
+[tests]
+/test/ @qa
+*.go @golang
+`
+	assert.Equal(t, "*.md @docs-team\n"+wantRules, got.Repr())
+	var gotKinds []codeownerspb.Diagnostic_Kind
+	var gotLines []int32
+	for _, d := range got.GetDiagnostic() {
+		gotKinds = append(gotKinds, d.GetKind())
+		gotLines = append(gotLines, d.GetLineNumber())
+	}
+	assert.Equal(t, []codeownerspb.Diagnostic_Kind{
+		codeownerspb.Diagnostic_INVALID_PATTERN,
+		codeownerspb.Diagnostic_UNKNOWN_OWNER_SYNTAX,
+		codeownerspb.Diagnostic_UNKNOWN_OWNER_SYNTAX,
+		codeownerspb.Diagnostic_DUPLICATE_PATTERN,
+	}, gotKinds)
+	assert.Equal(t, []int32{3, 4, 4, 4}, gotLines)
+	assert.Equal(t, "/src/foo**/bar @invalid-pattern", got.GetDiagnostic()[0].GetLine())
+}
+
+func TestParseLenientReportsShadowedRules(t *testing.T) {
+	diagnostics, err := codeowners.Validate(strings.NewReader(`/docs/internal/index.md @index-owner
+docs/internal/ @internal-owner
+/src/main.go @main-owner
+[Other]
+/docs/ @other-section-owner
+[Documentation]
+docs/ @docs-owner
+* @everyone
+`))
+	require.NoError(t, err)
+	want := []*codeownerspb.Diagnostic{
+		{
+			Kind:       codeownerspb.Diagnostic_SHADOWED_RULE,
+			LineNumber: 1,
+			Line:       "/docs/internal/index.md @index-owner",
+			Message:    `rule is shadowed by pattern "docs/internal/" on line 2`,
+		},
+		{
+			Kind:       codeownerspb.Diagnostic_SHADOWED_RULE,
+			LineNumber: 2,
+			Line:       "docs/internal/ @internal-owner",
+			Message:    `rule is shadowed by pattern "docs/" on line 7`,
+		},
+		// Rules are shadowed by rules in other sections too,
+		// since ownership is resolved regardless of sections.
+		{
+			Kind:       codeownerspb.Diagnostic_SHADOWED_RULE,
+			LineNumber: 3,
+			Line:       "/src/main.go @main-owner",
+			Message:    `rule is shadowed by pattern "*" on line 8`,
+		},
+		{
+			Kind:       codeownerspb.Diagnostic_SHADOWED_RULE,
+			LineNumber: 5,
+			Line:       "/docs/ @other-section-owner",
+			Message:    `rule is shadowed by pattern "docs/" on line 7`,
+		},
+		{
+			Kind:       codeownerspb.Diagnostic_SHADOWED_RULE,
+			LineNumber: 7,
+			Line:       "docs/ @docs-owner",
+			Message:    `rule is shadowed by pattern "*" on line 8`,
+		},
+	}
+	assert.Equal(t, want, diagnostics)
+}
+
+func TestValidateNoDiagnostics(t *testing.T) {
+	diagnostics, err := codeowners.Validate(strings.NewReader(`* @everyone
+/docs/ @docs-owner
+*.go me@example.com
+`))
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
@@ -24,6 +24,75 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Diagnostic_Kind int32
+
+const (
+	Diagnostic_KIND_UNSPECIFIED Diagnostic_Kind = 0
+	// The line is neither a rule, a section header nor a comment.
+	// Nothing from the line is retained.
+	Diagnostic_UNPARSEABLE_LINE Diagnostic_Kind = 1
+	// The pattern of the rule is not a valid glob pattern.
+	// The whole rule is dropped.
+	Diagnostic_INVALID_PATTERN Diagnostic_Kind = 2
+	// One of the owners is neither a `@handle` nor an e-mail address.
+	// The owner is dropped, but the rule is retained.
+	Diagnostic_UNKNOWN_OWNER_SYNTAX Diagnostic_Kind = 3
+	// The same pattern appears again further down the file.
+	// Only the last rule with the pattern is ever applied, so this one
+	// has no effect.
+	Diagnostic_DUPLICATE_PATTERN Diagnostic_Kind = 4
+	// A rule further down the file matches every path that
+	// this rule matches, so this rule is never applied.
+	Diagnostic_SHADOWED_RULE Diagnostic_Kind = 5
+)
+
+// Enum value maps for Diagnostic_Kind.
+var (
+	Diagnostic_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "UNPARSEABLE_LINE",
+		2: "INVALID_PATTERN",
+		3: "UNKNOWN_OWNER_SYNTAX",
+		4: "DUPLICATE_PATTERN",
+		5: "SHADOWED_RULE",
+	}
+	Diagnostic_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED":     0,
+		"UNPARSEABLE_LINE":     1,
+		"INVALID_PATTERN":      2,
+		"UNKNOWN_OWNER_SYNTAX": 3,
+		"DUPLICATE_PATTERN":    4,
+		"SHADOWED_RULE":        5,
+	}
+)
+
+func (x Diagnostic_Kind) Enum() *Diagnostic_Kind {
+	p := new(Diagnostic_Kind)
+	*p = x
+	return p
+}
+
+func (x Diagnostic_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Diagnostic_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_codeowners_proto_enumTypes[0].Descriptor()
+}
+
+func (Diagnostic_Kind) Type() protoreflect.EnumType {
+	return &file_codeowners_proto_enumTypes[0]
+}
+
+func (x Diagnostic_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Diagnostic_Kind.Descriptor instead.
+func (Diagnostic_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_codeowners_proto_rawDescGZIP(), []int{3, 0}
+}
+
 // File represents the contents of a single CODEOWNERS file.
 // As specified by various CODEOWNERS implementations the following apply:
 //   - There is at most one CODEOWNERS file per repository.
@@ -40,6 +109,12 @@ type File struct {
 	unknownFields protoimpl.UnknownFields
 
 	Rule []*Rule `protobuf:"bytes,1,rep,name=rule,proto3" json:"rule,omitempty"`
+	// Diagnostics list problems found in the text representation of the file.
+	// They are only recorded when parsing leniently, in which case every
+	// rule that could be parsed is kept, and each line that could not
+	// be (fully) understood is reported here instead of failing the parse.
+	// Order follows line numbers.
+	Diagnostic []*Diagnostic `protobuf:"bytes,2,rep,name=diagnostic,proto3" json:"diagnostic,omitempty"`
 }
 
 func (x *File) Reset() {
@@ -81,6 +156,13 @@ func (x *File) GetRule() []*Rule {
 	return nil
 }
 
+func (x *File) GetDiagnostic() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostic
+	}
+	return nil
+}
+
 // Rule associates a single pattern to match a path with an owner.
 type Rule struct {
 	state         protoimpl.MessageState
@@ -250,29 +332,126 @@ func (x *Owner) GetEmail() string {
 	return ""
 }
 
+// Diagnostic describes a single problem with a line of a CODEOWNERS file.
+type Diagnostic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind Diagnostic_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=codeowners.Diagnostic_Kind" json:"kind,omitempty"`
+	// Line number within the file, starting from 1.
+	LineNumber int32 `protobuf:"varint,2,opt,name=line_number,json=lineNumber,proto3" json:"line_number,omitempty"`
+	// Raw text of the line, as it appears in the file.
+	Line string `protobuf:"bytes,3,opt,name=line,proto3" json:"line,omitempty"`
+	// Human readable explanation of the problem.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Diagnostic) Reset() {
+	*x = Diagnostic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_codeowners_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Diagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostic) ProtoMessage() {}
+
+func (x *Diagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_codeowners_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
+func (*Diagnostic) Descriptor() ([]byte, []int) {
+	return file_codeowners_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Diagnostic) GetKind() Diagnostic_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Diagnostic_KIND_UNSPECIFIED
+}
+
+func (x *Diagnostic) GetLineNumber() int32 {
+	if x != nil {
+		return x.LineNumber
+	}
+	return 0
+}
+
+func (x *Diagnostic) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_codeowners_proto protoreflect.FileDescriptor
 
 var file_codeowners_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0a, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x2c,
+	0x74, 0x6f, 0x12, 0x0a, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x64,
 	0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x01,
 	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72,
-	0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0x6c, 0x0a, 0x04,
-	0x52, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x27,
-	0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72,
-	0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x35, 0x0a, 0x05, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69,
-	0x6c, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x6f, 0x77, 0x6e, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x36, 0x0a, 0x0a,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x44, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x22, 0x6c, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x27, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x73, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x35, 0x0a, 0x05, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x9a, 0x02, 0x0a, 0x0a, 0x44, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x2f, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x4b,
+	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6e,
+	0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x04, 0x4b, 0x69, 0x6e,
+	0x64, 0x12, 0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e, 0x50, 0x41, 0x52,
+	0x53, 0x45, 0x41, 0x42, 0x4c, 0x45, 0x5f, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x13, 0x0a,
+	0x0f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e,
+	0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x4f, 0x57,
+	0x4e, 0x45, 0x52, 0x5f, 0x53, 0x59, 0x4e, 0x54, 0x41, 0x58, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11,
+	0x44, 0x55, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52,
+	0x4e, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x48, 0x41, 0x44, 0x4f, 0x57, 0x45, 0x44, 0x5f,
+	0x52, 0x55, 0x4c, 0x45, 0x10, 0x05, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x77, 0x6e, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -287,20 +466,25 @@ func file_codeowners_proto_rawDescGZIP() []byte {
 	return file_codeowners_proto_rawDescData
 }
 
-var file_codeowners_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_codeowners_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_codeowners_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_codeowners_proto_goTypes = []interface{}{
-	(*File)(nil),  // 0: codeowners.File
-	(*Rule)(nil),  // 1: codeowners.Rule
-	(*Owner)(nil), // 2: codeowners.Owner
+	(Diagnostic_Kind)(0), // 0: codeowners.Diagnostic.Kind
+	(*File)(nil),         // 1: codeowners.File
+	(*Rule)(nil),         // 2: codeowners.Rule
+	(*Owner)(nil),        // 3: codeowners.Owner
+	(*Diagnostic)(nil),   // 4: codeowners.Diagnostic
 }
 var file_codeowners_proto_depIdxs = []int32{
-	1, // 0: codeowners.File.rule:type_name -> codeowners.Rule
-	2, // 1: codeowners.Rule.owner:type_name -> codeowners.Owner
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: codeowners.File.rule:type_name -> codeowners.Rule
+	4, // 1: codeowners.File.diagnostic:type_name -> codeowners.Diagnostic
+	3, // 2: codeowners.Rule.owner:type_name -> codeowners.Owner
+	0, // 3: codeowners.Diagnostic.kind:type_name -> codeowners.Diagnostic.Kind
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_codeowners_proto_init() }
@@ -345,19 +529,32 @@ func file_codeowners_proto_init() {
 				return nil
 			}
 		}
+		file_codeowners_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Diagnostic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_codeowners_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_codeowners_proto_goTypes,
 		DependencyIndexes: file_codeowners_proto_depIdxs,
+		EnumInfos:         file_codeowners_proto_enumTypes,
 		MessageInfos:      file_codeowners_proto_msgTypes,
 	}.Build()
 	File_codeowners_proto = out.File
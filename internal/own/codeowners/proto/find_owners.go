@@ -3,6 +3,7 @@ package proto
 import (
 	"fmt"
 	"math/big"
+	"path"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -12,17 +13,24 @@ import (
 // Rules are evaluated in order: Returned owners come from the rule which pattern matches
 // given path, that is the furthest down the file.
 func (x *File) FindOwners(path string) []*Owner {
-	var owners []*Owner
+	return x.FindRule(path).GetOwner()
+}
+
+// FindRule returns the rule that determines ownership of given path as per
+// this CODEOWNERS file, that is the rule furthest down the file which pattern
+// matches the path. If no rule matches, nil is returned.
+func (x *File) FindRule(path string) *Rule {
+	var matching *Rule
 	for _, rule := range x.GetRule() {
 		glob, err := compile(rule.GetPattern())
 		if err != nil {
 			continue
 		}
 		if glob.match(path) {
-			owners = rule.GetOwner()
+			matching = rule
 		}
 	}
-	return owners
+	return matching
 }
 
 const separator = "/"
@@ -57,6 +65,17 @@ type anyMatch struct{}
 func (p anyMatch) String() string      { return "*" }
 func (p anyMatch) Match(_ string) bool { return true }
 
+// wildcardMatch is indicated by a file or directory name containing
+// wildcards like `*.md`, and matches names as per path.Match.
+type wildcardMatch string
+
+func (p wildcardMatch) String() string { return string(p) }
+func (p wildcardMatch) Match(part string) bool {
+	// Error can only be ErrBadPattern, which is checked by compile.
+	matched, _ := path.Match(string(p), part)
+	return matched
+}
+
 // compile translates a text representation of a glob pattern
 // to an executable one that can `match` file paths.
 func compile(pattern string) (globPattern, error) {
@@ -75,6 +94,16 @@ func compile(pattern string) (globPattern, error) {
 		case "**":
 			glob = append(glob, anySubPath{})
 		default:
+			if strings.Contains(part, "**") {
+				return nil, errors.Newf("%q: ** can only be used as a whole path segment", part)
+			}
+			if strings.ContainsAny(part, "*?[") {
+				if _, err := path.Match(part, ""); err != nil {
+					return nil, errors.Wrapf(err, "%q", part)
+				}
+				glob = append(glob, wildcardMatch(part))
+				continue
+			}
 			glob = append(glob, exactMatch(part))
 		}
 	}
@@ -52,6 +52,20 @@ func TestFileOwnersMatch(t *testing.T) {
 				"/main/src/foo/bar/README.md",
 			},
 		},
+		{
+			pattern: "*.md",
+			paths: []string{
+				"/README.md",
+				"/docs/index.md",
+			},
+		},
+		{
+			pattern: "docs/*.md",
+			paths: []string{
+				"/docs/index.md",
+				"/src/docs/index.md",
+			},
+		},
 	}
 	for _, c := range cases {
 		for _, path := range c.paths {
@@ -118,6 +132,13 @@ func TestFileOwnersNoMatch(t *testing.T) {
 				"/nested/main/src/foo/bar/README.md",
 			},
 		},
+		{
+			pattern: "docs/*.md",
+			paths: []string{
+				"/docs/index.js",
+				"/docs/nested/index.md",
+			},
+		},
 	}
 	for _, c := range cases {
 		for _, path := range c.paths {
@@ -160,3 +181,25 @@ func TestFileOwnersOrder(t *testing.T) {
 	got := file.FindOwners("/top-level-directory/some/path/main.go")
 	assert.Equal(t, wantOwner, got)
 }
+
+func TestFileFindRule(t *testing.T) {
+	wantRule := &codeownerspb.Rule{
+		Pattern: "/src/",
+		Owner:   []*codeownerspb.Owner{{Handle: "src-owner"}},
+	}
+	file := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern: "*",
+				Owner:   []*codeownerspb.Owner{{Handle: "default-owner"}},
+			},
+			wantRule,
+			{
+				Pattern: "/docs/",
+				Owner:   []*codeownerspb.Owner{{Handle: "docs-owner"}},
+			},
+		},
+	}
+	assert.Same(t, wantRule, file.FindRule("/src/main.go"))
+	assert.Nil(t, (&codeownerspb.File{}).FindRule("/src/main.go"))
+}
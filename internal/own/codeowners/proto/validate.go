@@ -0,0 +1,60 @@
+package proto
+
+import "strings"
+
+// ValidatePattern returns an error if given CODEOWNERS pattern is not
+// a valid glob pattern, and thus would never be matched by FindOwners.
+func ValidatePattern(pattern string) error {
+	_, err := compile(pattern)
+	return err
+}
+
+// PatternCovers returns true if every path matched by pattern `other`
+// is also matched by `pattern`. The check is conservative: it only
+// recognizes patterns that match everything (like `*` or `**`) and
+// directory patterns (like `/docs/`) that contain other patterns.
+// False is returned whenever coverage cannot be established.
+func PatternCovers(pattern, other string) bool {
+	// Patterns consisting only of wildcards and separators may match
+	// everything. Other patterns are not compiled, as this check is
+	// often performed for every pair of rules in a file.
+	if strings.Trim(pattern, "*"+separator) == "" {
+		glob, err := compile(pattern)
+		return err == nil && glob.matchesEverything()
+	}
+	if !strings.HasSuffix(pattern, separator) || strings.Contains(pattern, "*") {
+		return false
+	}
+	// Anchored directory pattern like `/docs/` only covers other anchored
+	// patterns that are rooted within the same directory.
+	if strings.HasPrefix(pattern, separator) {
+		return strings.HasPrefix(other, pattern)
+	}
+	// Not anchored directory pattern like `docs/` matches at any depth,
+	// so it covers both `docs/index.md` and `/docs/index.md`.
+	return strings.HasPrefix(strings.TrimPrefix(other, separator), pattern)
+}
+
+// matchesEverything returns true if this glob pattern matches any
+// file path, that is, it consists of a leading `**` followed only
+// by `**` parts, and at most a single trailing `*`.
+func (glob globPattern) matchesEverything() bool {
+	if len(glob) == 0 {
+		return false
+	}
+	if _, ok := glob[0].(anySubPath); !ok {
+		return false
+	}
+	for i, part := range glob[1:] {
+		switch part.(type) {
+		case anySubPath:
+			continue
+		case anyMatch:
+			if i+2 == len(glob) {
+				continue
+			}
+		}
+		return false
+	}
+	return true
+}
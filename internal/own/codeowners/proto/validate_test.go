@@ -0,0 +1,42 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+func TestValidatePattern(t *testing.T) {
+	for _, pattern := range []string{"*", "**", "*.js", "/docs/", "docs/**/index.md"} {
+		assert.NoError(t, codeownerspb.ValidatePattern(pattern), pattern)
+	}
+	for _, pattern := range []string{"docs//index.md", "/src/foo**/bar", "/docs/[a-z.md"} {
+		assert.Error(t, codeownerspb.ValidatePattern(pattern), pattern)
+	}
+}
+
+func TestPatternCovers(t *testing.T) {
+	cases := []struct {
+		pattern string
+		other   string
+		want    bool
+	}{
+		{pattern: "*", other: "/docs/index.md", want: true},
+		{pattern: "**", other: "docs/", want: true},
+		{pattern: "/**/*", other: "*.js", want: true},
+		{pattern: "/docs/", other: "/docs/index.md", want: true},
+		{pattern: "/docs/", other: "/docs/internal/", want: true},
+		{pattern: "/docs/", other: "docs/index.md", want: false},
+		{pattern: "docs/", other: "docs/index.md", want: true},
+		{pattern: "docs/", other: "/docs/index.md", want: true},
+		{pattern: "/*", other: "/docs/index.md", want: false},
+		{pattern: "*.js", other: "/src/*.js", want: false},
+		{pattern: "/docs", other: "/docs/index.md", want: false},
+	}
+	for _, c := range cases {
+		got := codeownerspb.PatternCovers(c.pattern, c.other)
+		assert.Equal(t, c.want, got, "PatternCovers(%q, %q)", c.pattern, c.other)
+	}
+}
@@ -82,6 +82,11 @@ var (
 		Help: "Incremented each time the scheduler updates a repository due to user traffic.",
 	})
 
+	schedLeaseConflicts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_sched_lease_conflicts",
+		Help: "Incremented each time the scheduler skips an update because the repository is not due or being updated by another instance.",
+	})
+
 	schedKnownRepos = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "src_repoupdater_sched_known_repos",
 		Help: "The number of repositories that are managed by the scheduler.",
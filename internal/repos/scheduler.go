@@ -3,6 +3,7 @@ package repos
 import (
 	"container/heap"
 	"context"
+	"fmt"
 	"math/rand"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
@@ -108,11 +110,20 @@ const (
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
 // is limited by the gitMaxConcurrentClones site configuration.
+//
+// The schedule is persisted in the repo_update_schedule table, so that learned intervals
+// survive restarts and several repo-updater instances can share the work: the in-memory
+// schedule of an instance is a cache of the persisted one, and an instance leases a repo in
+// the database before updating it, see scheduleStore.
 type UpdateScheduler struct {
 	db          database.DB
+	store       scheduleStore
 	updateQueue *updateQueue
 	schedule    *schedule
 	logger      log.Logger
+
+	// owner identifies this instance in the leases it holds on repos.
+	owner string
 }
 
 // A configuredRepo represents the configuration data for a given repo from
@@ -133,7 +144,9 @@ func NewUpdateScheduler(logger log.Logger, db database.DB) *UpdateScheduler {
 	updateSchedLogger := logger.Scoped("UpdateScheduler", "repo update scheduler")
 
 	return &UpdateScheduler{
-		db: db,
+		db:    db,
+		store: newScheduleStore(db),
+		owner: fmt.Sprintf("%s-%d", hostname.Get(), rand.Int63()),
 		updateQueue: &updateQueue{
 			index:         make(map[api.RepoID]*repoUpdate),
 			notifyEnqueue: make(chan struct{}, notifyChanBuffer),
@@ -212,6 +225,13 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 				defer cancel()
 				defer s.updateQueue.remove(repo, true)
 
+				// Another instance may have updated the repo or be updating it.
+				if !s.lease(ctx, repo) {
+					return
+				}
+				var newInterval time.Duration
+				defer func() { s.release(repo, newInterval) }()
+
 				// This is a blocking call since the repo will be cloned synchronously by gitserver
 				// if it doesn't exist or update it if it does. The timeout of this request depends
 				// on the value of conf.GitLongCommandTimeout() or if the passed context has a set
@@ -230,7 +250,7 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 				}
 
 				if interval := getCustomInterval(subLogger, conf.Get(), string(repo.Name)); interval > 0 {
-					s.schedule.updateInterval(repo, interval)
+					newInterval, _ = s.schedule.updateInterval(repo, interval)
 					return
 				}
 
@@ -238,19 +258,73 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 					// On error we will double the current interval so that we back off and don't
 					// get stuck with problematic repos with low intervals.
 					if currentInterval, ok := s.schedule.getCurrentInterval(repo); ok {
-						s.schedule.updateInterval(repo, currentInterval*2)
+						newInterval, _ = s.schedule.updateInterval(repo, currentInterval*2)
 					}
 				} else if resp != nil && resp.LastFetched != nil && resp.LastChanged != nil {
 					// This is the heuristic that is described in the UpdateScheduler documentation.
 					// Update that documentation if you update this logic.
 					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
-					s.schedule.updateInterval(repo, interval)
+					newInterval, _ = s.schedule.updateInterval(repo, interval)
 				}
 			}(ctx, repo, cancel)
 		}
 	}
 }
 
+// leaseDuration is how long an instance may update a repo before other instances can lease
+// it again. Updates are bounded by the git long command timeout.
+func leaseDuration() time.Duration {
+	return conf.GitLongCommandTimeout() + 5*time.Minute
+}
+
+// lease leases a repo in the persisted schedule before it is updated. It returns false if the
+// repo must not be updated by this instance, in which case the in-memory schedule of the repo
+// is synced with the persisted one. If the persisted schedule is unavailable, the repo is
+// updated anyway.
+func (s *UpdateScheduler) lease(ctx context.Context, repo configuredRepo) bool {
+	entry, leased, err := s.store.Lease(ctx, repo.ID, s.owner, leaseDuration())
+	if err != nil {
+		schedError.WithLabelValues("lease").Inc()
+		s.logger.Warn("leasing repo", log.String("uri", string(repo.Name)), log.Error(err))
+		return true
+	}
+	if !leased {
+		schedLeaseConflicts.Inc()
+		if entry.Repo.ID != 0 {
+			s.schedule.sync([]scheduleEntry{entry})
+		}
+	}
+	return leased
+}
+
+// release releases the lease of a repo after it was updated, rescheduling it with interval if
+// interval is positive.
+func (s *UpdateScheduler) release(repo configuredRepo, interval time.Duration) {
+	// Release the lease even if the update was canceled, so that other instances don't have to
+	// wait for the lease to expire.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.store.Release(ctx, repo.ID, s.owner, interval); err != nil {
+		schedError.WithLabelValues("release").Inc()
+		s.logger.Warn("releasing repo", log.String("uri", string(repo.Name)), log.Error(err))
+	}
+}
+
+// persist adds repos to the persisted schedule, or pulls them forward if pullForward is true,
+// and syncs the in-memory schedule with the result.
+func (s *UpdateScheduler) persist(ctx context.Context, ids []api.RepoID, due time.Time, p priority, pullForward bool) {
+	if len(ids) == 0 {
+		return
+	}
+	entries, err := s.store.Schedule(ctx, ids, minDelay, due, p, pullForward)
+	if err != nil {
+		schedError.WithLabelValues("persist").Inc()
+		s.logger.Warn("persisting schedule", log.Error(err))
+		return
+	}
+	s.schedule.sync(entries)
+}
+
 func getCustomInterval(logger log.Logger, c *conf.Unified, repoName string) time.Duration {
 	if c == nil {
 		return 0
@@ -301,28 +375,51 @@ var configuredLimiter = func() *mutablelimiter.Limiter {
 //	             commits. Enqueue for asap clone (or fetch).
 //	Unmodified - we likely already have this cloned. Just rely on
 //	             the scheduler and do not enqueue.
-func (s *UpdateScheduler) UpdateFromDiff(diff Diff) {
+func (s *UpdateScheduler) UpdateFromDiff(ctx context.Context, diff Diff) {
+	var removed, unknown []api.RepoID
+
 	for _, r := range diff.Deleted {
 		s.remove(r)
+		removed = append(removed, r.ID)
 	}
 
-	for _, r := range diff.Added {
-		s.upsert(r, true)
+	// Repos are only enqueued once they are due in the persisted schedule, otherwise the
+	// update loop could fail to lease them and skip their update.
+	var enqueued []api.RepoID
+	toEnqueue := append(append([]*types.Repo{}, diff.Added...), diff.Modified.Repos()...)
+	for _, r := range toEnqueue {
+		s.upsert(r)
+		enqueued = append(enqueued, r.ID)
 	}
-	for _, r := range diff.Modified.Repos() {
-		s.upsert(r, true)
+	// Enqueued repos are due now, so that whichever instance dequeues them first can lease
+	// them.
+	s.persist(ctx, enqueued, timeNow(), priorityLow, true)
+	for _, r := range toEnqueue {
+		s.enqueue(r)
 	}
 
 	known := len(diff.Added) + len(diff.Modified)
 	for _, r := range diff.Unmodified {
 		if r.IsDeleted() {
 			s.remove(r)
+			removed = append(removed, r.ID)
 			continue
 		}
 
 		known++
-		s.upsert(r, false)
+		if !s.upsert(r) {
+			unknown = append(unknown, r.ID)
+		}
 	}
+
+	if len(removed) > 0 {
+		if err := s.store.Remove(ctx, removed); err != nil {
+			schedError.WithLabelValues("persist").Inc()
+			s.logger.Warn("removing repos from persisted schedule", log.Error(err))
+		}
+	}
+	// Repos new to this instance adopt their persisted schedule.
+	s.persist(ctx, unknown, timeNow().Add(minDelay), priorityLow, false)
 }
 
 // PrioritiseUncloned will treat any repos listed in ids as uncloned, which in
@@ -330,13 +427,23 @@ func (s *UpdateScheduler) UpdateFromDiff(diff Diff) {
 //
 // This method should be called periodically with the list of all repositories
 // managed by the scheduler that are not cloned on gitserver.
-func (s *UpdateScheduler) PrioritiseUncloned(repos []types.MinimalRepo) {
+func (s *UpdateScheduler) PrioritiseUncloned(ctx context.Context, repos []types.MinimalRepo) {
 	s.schedule.prioritiseUncloned(repos)
+	s.persist(ctx, minimalRepoIDs(repos), timeNow().Add(minDelay), priorityLow, true)
 }
 
 // EnsureScheduled ensures that all repos in repos exist in the scheduler.
-func (s *UpdateScheduler) EnsureScheduled(repos []types.MinimalRepo) {
-	s.schedule.insertNew(repos)
+func (s *UpdateScheduler) EnsureScheduled(ctx context.Context, repos []types.MinimalRepo) {
+	added := s.schedule.insertNew(repos)
+	s.persist(ctx, minimalRepoIDs(added), timeNow().Add(minDelay), priorityLow, false)
+}
+
+func minimalRepoIDs(repos []types.MinimalRepo) []api.RepoID {
+	ids := make([]api.RepoID, 0, len(repos))
+	for _, r := range repos {
+		ids = append(ids, r.ID)
+	}
+	return ids
 }
 
 // ListRepoIDs lists the ids of all repos managed by the scheduler
@@ -354,20 +461,20 @@ func (s *UpdateScheduler) ListRepoIDs() []api.RepoID {
 // upsert adds r to the scheduler for periodic updates. If r.ID is already in
 // the scheduler, then the fields are updated (upsert).
 //
-// If enqueue is true then r is also enqueued to the update queue for a git
-// fetch/clone soon.
-func (s *UpdateScheduler) upsert(r *types.Repo, enqueue bool) {
+// It returns whether r was already in the scheduler.
+func (s *UpdateScheduler) upsert(r *types.Repo) (known bool) {
 	repo := configuredRepoFromRepo(r)
-	logger := s.logger.With(log.String("repo", string(r.Name)))
 
-	updated := s.schedule.upsert(repo)
-	logger.Debug("scheduler.schedule.upserted", log.Bool("updated", updated))
+	known = s.schedule.upsert(repo)
+	s.logger.Debug("scheduler.schedule.upserted", log.String("repo", string(r.Name)), log.Bool("updated", known))
+	return known
+}
 
-	if !enqueue {
-		return
-	}
-	updated = s.updateQueue.enqueue(repo, priorityLow)
-	logger.Debug("scheduler.updateQueue.enqueued", log.Bool("updated", updated))
+// enqueue enqueues r to the update queue for a git fetch/clone soon. The repo
+// must be due in the persisted schedule, so that the update loop can lease it.
+func (s *UpdateScheduler) enqueue(r *types.Repo) {
+	updated := s.updateQueue.enqueue(configuredRepoFromRepo(r), priorityLow)
+	s.logger.Debug("scheduler.updateQueue.enqueued", log.String("repo", string(r.Name)), log.Bool("updated", updated))
 }
 
 func (s *UpdateScheduler) remove(r *types.Repo) {
@@ -393,13 +500,20 @@ func configuredRepoFromRepo(r *types.Repo) configuredRepo {
 }
 
 // UpdateOnce causes a single update of the given repository.
-// It neither adds nor removes the repo from the schedule.
-func (s *UpdateScheduler) UpdateOnce(id api.RepoID, name api.RepoName) {
+// It does not remove the repo from the schedule.
+func (s *UpdateScheduler) UpdateOnce(ctx context.Context, id api.RepoID, name api.RepoName) {
 	repo := configuredRepo{
 		ID:   id,
 		Name: name,
 	}
 	schedManualFetch.Inc()
+
+	// The repo must be due in the persisted schedule before it is enqueued, for the update
+	// loop to lease it.
+	if _, err := s.store.Schedule(ctx, []api.RepoID{id}, minDelay, timeNow(), priorityHigh, true); err != nil {
+		schedError.WithLabelValues("persist").Inc()
+		s.logger.Warn("persisting manual update", log.String("uri", string(name)), log.Error(err))
+	}
 	s.updateQueue.enqueue(repo, priorityHigh)
 }
 
@@ -414,6 +528,18 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 		Name: "repos",
 	}
 
+	persisted, err := s.store.List(ctx)
+	if err != nil {
+		s.logger.Warn("listing persisted schedule for debug page", log.Error(err))
+	}
+	for _, entry := range persisted {
+		data.Schedule = append(data.Schedule, &scheduledRepoUpdate{
+			Repo:     entry.Repo,
+			Interval: entry.Interval,
+			Due:      entry.Due,
+		})
+	}
+
 	s.schedule.mu.Lock()
 	schedule := schedule{
 		heap: make([]*scheduledRepoUpdate, len(s.schedule.heap)),
@@ -427,7 +553,8 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 	}
 	s.schedule.mu.Unlock()
 
-	for len(schedule.heap) > 0 {
+	// Fall back to the in-memory schedule of this instance.
+	for err != nil && len(schedule.heap) > 0 {
 		update := heap.Pop(&schedule).(*scheduledRepoUpdate)
 		data.Schedule = append(data.Schedule, update)
 	}
@@ -452,7 +579,6 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 		data.UpdateQueue = append(data.UpdateQueue, update)
 	}
 
-	var err error
 	data.SyncJobs, err = s.db.ExternalServices().GetSyncJobs(ctx, database.ExternalServicesGetSyncJobsOptions{})
 	if err != nil {
 		s.logger.Warn("getting external service sync jobs for debug page", log.Error(err))
@@ -462,19 +588,35 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 }
 
 // ScheduleInfo returns the current schedule info for a repo.
-func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
+//
+// The schedule info comes from the persisted schedule, and the queue info from the queue of
+// this instance. A repo being updated by another instance is reported as updating.
+func (s *UpdateScheduler) ScheduleInfo(ctx context.Context, id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
 	var result protocol.RepoUpdateSchedulerInfoResult
 
-	s.schedule.mu.Lock()
-	if update := s.schedule.index[id]; update != nil {
+	entry, index, total, err := s.store.Get(ctx, id)
+	if err != nil {
+		s.logger.Warn("getting persisted schedule", log.Error(err))
+
+		// Fall back to the in-memory schedule of this instance.
+		s.schedule.mu.Lock()
+		if update := s.schedule.index[id]; update != nil {
+			result.Schedule = &protocol.RepoScheduleState{
+				Index:           update.Index,
+				Total:           len(s.schedule.index),
+				IntervalSeconds: int(update.Interval / time.Second),
+				Due:             update.Due,
+			}
+		}
+		s.schedule.mu.Unlock()
+	} else if entry != nil {
 		result.Schedule = &protocol.RepoScheduleState{
-			Index:           update.Index,
-			Total:           len(s.schedule.index),
-			IntervalSeconds: int(update.Interval / time.Second),
-			Due:             update.Due,
+			Index:           index,
+			Total:           total,
+			IntervalSeconds: int(entry.Interval / time.Second),
+			Due:             entry.Due,
 		}
 	}
-	s.schedule.mu.Unlock()
 
 	s.updateQueue.mu.Lock()
 	if update := s.updateQueue.index[id]; update != nil {
@@ -487,6 +629,13 @@ func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 	}
 	s.updateQueue.mu.Unlock()
 
+	if result.Queue == nil && entry != nil && entry.leased(timeNow()) {
+		result.Queue = &protocol.RepoQueueState{
+			Updating: true,
+			Priority: int(entry.Priority),
+		}
+	}
+
 	return &result
 }
 
@@ -758,8 +907,9 @@ func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
 	}
 }
 
-// insertNew will insert repos only if they are not known to the scheduler
-func (s *schedule) insertNew(repos []types.MinimalRepo) {
+// insertNew will insert repos only if they are not known to the scheduler, and
+// returns the inserted repos.
+func (s *schedule) insertNew(repos []types.MinimalRepo) (added []types.MinimalRepo) {
 	required := make(map[string]struct{}, len(repos))
 	for _, n := range repos {
 		required[strings.ToLower(string(n.Name))] = struct{}{}
@@ -779,7 +929,7 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, repo := range configuredRepos {
+	for i, repo := range configuredRepos {
 		if update := s.index[repo.ID]; update != nil {
 			continue
 		}
@@ -788,22 +938,49 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 			Interval: minDelay,
 			Due:      due,
 		})
+		added = append(added, repos[i])
 		rescheduleTimer = true
 	}
 
 	if rescheduleTimer {
 		s.rescheduleTimer()
 	}
+	return added
 }
 
-// updateInterval updates the update interval of a repo in the schedule.
+// sync updates the interval and due time of the given repos in the schedule to their
+// persisted values. Repos not in the schedule are ignored.
+func (s *schedule) sync(entries []scheduleEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rescheduleTimer := false
+	for _, entry := range entries {
+		update := s.index[entry.Repo.ID]
+		if update == nil || (update.Interval == entry.Interval && update.Due.Equal(entry.Due)) {
+			continue
+		}
+		update.Interval = entry.Interval
+		update.Due = entry.Due
+		heap.Fix(s, update.Index)
+		rescheduleTimer = true
+	}
+
+	if rescheduleTimer {
+		s.rescheduleTimer()
+	}
+}
+
+// updateInterval updates the update interval of a repo in the schedule, and returns
+// the new interval including jitter.
 // It does nothing if the repo is not in the schedule.
-func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
+func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) (updated time.Duration, ok bool) {
 	if repo.ID == 0 {
 		panic("repo.id is zero")
 	}
 
 	s.mu.Lock()
+	defer s.mu.Unlock()
 	if update := s.index[repo.ID]; update != nil {
 		switch {
 		case interval > maxDelay:
@@ -825,8 +1002,9 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 		)
 		heap.Fix(s, update.Index)
 		s.rescheduleTimer()
+		return update.Interval, true
 	}
-	s.mu.Unlock()
+	return 0, false
 }
 
 // getCurrentInterval gets the current interval for the supplied repo and a bool
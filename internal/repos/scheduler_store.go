@@ -0,0 +1,251 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// scheduleEntry is the persisted update schedule of a repo.
+type scheduleEntry struct {
+	Repo     configuredRepo
+	Interval time.Duration
+	Due      time.Time
+	// Priority is the priority of the update requested since the last update of the repo.
+	Priority priority
+	// LeaseOwner is the scheduler instance updating the repo, if any.
+	LeaseOwner     string
+	LeaseExpiresAt time.Time
+}
+
+// leased returns whether the repo is being updated by a scheduler instance.
+func (e *scheduleEntry) leased(now time.Time) bool {
+	return e.LeaseOwner != "" && e.LeaseExpiresAt.After(now)
+}
+
+// scheduleStore persists the update schedule of repos, so that it survives restarts of
+// repo-updater and is shared by all of its replicas.
+//
+// The schedule of each instance is a cache of the persisted schedule. Before updating a repo,
+// an instance acquires a lease on it, which only succeeds if the persisted schedule says the
+// repo is due and no other instance is updating it. This way each update is performed by a
+// single instance, whichever dequeues it first.
+type scheduleStore interface {
+	// Schedule adds the given repos to the schedule with the given interval and due time, and
+	// returns their persisted schedule. Repos already in the schedule keep their interval and
+	// due time, unless pullForward is true and due is before their due time, in which case
+	// they are updated at due with the given priority.
+	Schedule(ctx context.Context, ids []api.RepoID, interval time.Duration, due time.Time, p priority, pullForward bool) ([]scheduleEntry, error)
+	// Remove removes the given repos from the schedule.
+	Remove(ctx context.Context, ids []api.RepoID) error
+	// Lease acquires a lease of the given duration on a repo for owner, if the repo is due
+	// and not leased by another owner. It returns the persisted schedule of the repo, whose
+	// due time is moved one interval forward when the lease is acquired.
+	Lease(ctx context.Context, id api.RepoID, owner string, duration time.Duration) (entry scheduleEntry, leased bool, err error)
+	// Release releases the lease of owner on a repo. If interval is positive, the repo is
+	// rescheduled one interval from now.
+	Release(ctx context.Context, id api.RepoID, owner string, interval time.Duration) error
+	// List returns the persisted schedule of all repos, ordered by due time.
+	List(ctx context.Context) ([]scheduleEntry, error)
+	// Get returns the persisted schedule of a repo, its position in the schedule and the
+	// number of scheduled repos.
+	Get(ctx context.Context, id api.RepoID) (entry *scheduleEntry, index, total int, err error)
+}
+
+type dbScheduleStore struct {
+	*basestore.Store
+}
+
+func newScheduleStore(db database.DB) scheduleStore {
+	return &dbScheduleStore{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+// scheduleBatchSize bounds the number of repos scheduled in a single query.
+const scheduleBatchSize = 10000
+
+func (s *dbScheduleStore) Schedule(ctx context.Context, ids []api.RepoID, interval time.Duration, due time.Time, p priority, pullForward bool) ([]scheduleEntry, error) {
+	var entries []scheduleEntry
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > scheduleBatchSize {
+			batch = batch[:scheduleBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		batchEntries, err := scanScheduleEntries(s.Query(ctx, sqlf.Sprintf(scheduleReposQuery,
+			int64(interval/time.Second),
+			due,
+			int(p),
+			pq.Array(batch),
+			pullForward,
+			due,
+			due,
+			int(p),
+		)))
+		if err != nil {
+			return nil, errors.Wrap(err, "scheduling repos")
+		}
+		entries = append(entries, batchEntries...)
+	}
+	return entries, nil
+}
+
+const scheduleReposQuery = `
+WITH scheduled AS (
+	INSERT INTO repo_update_schedule (repo_id, interval_seconds, due_at, priority)
+	SELECT repo.id, %s, %s, %s
+	FROM repo
+	WHERE repo.id = ANY(%s) AND repo.deleted_at IS NULL
+	ON CONFLICT (repo_id) DO UPDATE SET
+		due_at = CASE WHEN %s AND %s < repo_update_schedule.due_at THEN %s ELSE repo_update_schedule.due_at END,
+		priority = GREATEST(repo_update_schedule.priority, %s)
+	RETURNING repo_id, interval_seconds, due_at, priority, lease_owner, lease_expires_at
+)
+SELECT s.repo_id, repo.name, s.interval_seconds, s.due_at, s.priority, s.lease_owner, s.lease_expires_at
+FROM scheduled s
+JOIN repo ON repo.id = s.repo_id
+`
+
+func (s *dbScheduleStore) Remove(ctx context.Context, ids []api.RepoID) error {
+	return s.Exec(ctx, sqlf.Sprintf(`DELETE FROM repo_update_schedule WHERE repo_id = ANY(%s)`, pq.Array(ids)))
+}
+
+// leaseSlack is how early a repo can be leased before it is due. It absorbs the difference
+// between the clocks of repo-updater instances and of the database.
+const leaseSlack = 5 * time.Second
+
+func (s *dbScheduleStore) Lease(ctx context.Context, id api.RepoID, owner string, duration time.Duration) (scheduleEntry, bool, error) {
+	entries, err := scanScheduleEntries(s.Query(ctx, sqlf.Sprintf(leaseRepoQuery,
+		owner,
+		int64(duration/time.Second),
+		id,
+		int64(leaseSlack/time.Second),
+	)))
+	if err != nil {
+		return scheduleEntry{}, false, errors.Wrap(err, "leasing repo")
+	}
+	if len(entries) == 1 {
+		return entries[0], true, nil
+	}
+
+	entry, _, _, err := s.Get(ctx, id)
+	if err != nil || entry == nil {
+		return scheduleEntry{}, false, err
+	}
+	return *entry, false, nil
+}
+
+const leaseRepoQuery = `
+WITH leased AS (
+	UPDATE repo_update_schedule SET
+		lease_owner = %s,
+		lease_expires_at = NOW() + %s * '1 second'::interval,
+		due_at = NOW() + interval_seconds * '1 second'::interval,
+		priority = 0
+	WHERE
+		repo_id = %s AND
+		due_at <= NOW() + %s * '1 second'::interval AND
+		(lease_owner IS NULL OR lease_expires_at < NOW())
+	RETURNING repo_id, interval_seconds, due_at, priority, lease_owner, lease_expires_at
+)
+SELECT s.repo_id, repo.name, s.interval_seconds, s.due_at, s.priority, s.lease_owner, s.lease_expires_at
+FROM leased s
+JOIN repo ON repo.id = s.repo_id
+`
+
+func (s *dbScheduleStore) Release(ctx context.Context, id api.RepoID, owner string, interval time.Duration) error {
+	return s.Exec(ctx, sqlf.Sprintf(releaseRepoQuery,
+		interval > 0,
+		int64(interval/time.Second),
+		interval > 0,
+		int64(interval/time.Second),
+		id,
+		owner,
+	))
+}
+
+const releaseRepoQuery = `
+UPDATE repo_update_schedule SET
+	interval_seconds = CASE WHEN %s THEN %s ELSE interval_seconds END,
+	due_at = CASE WHEN %s THEN NOW() + %s * '1 second'::interval ELSE due_at END,
+	lease_owner = NULL,
+	lease_expires_at = NULL
+WHERE repo_id = %s AND lease_owner = %s
+`
+
+func (s *dbScheduleStore) List(ctx context.Context) ([]scheduleEntry, error) {
+	return scanScheduleEntries(s.Query(ctx, sqlf.Sprintf(listScheduleQuery)))
+}
+
+const listScheduleQuery = `
+SELECT s.repo_id, repo.name, s.interval_seconds, s.due_at, s.priority, s.lease_owner, s.lease_expires_at
+FROM repo_update_schedule s
+JOIN repo ON repo.id = s.repo_id
+WHERE repo.deleted_at IS NULL
+ORDER BY s.due_at, s.repo_id
+`
+
+func (s *dbScheduleStore) Get(ctx context.Context, id api.RepoID) (*scheduleEntry, int, int, error) {
+	var (
+		entry        scheduleEntry
+		index, total int
+	)
+	row := s.QueryRow(ctx, sqlf.Sprintf(getScheduleQuery, id))
+	err := scanScheduleEntry(row, &entry, &index, &total)
+	if err == sql.ErrNoRows {
+		return nil, 0, 0, nil
+	}
+	if err != nil {
+		return nil, 0, 0, errors.Wrap(err, "getting repo schedule")
+	}
+	return &entry, index, total, nil
+}
+
+const getScheduleQuery = `
+SELECT
+	s.repo_id, repo.name, s.interval_seconds, s.due_at, s.priority, s.lease_owner, s.lease_expires_at,
+	(SELECT COUNT(*) FROM repo_update_schedule o WHERE (o.due_at, o.repo_id) < (s.due_at, s.repo_id)),
+	(SELECT COUNT(*) FROM repo_update_schedule)
+FROM repo_update_schedule s
+JOIN repo ON repo.id = s.repo_id
+WHERE s.repo_id = %s
+`
+
+var scanScheduleEntries = basestore.NewSliceScanner(func(sc dbutil.Scanner) (entry scheduleEntry, err error) {
+	return entry, scanScheduleEntry(sc, &entry)
+})
+
+func scanScheduleEntry(sc dbutil.Scanner, entry *scheduleEntry, extra ...any) error {
+	var (
+		intervalSeconds int64
+		p               int
+		leaseOwner      sql.NullString
+		leaseExpiresAt  sql.NullTime
+	)
+	dest := append([]any{
+		&entry.Repo.ID,
+		&entry.Repo.Name,
+		&intervalSeconds,
+		&entry.Due,
+		&p,
+		&leaseOwner,
+		&leaseExpiresAt,
+	}, extra...)
+	if err := sc.Scan(dest...); err != nil {
+		return err
+	}
+	entry.Interval = time.Duration(intervalSeconds) * time.Second
+	entry.Priority = priority(p)
+	entry.LeaseOwner = leaseOwner.String
+	entry.LeaseExpiresAt = leaseExpiresAt.Time
+	return nil
+}
@@ -0,0 +1,113 @@
+package repos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestScheduleStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	a := &types.Repo{Name: "a"}
+	b := &types.Repo{Name: "b"}
+	if err := db.Repos().Create(ctx, a, b); err != nil {
+		t.Fatal(err)
+	}
+	ids := []api.RepoID{a.ID, b.ID}
+
+	store := newScheduleStore(db)
+	now := time.Now()
+
+	entries, err := store.Schedule(ctx, ids, time.Hour, now.Add(-time.Minute), priorityLow, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 scheduled repos, got %d", len(entries))
+	}
+
+	// Scheduling again keeps the persisted schedule, unless pulled forward.
+	entries, err = store.Schedule(ctx, []api.RepoID{b.ID}, minDelay, now.Add(time.Hour), priorityLow, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries[0].Interval != time.Hour || !entries[0].Due.Before(now) {
+		t.Fatalf("expected the schedule of b to be kept, got %+v", entries[0])
+	}
+	entries, err = store.Schedule(ctx, []api.RepoID{b.ID}, minDelay, now.Add(-time.Hour), priorityHigh, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries[0].Priority != priorityHigh || !entries[0].Due.Before(now.Add(-time.Minute)) {
+		t.Fatalf("expected b to be pulled forward, got %+v", entries[0])
+	}
+
+	entry, index, total, err := store.Get(ctx, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil || entry.Repo.Name != b.Name || index != 0 || total != 2 {
+		t.Fatalf("unexpected schedule of b: %+v at %d of %d", entry, index, total)
+	}
+
+	// Only one instance can lease a due repo.
+	entry1, leased, err := store.Lease(ctx, a.ID, "instance-1", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !leased || entry1.LeaseOwner != "instance-1" || !entry1.Due.After(now) {
+		t.Fatalf("expected instance-1 to lease a, got %+v", entry1)
+	}
+	entry2, leased, err := store.Lease(ctx, a.ID, "instance-2", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leased || !entry2.leased(time.Now()) {
+		t.Fatalf("expected instance-2 not to lease a, got %+v", entry2)
+	}
+
+	// Releasing the lease of another instance does nothing.
+	if err := store.Release(ctx, a.ID, "instance-2", 2*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Release(ctx, a.ID, "instance-1", 2*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	entry, _, _, err = store.Get(ctx, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.leased(time.Now()) || entry.Interval != 2*time.Hour {
+		t.Fatalf("expected a to be released and rescheduled, got %+v", entry)
+	}
+
+	// a is no longer due.
+	if _, leased, err := store.Lease(ctx, a.ID, "instance-2", time.Minute); err != nil || leased {
+		t.Fatalf("expected instance-2 not to lease a, got %v, %v", leased, err)
+	}
+
+	if err := store.Remove(ctx, []api.RepoID{b.ID}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Repo.ID != a.ID {
+		t.Fatalf("expected only a to be scheduled, got %+v", entries)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"container/heap"
 	"context"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -650,10 +651,11 @@ func Test_updateScheduler_UpdateFromDiff(t *testing.T) {
 			defer stop()
 
 			s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+			s.store = &fakeScheduleStore{}
 			setupInitialSchedule(s, test.initialSchedule)
 			setupInitialQueue(s, test.initialQueue)
 
-			s.UpdateFromDiff(test.diff)
+			s.UpdateFromDiff(context.Background(), test.diff)
 
 			verifySchedule(t, s, test.finalSchedule)
 			verifyQueue(t, s, test.finalQueue)
@@ -661,6 +663,177 @@ func Test_updateScheduler_UpdateFromDiff(t *testing.T) {
 	}
 }
 
+func TestUpdateScheduler_UpdateFromDiff_persisted(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+	s.store = &fakeScheduleStore{
+		persisted: map[api.RepoID]scheduleEntry{
+			// b was scheduled by another instance, or before a restart.
+			b.ID: {Repo: b, Interval: 2 * time.Hour, Due: defaultTime.Add(time.Hour)},
+		},
+	}
+	setupInitialSchedule(s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+	})
+
+	s.UpdateFromDiff(context.Background(), Diff{
+		Unmodified: []*types.Repo{
+			{ID: a.ID, Name: a.Name},
+			{ID: b.ID, Name: b.Name},
+		},
+	})
+
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+		{Repo: b, Interval: 2 * time.Hour, Due: defaultTime.Add(time.Hour)},
+	})
+}
+
+// Tests that repos are due in the persisted schedule before the update loop can dequeue
+// them, otherwise their update would be skipped.
+func TestUpdateScheduler_persistBeforeEnqueue(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+	ctx := context.Background()
+
+	// leaseQueued emulates the update loop, and records the repos it leased or failed to
+	// lease.
+	var leased, refused []api.RepoName
+	leaseQueued := func() {
+		for {
+			repo, ok := s.updateQueue.acquireNext()
+			if !ok {
+				return
+			}
+			if s.lease(ctx, repo) {
+				leased = append(leased, repo.Name)
+			} else {
+				refused = append(refused, repo.Name)
+			}
+			s.updateQueue.remove(repo, true)
+		}
+	}
+	s.store = &fakeScheduleStore{beforeSchedule: leaseQueued}
+
+	s.UpdateFromDiff(ctx, Diff{Added: []*types.Repo{{ID: a.ID, Name: a.Name}}})
+	s.UpdateOnce(ctx, b.ID, b.Name)
+	leaseQueued()
+
+	if len(refused) > 0 {
+		t.Fatalf("repos dequeued before being scheduled: %v", refused)
+	}
+	if diff := cmp.Diff([]api.RepoName{a.Name, b.Name}, leased); diff != "" {
+		t.Fatalf("unexpected leased repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateScheduler_lease(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+	s.store = &fakeScheduleStore{
+		persisted: map[api.RepoID]scheduleEntry{
+			a.ID: {Repo: a, Interval: minDelay, Due: defaultTime},
+			b.ID: {Repo: b, Interval: time.Hour, Due: defaultTime.Add(time.Hour), LeaseOwner: "other"},
+		},
+		leasedByOthers: map[api.RepoID]bool{b.ID: true},
+	}
+	setupInitialSchedule(s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: minDelay, Due: defaultTime},
+		{Repo: b, Interval: minDelay, Due: defaultTime},
+	})
+
+	if !s.lease(context.Background(), a) {
+		t.Fatal("expected to lease a")
+	}
+	if s.lease(context.Background(), b) {
+		t.Fatal("expected not to lease b, which is leased by another instance")
+	}
+
+	// b is rescheduled as another instance updated it.
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: minDelay, Due: defaultTime},
+		{Repo: b, Interval: time.Hour, Due: defaultTime.Add(time.Hour)},
+	})
+}
+
+// fakeScheduleStore is a scheduleStore that lets this instance lease any repo in the
+// persisted schedule, unless leased by another instance.
+type fakeScheduleStore struct {
+	// persisted is the persisted schedule of repos before the test.
+	persisted map[api.RepoID]scheduleEntry
+	// leasedByOthers are the repos leased by other instances.
+	leasedByOthers map[api.RepoID]bool
+	// beforeSchedule is called before repos are scheduled, to emulate the update loop
+	// leasing the repos of the update queue concurrently.
+	beforeSchedule func()
+
+	mu sync.Mutex
+	// scheduled are the repos scheduled during the test.
+	scheduled map[api.RepoID]bool
+}
+
+func (f *fakeScheduleStore) Schedule(_ context.Context, ids []api.RepoID, _ time.Duration, _ time.Time, _ priority, _ bool) ([]scheduleEntry, error) {
+	if f.beforeSchedule != nil {
+		f.beforeSchedule()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.scheduled == nil {
+		f.scheduled = map[api.RepoID]bool{}
+	}
+	var entries []scheduleEntry
+	for _, id := range ids {
+		f.scheduled[id] = true
+		if entry, ok := f.persisted[id]; ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (f *fakeScheduleStore) Remove(context.Context, []api.RepoID) error {
+	return nil
+}
+
+func (f *fakeScheduleStore) Lease(_ context.Context, id api.RepoID, _ string, _ time.Duration) (scheduleEntry, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.persisted[id]
+	if !ok && !f.scheduled[id] {
+		// Repos that are not in the persisted schedule can't be leased.
+		return scheduleEntry{}, false, nil
+	}
+	return entry, !f.leasedByOthers[id], nil
+}
+
+func (f *fakeScheduleStore) Release(context.Context, api.RepoID, string, time.Duration) error {
+	return nil
+}
+
+func (f *fakeScheduleStore) List(context.Context) ([]scheduleEntry, error) {
+	return nil, nil
+}
+
+func (f *fakeScheduleStore) Get(context.Context, api.RepoID) (*scheduleEntry, int, int, error) {
+	return nil, 0, 0, nil
+}
+
 func TestSchedule_upsert(t *testing.T) {
 	a := configuredRepo{ID: 1, Name: "a"}
 	a2 := configuredRepo{ID: 1, Name: "a2"}
@@ -1439,6 +1612,11 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 			defer func() { requestRepoUpdate = nil }()
 
 			s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+			store := &fakeScheduleStore{scheduled: map[api.RepoID]bool{}}
+			for _, u := range test.initialQueue {
+				store.scheduled[u.Repo.ID] = true
+			}
+			s.store = store
 			s.schedule.randGenerator = &mockRandomGenerator{}
 
 			// unbuffer the channel
@@ -61,6 +61,15 @@ const (
 	// query. May be a number or string representing something approximate,
 	// like "500+".
 	ResultCount AnnotationName = "ResultCount"
+
+	// SmartSearchRules communicates the comma-separated names of the Smart
+	// Search rules that generated a query.
+	SmartSearchRules AnnotationName = "SmartSearchRules"
+
+	// MatchCount communicates the number of matches streamed for a query
+	// generated by Smart Search. Generated queries run one after the other,
+	// so clients use it to tell which query generated a streamed match.
+	MatchCount AnnotationName = "MatchCount"
 )
 
 func (q *QueryDescription) QueryString() string {
@@ -0,0 +1,212 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// NewFileHasOwnersJob creates a filter job to post-filter results for the
+// file:has.owner() predicate.
+//
+// Only file matches are retained, for which the CODEOWNERS file of the
+// repository, evaluated at the commit of the match, names all the included
+// owners and none of the excluded owners as owners of the matched path.
+// Owners are compared by handle or e-mail, case-insensitively.
+func NewFileHasOwnersJob(child job.Job, includeOwners, excludeOwners []string) job.Job {
+	return &fileHasOwnersJob{
+		child:         child,
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+	}
+}
+
+type fileHasOwnersJob struct {
+	child job.Job
+
+	// Owners as given in file:has.owner() predicates.
+	includeOwners []string
+	excludeOwners []string
+}
+
+func (j *fileHasOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	codeowners := newCodeownersCache(backend.NewOwnService(clients.Gitserver, clients.DB))
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = j.filterMatches(ctx, codeowners, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *fileHasOwnersJob) filterMatches(ctx context.Context, codeowners *codeownersCache, matches []result.Match) ([]result.Match, error) {
+	var errs error
+	filtered := matches[:0]
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			// Only file matches can be associated with owners.
+			continue
+		}
+		owners, err := codeowners.owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		if containsAllOwners(owners, j.includeOwners) && !containsAnyOwner(owners, j.excludeOwners) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered, errs
+}
+
+func (j *fileHasOwnersJob) Name() string {
+	return "FileHasOwnersFilterJob"
+}
+
+func (j *fileHasOwnersJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeOwners", j.includeOwners),
+			trace.Strings("excludeOwners", j.excludeOwners),
+		)
+	}
+	return res
+}
+
+func (j *fileHasOwnersJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// containsAllOwners returns true if every one of the searched owners
+// is among the given CODEOWNERS owners.
+func containsAllOwners(owners []*codeownerspb.Owner, searched []string) bool {
+	for _, s := range searched {
+		if !containsAnyOwner(owners, []string{s}) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAnyOwner returns true if at least one of the searched owners
+// is among the given CODEOWNERS owners.
+func containsAnyOwner(owners []*codeownerspb.Owner, searched []string) bool {
+	for _, s := range searched {
+		want := ownerSearchTerm(s)
+		for _, o := range owners {
+			if ownerIdentifier(o) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ownerSearchTerm normalizes an owner given in a search query, so that it
+// compares equal to the identifier of a matching owner. Terms that
+// are neither a `@handle` nor an e-mail address are considered handles.
+func ownerSearchTerm(term string) string {
+	term = strings.ToLower(term)
+	if !strings.Contains(term, "@") {
+		return "@" + term
+	}
+	return term
+}
+
+// ownerIdentifier returns the case-insensitive identifier of a CODEOWNERS owner.
+func ownerIdentifier(o *codeownerspb.Owner) string {
+	return (&result.OwnerMatch{Handle: o.GetHandle(), Email: o.GetEmail()}).Identifier()
+}
+
+// codeownersCache loads the CODEOWNERS file for every repository commit
+// at most once, as many matches are typically found at the same commit.
+// Files of different repository commits are loaded concurrently.
+type codeownersCache struct {
+	ownService backend.OwnService
+	group      singleflight.Group
+
+	mu    sync.Mutex
+	files map[codeownersKey]*codeownerspb.File
+}
+
+type codeownersKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+func newCodeownersCache(ownService backend.OwnService) *codeownersCache {
+	return &codeownersCache{
+		ownService: ownService,
+		files:      map[codeownersKey]*codeownerspb.File{},
+	}
+}
+
+// owners returns the owners of given path as per the CODEOWNERS file found
+// in the repository at the given commit. If there is no CODEOWNERS file,
+// or no rule matches the path, no owners are returned.
+func (c *codeownersCache) owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]*codeownerspb.Owner, error) {
+	key := codeownersKey{repo: repo, commit: commit}
+	c.mu.Lock()
+	file, ok := c.files[key]
+	c.mu.Unlock()
+	if ok {
+		return file.FindOwners(path), nil
+	}
+
+	v, err, _ := c.group.Do(string(repo)+"@"+string(commit), func() (any, error) {
+		file, err := c.ownService.OwnersFile(ctx, repo, commit)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.files[key] = file
+		c.mu.Unlock()
+		return file, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*codeownerspb.File).FindOwners(path), nil
+}
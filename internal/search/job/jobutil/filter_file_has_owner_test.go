@@ -0,0 +1,220 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+const testCodeowners = `*.go @gophers
+/docs/ docs@example.com @Writers
+/docs/internal/ @alice
+`
+
+func codeownersGitserver() *gitserver.MockClient {
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, _ api.CommitID, path string) ([]byte, error) {
+		if repo == "owned" && path == "CODEOWNERS" {
+			return []byte(testCodeowners), nil
+		}
+		return nil, errors.New("file does not exist")
+	})
+	return git
+}
+
+func ownedFileMatch(repo api.RepoName, path string) *result.FileMatch {
+	return &result.FileMatch{
+		File: result.File{
+			Repo:     types.MinimalRepo{Name: repo},
+			CommitID: "deadbeef",
+			Path:     path,
+		},
+	}
+}
+
+func TestFileHasOwnersJob(t *testing.T) {
+	matches := []result.Match{
+		ownedFileMatch("owned", "main.go"),
+		ownedFileMatch("owned", "docs/index.md"),
+		ownedFileMatch("owned", "docs/internal/index.md"),
+		ownedFileMatch("owned", "README.md"),
+		ownedFileMatch("unowned", "main.go"),
+		&result.RepoMatch{Name: "owned"},
+	}
+	paths := func(ms []result.Match) (res []string) {
+		for _, m := range ms {
+			fm := m.(*result.FileMatch)
+			res = append(res, string(fm.Repo.Name)+"/"+fm.Path)
+		}
+		return res
+	}
+	cases := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{{
+		name:    "handle",
+		include: []string{"@gophers"},
+		want:    []string{"owned/main.go"},
+	}, {
+		name:    "handle is case insensitive",
+		include: []string{"@writers"},
+		want:    []string{"owned/docs/index.md"},
+	}, {
+		name:    "email",
+		include: []string{"docs@example.com"},
+		want:    []string{"owned/docs/index.md"},
+	}, {
+		name:    "handle without at sign",
+		include: []string{"alice"},
+		want:    []string{"owned/docs/internal/index.md"},
+	}, {
+		name:    "all included owners must match",
+		include: []string{"@writers", "@gophers"},
+	}, {
+		name:    "excluded owner",
+		exclude: []string{"@gophers"},
+		want:    []string{"owned/docs/index.md", "owned/docs/internal/index.md", "owned/README.md", "unowned/main.go"},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: append([]result.Match(nil), matches...)})
+				return nil, nil
+			})
+			var got []result.Match
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				got = append(got, ev.Results...)
+			})
+			clients := job.RuntimeClients{Gitserver: codeownersGitserver(), DB: database.NewMockDB()}
+			j := NewFileHasOwnersJob(childJob, tc.include, tc.exclude)
+			alert, err := j.Run(context.Background(), clients, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, paths(got))
+		})
+	}
+}
+
+func TestSelectFileOwnersJob(t *testing.T) {
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: []result.Match{
+			ownedFileMatch("owned", "main.go"),
+			ownedFileMatch("owned", "cmd/main.go"),
+			ownedFileMatch("owned", "docs/index.md"),
+		}})
+		s.Send(streaming.SearchEvent{Results: []result.Match{
+			ownedFileMatch("owned", "docs/internal/index.md"),
+			ownedFileMatch("owned", "docs/README.md"),
+			ownedFileMatch("unowned", "main.go"),
+			&result.RepoMatch{Name: "owned"},
+		}})
+		return nil, nil
+	})
+	var got []string
+	streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		for _, m := range ev.Results {
+			o := m.(*result.OwnerMatch)
+			owner := o.Identifier()
+			if o.User != nil {
+				owner += " user:" + o.User.Username
+			}
+			if o.Org != nil {
+				owner += " org:" + o.Org.Name
+			}
+			got = append(got, owner)
+		}
+	})
+	clients := job.RuntimeClients{Gitserver: codeownersGitserver(), DB: ownersDB()}
+	alert, err := NewSelectFileOwnersJob(childJob).Run(context.Background(), clients, streamCollector)
+	require.Nil(t, alert)
+	require.NoError(t, err)
+	require.Equal(t, []string{"@gophers org:gophers", "docs@example.com", "@writers", "@alice user:alice"}, got)
+}
+
+// ownersDB returns a database where the user alice and the organization gophers exist.
+func ownersDB() database.DB {
+	alice := &types.User{ID: 1, Username: "alice"}
+	users := database.NewMockUserStore()
+	users.GetByUsernamesFunc.SetDefaultHook(func(_ context.Context, usernames ...string) ([]*types.User, error) {
+		for _, u := range usernames {
+			if u == alice.Username {
+				return []*types.User{alice}, nil
+			}
+		}
+		return nil, nil
+	})
+	users.ListFunc.SetDefaultReturn([]*types.User{alice}, nil)
+	orgs := database.NewMockOrgStore()
+	orgs.GetByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Org, error) {
+		if name == "gophers" {
+			return &types.Org{ID: 1, Name: name}, nil
+		}
+		return nil, &database.OrgNotFoundError{Message: name}
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	return db
+}
+
+// blockingOwnService loads the CODEOWNERS file of repository "a" only once
+// the file of repository "b" is being loaded.
+type blockingOwnService struct {
+	backend.OwnService
+	bStarted chan struct{}
+}
+
+func (s blockingOwnService) OwnersFile(ctx context.Context, repo api.RepoName, _ api.CommitID) (*codeownerspb.File, error) {
+	switch repo {
+	case "a":
+		select {
+		case <-s.bStarted:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	case "b":
+		close(s.bStarted)
+	}
+	return &codeownerspb.File{}, nil
+}
+
+func TestCodeownersCacheLoadsConcurrently(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c := newCodeownersCache(blockingOwnService{bStarted: make(chan struct{})})
+
+	errs := make(chan error, 2)
+	for _, repo := range []api.RepoName{"a", "b"} {
+		repo := repo
+		go func() {
+			_, err := c.owners(ctx, repo, "deadbeef", "main.go")
+			errs <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, <-errs)
+	}
+}
@@ -184,10 +184,24 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.owner() post-filter
+		includeOwners, excludeOwners := b.FileHasOwner()
+		if len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = NewFileHasOwnersJob(basicJob, includeOwners, excludeOwners)
+		}
+	}
+
+	var selectFileOwners bool
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if isSelectFileOwners(sp) {
+				// Owners are selected after subrepo permissions are applied
+				// below, so that only owners of visible files are returned.
+				selectFileOwners = true
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
@@ -198,6 +212,10 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	if selectFileOwners {
+		basicJob = NewSelectFileOwnersJob(basicJob)
+	}
+
 	{ // Apply search result sanitization post-filter if enabled
 		if len(inputs.SanitizeSearchPatterns) > 0 {
 			basicJob = NewSanitizeJob(inputs.SanitizeSearchPatterns, basicJob)
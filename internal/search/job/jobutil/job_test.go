@@ -190,7 +190,7 @@ func TestNewPlanJob(t *testing.T) {
           (SEQUENTIAL
             (ensureUnique . false)
             (ZOEKTGLOBALTEXTSEARCH
-              (query . regex:"ok(?-s:.)*?ok")
+              (query . regex:"(?-s:ok.*?ok)")
               (type . text)
               )
             (REPOSEARCH
@@ -306,7 +306,7 @@ func TestNewPlanJob(t *testing.T) {
         (limit . 500)
         (PARALLEL
           (ZOEKTGLOBALTEXTSEARCH
-            (query . regex:"foo(?-s:.)*?@bar")
+            (query . regex:"(?-s:foo.*?@bar)")
             (type . text)
             )
           (REPOSCOMPUTEEXCLUDED
@@ -680,6 +680,41 @@ func TestNewPlanJob(t *testing.T) {
           (REPOSEARCH
             (repoOpts.hasFileContent[0].path . a)(repoOpts.hasFileContent[0].content . b)
             (repoNamePatterns . [])))))))`),
+		}, {
+			query:      `repo:sourcegraph file:has.owner(@alice) -file:has.owner(bob@example.com) select:file.owners foo`,
+			protocol:   search.Streaming,
+			searchType: query.SearchTypeLiteral,
+			want: autogold.Want("file has owner and select file owners", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . literal)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (SELECTFILEOWNERS
+          (FILEHASOWNERSFILTER
+            (includeOwners.0 . @alice)
+            (excludeOwners.0 . bob@example.com)
+            (PARALLEL
+              (SEQUENTIAL
+                (ensureUnique . false)
+                (REPOPAGER
+                  (repoOpts.repoFilters . [sourcegraph])
+                  (PARTIALREPOS
+                    (ZOEKTREPOSUBSETTEXTSEARCH
+                      (query . substr:"foo")
+                      (type . text))))
+                (REPOPAGER
+                  (repoOpts.repoFilters . [sourcegraph])
+                  (PARTIALREPOS
+                    (SEARCHERTEXTSEARCH
+                      (indexed . false)))))
+              (REPOSCOMPUTEEXCLUDED
+                (repoOpts.repoFilters . [sourcegraph]))
+              NoopJob)))))))`),
 		}, {
 			query:      `repo:has(key:value)`,
 			protocol:   search.Streaming,
@@ -69,6 +69,9 @@ func (j *sanitizeJob) sanitizeEvent(event streaming.SearchEvent) streaming.Searc
 			}
 		case *result.RepoMatch:
 			sanitized = append(sanitized, v)
+		case *result.OwnerMatch:
+			// Owner matches do not contain any content to sanitize.
+			sanitized = append(sanitized, v)
 		default:
 			// default to dropping this result
 		}
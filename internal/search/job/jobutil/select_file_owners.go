@@ -0,0 +1,146 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// isSelectFileOwners returns true for the `select:file.owners` select path.
+func isSelectFileOwners(path filter.SelectPath) bool {
+	return path.Root() == filter.File && len(path) > 1 && path[1] == "owners"
+}
+
+// NewSelectFileOwnersJob creates a job that transforms streamed file matches
+// into the owners of the matched files, as per the CODEOWNERS file in each
+// repository at the matched commit. Every owner is streamed only once, no
+// matter how many matched files it owns, and resolved to a Sourcegraph user
+// or organization if possible. All other matches are dropped.
+func NewSelectFileOwnersJob(child job.Job) job.Job {
+	return &selectFileOwnersJob{child: child}
+}
+
+type selectFileOwnersJob struct {
+	child job.Job
+}
+
+func (j *selectFileOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu    sync.Mutex
+		errs  error
+		dedup = result.NewDeduper()
+	)
+
+	ownService := backend.NewOwnService(clients.Gitserver, clients.DB)
+	codeowners := newCodeownersCache(ownService)
+	selectingStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var selected []*result.OwnerMatch
+		for _, m := range event.Results {
+			fm, ok := m.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			owners, err := codeowners.owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+			if err != nil {
+				mu.Lock()
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				continue
+			}
+			for _, o := range owners {
+				selected = append(selected, &result.OwnerMatch{
+					Handle:   o.GetHandle(),
+					Email:    o.GetEmail(),
+					Repo:     fm.Repo,
+					CommitID: fm.CommitID,
+				})
+			}
+		}
+
+		mu.Lock()
+		deduped := selected[:0]
+		for _, m := range selected {
+			if dedup.Seen(m) {
+				continue
+			}
+			dedup.Add(m)
+			deduped = append(deduped, m)
+		}
+		mu.Unlock()
+
+		if err := resolveOwnerMatches(ctx, ownService, deduped); err != nil {
+			// Owners are still streamed, only without a user or organization.
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+
+		event.Results = make([]result.Match, 0, len(deduped))
+		for _, m := range deduped {
+			event.Results = append(event.Results, m)
+		}
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, selectingStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// resolveOwnerMatches sets the user or organization of the given owner matches
+// which resolve unambiguously to one.
+func resolveOwnerMatches(ctx context.Context, ownService backend.OwnService, matches []*result.OwnerMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	owners := make([]*codeownerspb.Owner, 0, len(matches))
+	for _, m := range matches {
+		owners = append(owners, &codeownerspb.Owner{Handle: m.Handle, Email: m.Email})
+	}
+	resolved, err := ownService.Resolve(ctx, owners)
+	if err != nil {
+		return err
+	}
+	for i, r := range resolved {
+		if r.Resolution != backend.OwnerResolved {
+			continue
+		}
+		if len(r.Users) == 1 {
+			matches[i].User = r.Users[0]
+		}
+		matches[i].Org = r.Org
+	}
+	return nil
+}
+
+func (j *selectFileOwnersJob) Name() string {
+	return "SelectFileOwnersJob"
+}
+
+func (j *selectFileOwnersJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (j *selectFileOwnersJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *selectFileOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
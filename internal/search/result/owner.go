@@ -0,0 +1,69 @@
+package result
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of matched files as given by a CODEOWNERS file,
+// which is what `select:file.owners` yields. Owner matches do not belong
+// to a single repository: The same owner found in multiple repositories
+// is the same match.
+type OwnerMatch struct {
+	// Handle is the handle of the owner without the leading `@`,
+	// if the owner is denoted by a handle.
+	Handle string
+	// Email is the e-mail of the owner, if the owner is denoted by one.
+	Email string
+
+	// User or Org is the Sourcegraph user or organization that the owner
+	// resolves to unambiguously, if any.
+	User *types.User
+	Org  *types.Org
+
+	// Repo and CommitID identify where the owner was found first.
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == "owners" {
+		return o
+	}
+	return nil
+}
+
+// Identifier returns the text of the owner as it appears in a CODEOWNERS
+// file, that is a handle prefixed with `@` or an e-mail. Handles and
+// e-mails are case-insensitive, so the identifier is lowercase.
+func (o *OwnerMatch) Identifier() string {
+	if o.Handle != "" {
+		return "@" + strings.ToLower(o.Handle)
+	}
+	return strings.ToLower(o.Email)
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Owner:    o.Identifier(),
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -0,0 +1,168 @@
+package smartsearch
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	// Validation only: Do NOT set any values in the configuration in this function.
+	conf.ContributeValidator(func(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+		for _, p := range validateConfig(c.SiteConfig().SearchSmartSearch) {
+			problems = append(problems, conf.NewSiteProblem(p))
+		}
+		return problems
+	})
+
+	go conf.Watch(func() {
+		narrow, widen := rulesFromConfig(conf.Get().SearchSmartSearch)
+		currentRules.Store(newRuleSets(narrow, widen))
+	})
+}
+
+// ruleSets are the rules of a site configuration, with their rewrite rule
+// patterns compiled.
+type ruleSets struct {
+	narrow, widen []rule
+	names         map[string]struct{}
+}
+
+func newRuleSets(narrow, widen []rule) *ruleSets {
+	names := make(map[string]struct{}, len(narrow)+len(widen))
+	for _, rules := range [][]rule{narrow, widen} {
+		for _, r := range rules {
+			names[r.name] = struct{}{}
+		}
+	}
+	return &ruleSets{narrow: narrow, widen: widen, names: names}
+}
+
+// currentRules are the rules of the current site configuration, built once
+// per configuration change.
+var currentRules atomic.Pointer[ruleSets]
+
+// configuredRules returns the narrowing and widening rule sets of the current
+// site configuration.
+func configuredRules() (narrow, widen []rule) {
+	rs := loadRuleSets()
+	return rs.narrow, rs.widen
+}
+
+func loadRuleSets() *ruleSets {
+	if rs := currentRules.Load(); rs != nil {
+		return rs
+	}
+	// The configuration has not been watched yet.
+	return newRuleSets(rulesFromConfig(conf.Get().SearchSmartSearch))
+}
+
+// validateConfig returns the problems of the `search.smartSearch` site
+// configuration c.
+func validateConfig(c *schema.SearchSmartSearch) (problems []string) {
+	if c == nil {
+		return nil
+	}
+
+	names := make(map[string]struct{})
+	for _, rules := range [][]rule{rulesNarrow, rulesWiden} {
+		for _, r := range rules {
+			names[r.name] = struct{}{}
+		}
+	}
+	for _, r := range c.RewriteRules {
+		if _, ok := names[r.Name]; ok {
+			problems = append(problems, fmt.Sprintf("search.smartSearch: the name of rewrite rule `%s` is already used by another rule.", r.Name))
+		}
+		names[r.Name] = struct{}{}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("search.smartSearch: rewrite rule `%s` has an invalid pattern: %s", r.Name, err))
+		}
+	}
+	for _, r := range c.Rules {
+		if _, ok := names[r.Name]; !ok {
+			problems = append(problems, fmt.Sprintf("search.smartSearch: unknown rule `%s`.", r.Name))
+		}
+	}
+	return problems
+}
+
+// rulesFromConfig returns the narrowing and widening rule sets of the
+// `search.smartSearch` site configuration c. Rewrite rules are added to the
+// built-in rules, then the rules listed in c come first in the listed order,
+// followed by the other rules in their default order. Disabled rules are
+// removed.
+func rulesFromConfig(c *schema.SearchSmartSearch) (narrow, widen []rule) {
+	if c == nil {
+		return rulesNarrow, rulesWiden
+	}
+
+	narrow = append([]rule{}, rulesNarrow...)
+	widen = append([]rule{}, rulesWiden...)
+	for _, r := range c.RewriteRules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			// Reported by the site configuration validator.
+			continue
+		}
+		description := r.Description
+		if description == "" {
+			description = r.Name
+		}
+		rewrite := rule{
+			name:        r.Name,
+			description: description,
+			transform:   []transform{rewritePatterns(re, r.Replacement)},
+		}
+		if r.Kind == "widen" {
+			widen = append(widen, rewrite)
+		} else {
+			narrow = append(narrow, rewrite)
+		}
+	}
+
+	return orderRules(narrow, c.Rules), orderRules(widen, c.Rules)
+}
+
+// orderRules returns the enabled rules of rules, ordered by config first.
+func orderRules(rules []rule, config []*schema.SmartSearchRule) []rule {
+	byName := make(map[string]rule, len(rules))
+	for _, r := range rules {
+		byName[r.name] = r
+	}
+
+	ordered := make([]rule, 0, len(rules))
+	listed := make(map[string]struct{}, len(config))
+	for _, c := range config {
+		r, ok := byName[c.Name]
+		if !ok {
+			continue
+		}
+		if _, ok := listed[c.Name]; ok {
+			continue
+		}
+		listed[c.Name] = struct{}{}
+		if c.Enabled == nil || *c.Enabled {
+			ordered = append(ordered, r)
+		}
+	}
+	for _, r := range rules {
+		if _, ok := listed[r.name]; !ok {
+			ordered = append(ordered, r)
+		}
+	}
+	return ordered
+}
+
+// IsRuleName returns true if name is the name of a rule in the current site
+// configuration. It is used to bound the rule names reported by clients in
+// telemetry.
+func IsRuleName(name string) bool {
+	_, ok := loadRuleSets().names[name]
+	return ok
+}
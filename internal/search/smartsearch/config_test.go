@@ -0,0 +1,65 @@
+package smartsearch
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRulesFromConfig(t *testing.T) {
+	names := func(rules []rule) []string {
+		var names []string
+		for _, r := range rules {
+			names = append(names, r.name)
+		}
+		return names
+	}
+	disabled := false
+
+	t.Run("defaults", func(t *testing.T) {
+		narrow, widen := rulesFromConfig(nil)
+		autogold.Want("default narrow", []string{
+			"unquote-patterns", "type-patterns", "lang-patterns", "symbol-patterns",
+			"code-host-filters", "rewrite-repo-filter",
+		}).Equal(t, names(narrow))
+		autogold.Want("default widen", []string{"regexp-patterns", "unordered-patterns"}).Equal(t, names(widen))
+	})
+
+	t.Run("order, disable and rewrite", func(t *testing.T) {
+		narrow, widen := rulesFromConfig(&schema.SearchSmartSearch{
+			Rules: []*schema.SmartSearchRule{
+				{Name: "lang-patterns"},
+				{Name: "unquote-patterns", Enabled: &disabled},
+				{Name: "unordered-patterns"},
+				{Name: "k8s"},
+			},
+			RewriteRules: []*schema.SmartSearchRewriteRule{
+				{Name: "k8s", Pattern: `^k8s$`, Replacement: "kubernetes"},
+				{Name: "pkg", Pattern: `^pkg$`, Replacement: "package", Kind: "widen"},
+				{Name: "invalid", Pattern: `(`, Replacement: "x"},
+			},
+		})
+		autogold.Want("configured narrow", []string{
+			"lang-patterns", "k8s", "type-patterns", "symbol-patterns",
+			"code-host-filters", "rewrite-repo-filter",
+		}).Equal(t, names(narrow))
+		autogold.Want("configured widen", []string{"unordered-patterns", "regexp-patterns", "pkg"}).Equal(t, names(widen))
+	})
+}
+
+func TestValidateConfig(t *testing.T) {
+	problems := validateConfig(&schema.SearchSmartSearch{
+		Rules: []*schema.SmartSearchRule{{Name: "lang-patterns"}, {Name: "nope"}, {Name: "k8s"}},
+		RewriteRules: []*schema.SmartSearchRewriteRule{
+			{Name: "k8s", Pattern: `^k8s$`},
+			{Name: "type-patterns", Pattern: `(`},
+		},
+	})
+	autogold.Want("problems", []string{
+		"search.smartSearch: the name of rewrite rule `type-patterns` is already used by another rule.",
+		"search.smartSearch: rewrite rule `type-patterns` has an invalid pattern: error parsing regexp: missing closing ): `(`",
+		"search.smartSearch: unknown rule `nope`.",
+	}).Equal(t, problems)
+}
@@ -81,6 +81,7 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 	n = func(phase PHASE, k int, c *cg, w int) next {
 		var transform []transform
 		var descriptions []string
+		var names []string
 		var generated *query.Basic
 
 		narrowing_exhausted := k == 0
@@ -98,6 +99,7 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 
 			transform = append(transform, widen[w].transform...)
 			descriptions = append(descriptions, widen[w].description)
+			names = append(names, widen[w].name)
 			w += 1 // advance to next widening rule.
 
 		case TWO:
@@ -126,11 +128,13 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 			for _, idx := range c.Combination(nil) {
 				transform = append(transform, narrow[idx].transform...)
 				descriptions = append(descriptions, narrow[idx].description)
+				names = append(names, narrow[idx].name)
 			}
 
 			// Compose narrow rules with a widen rule.
 			transform = append(transform, widen[w].transform...)
 			descriptions = append(descriptions, widen[w].description)
+			names = append(names, widen[w].name)
 
 		case ONE:
 			if narrowing_exhausted && !widening_active {
@@ -153,6 +157,7 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 			for _, idx := range c.Combination(nil) {
 				transform = append(transform, narrow[idx].transform...)
 				descriptions = append(descriptions, narrow[idx].description)
+				names = append(names, narrow[idx].name)
 			}
 		}
 
@@ -164,6 +169,7 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 
 		q := autoQuery{
 			description: strings.Join(descriptions, " ⚬ "),
+			rules:       names,
 			query:       *generated,
 		}
 
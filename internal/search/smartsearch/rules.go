@@ -16,6 +16,8 @@ import (
 // Basic query, or they do not apply, in which case they return nil. See the
 // `unquotePatterns` rule for an example.
 type rule struct {
+	// name identifies the rule in site configuration and telemetry.
+	name        string
 	description string
 	transform   []transform
 }
@@ -24,26 +26,32 @@ type transform func(query.Basic) *query.Basic
 
 var rulesNarrow = []rule{
 	{
+		name:        "unquote-patterns",
 		description: "unquote patterns",
 		transform:   []transform{unquotePatterns},
 	},
 	{
+		name:        "type-patterns",
 		description: "apply search type for pattern",
 		transform:   []transform{typePatterns},
 	},
 	{
+		name:        "lang-patterns",
 		description: "apply language filter for pattern",
 		transform:   []transform{langPatterns},
 	},
 	{
+		name:        "symbol-patterns",
 		description: "apply symbol select for pattern",
 		transform:   []transform{symbolPatterns},
 	},
 	{
+		name:        "code-host-filters",
 		description: "expand URL to filters",
 		transform:   []transform{patternsToCodeHostFilters},
 	},
 	{
+		name:        "rewrite-repo-filter",
 		description: "rewrite repo URLs",
 		transform:   []transform{rewriteRepoFilter},
 	},
@@ -51,10 +59,12 @@ var rulesNarrow = []rule{
 
 var rulesWiden = []rule{
 	{
+		name:        "regexp-patterns",
 		description: "patterns as regular expressions",
 		transform:   []transform{regexpPatterns},
 	},
 	{
+		name:        "unordered-patterns",
 		description: "AND patterns together",
 		transform:   []transform{unorderedPatterns},
 	},
@@ -695,3 +705,44 @@ func patternsToCodeHostFilters(b query.Basic) *query.Basic {
 
 	return &newBasic
 }
+
+// rewritePatterns returns a transform that replaces the matches of re in every
+// pattern of the query with replacement, see the `rewriteRules` of the
+// `search.smartSearch` site configuration. The transform applies if it changes
+// at least one pattern.
+func rewritePatterns(re *regexp.Regexp, replacement string) transform {
+	return func(b query.Basic) *query.Basic {
+		rawParseTree, err := query.Parse(query.StringHuman(b.ToParseTree()), query.SearchTypeStandard)
+		if err != nil {
+			return nil
+		}
+
+		changed := false
+		newParseTree := query.MapPattern(rawParseTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+			if newValue := re.ReplaceAllString(value, replacement); newValue != value && newValue != "" {
+				changed = true
+				value = newValue
+			}
+			return query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			}
+		})
+		if !changed {
+			return nil
+		}
+
+		newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newParseTree)
+		if err != nil {
+			return nil
+		}
+
+		newBasic, err := query.ToBasicQuery(newNodes)
+		if err != nil {
+			return nil
+		}
+
+		return &newBasic
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/grafana/regexp"
 	"github.com/hexops/autogold"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 )
@@ -187,3 +188,22 @@ func Test_rewriteRepoFilter(t *testing.T) {
 		})
 	}
 }
+
+func Test_rewritePatterns(t *testing.T) {
+	rule := []transform{rewritePatterns(regexp.MustCompile(`^k8s$`), "kubernetes")}
+	test := func(input string) string {
+		return apply(input, rule)
+	}
+
+	cases := []string{
+		`k8s deployment`,
+		`repo:k8s -k8s`,
+		`k8sutil`,
+	}
+
+	for _, c := range cases {
+		t.Run("rewrite patterns", func(t *testing.T) {
+			autogold.Equal(t, autogold.Raw(test(c)))
+		})
+	}
+}
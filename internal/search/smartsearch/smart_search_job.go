@@ -3,6 +3,9 @@ package smartsearch
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
 
 	"github.com/opentracing/opentracing-go/log"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
@@ -19,7 +22,10 @@ import (
 // autoQuery is an automatically generated query with associated data (e.g., description).
 type autoQuery struct {
 	description string
-	query       query.Basic
+	// rules are the names of the rules that generated the query, in the order
+	// they were applied.
+	rules []string
+	query query.Basic
 }
 
 // newJob is a function that converts a query to a job, and one which lucky
@@ -34,9 +40,10 @@ type newJob func(query.Basic) (job.Job, error)
 // not, attempt to search the pattern as a regexp, and so on). There is no
 // random choice when applying rules.
 func NewSmartSearchJob(initialJob job.Job, newJob newJob, plan query.Plan) *FeelingLuckySearchJob {
+	narrow, widen := configuredRules()
 	generators := make([]next, 0, len(plan))
 	for _, b := range plan {
-		generators = append(generators, NewGenerator(b, rulesNarrow, rulesWiden))
+		generators = append(generators, NewGenerator(b, narrow, widen))
 	}
 
 	newGeneratedJob := func(autoQ *autoQuery) job.Job {
@@ -166,16 +173,20 @@ func (f *FeelingLuckySearchJob) MapChildren(fn job.MapFunc) job.Job {
 
 // generatedSearchJob represents a generated search at run time. Note
 // `NewNotification` returns the query notifications (encoded as error) given
-// the result count of the job. It is a function so that notifications can be
+// the result and match counts of the job. It is a function so that notifications can be
 // composed at runtime (with result counts) with static inputs (query string),
 // while not exposing static inputs.
 type generatedSearchJob struct {
 	Child           job.Job
-	NewNotification func(count int) error
+	NewNotification func(count, matchCount int) error
 }
 
 func (g *generatedSearchJob) Run(ctx context.Context, clients job.RuntimeClients, parentStream streaming.Sender) (*search.Alert, error) {
-	stream := streaming.NewResultCountingStream(parentStream)
+	var matchCount atomic.Int64
+	stream := streaming.NewResultCountingStream(streaming.StreamFunc(func(event streaming.SearchEvent) {
+		matchCount.Add(int64(len(event.Results)))
+		parentStream.Send(event)
+	}))
 	alert, err := g.Child.Run(ctx, clients, stream)
 	resultCount := stream.Count()
 	if resultCount == 0 {
@@ -183,11 +194,11 @@ func (g *generatedSearchJob) Run(ctx context.Context, clients job.RuntimeClients
 	}
 
 	if ctx.Err() != nil {
-		notification := g.NewNotification(resultCount)
+		notification := g.NewNotification(resultCount, int(matchCount.Load()))
 		return alert, errors.Append(err, notification)
 	}
 
-	notification := g.NewNotification(resultCount)
+	notification := g.NewNotification(resultCount, int(matchCount.Load()))
 	if err != nil {
 		return alert, errors.Append(err, notification)
 	}
@@ -216,7 +227,7 @@ type notifier struct {
 	*autoQuery
 }
 
-func (n *notifier) New(count int) error {
+func (n *notifier) New(count, matchCount int) error {
 	var resultCountString string
 	if count == limits.DefaultMaxSearchResultsStreaming {
 		resultCountString = fmt.Sprintf("%d+ results", count)
@@ -225,15 +236,18 @@ func (n *notifier) New(count int) error {
 	} else {
 		resultCountString = fmt.Sprintf("%d additional results", count)
 	}
-	annotations := make(map[search.AnnotationName]string)
-	annotations[search.ResultCount] = resultCountString
+	annotations := map[search.AnnotationName]string{
+		search.ResultCount: resultCountString,
+		search.MatchCount:  strconv.Itoa(matchCount),
+	}
+	if len(n.rules) > 0 {
+		annotations[search.SmartSearchRules] = strings.Join(n.rules, ",")
+	}
 
 	return &alertobserver.ErrLuckyQueries{
 		ProposedQueries: []*search.QueryDescription{{
 			Description: n.description,
-			Annotations: map[search.AnnotationName]string{
-				search.ResultCount: resultCountString,
-			},
+			Annotations: annotations,
 			Query:       query.StringHuman(n.query.ToParseTree()),
 			PatternType: query.SearchTypeLucky,
 		}},
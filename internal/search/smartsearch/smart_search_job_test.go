@@ -84,12 +84,13 @@ func TestGeneratedSearchJob(t *testing.T) {
 		if err == nil {
 			return ""
 		}
-		return err.(*alertobserver.ErrLuckyQueries).ProposedQueries[0].Annotations[search.ResultCount]
+		annotations := err.(*alertobserver.ErrLuckyQueries).ProposedQueries[0].Annotations
+		return annotations[search.ResultCount] + ", " + annotations[search.MatchCount] + " matches"
 	}
 
 	autogold.Want("0 results", autogold.Raw("")).Equal(t, autogold.Raw(test(0)))
-	autogold.Want("1 result", autogold.Raw("1 result")).Equal(t, autogold.Raw(test(1)))
-	autogold.Want("limit results", autogold.Raw("500+ results")).Equal(t, autogold.Raw(test(limits.DefaultMaxSearchResultsStreaming)))
+	autogold.Want("1 result", autogold.Raw("1 result, 1 matches")).Equal(t, autogold.Raw(test(1)))
+	autogold.Want("limit results", autogold.Raw("500+ results, 500 matches")).Equal(t, autogold.Raw(test(limits.DefaultMaxSearchResultsStreaming)))
 }
 
 func TestNewSmartSearchJob_ResultCount(t *testing.T) {
@@ -129,3 +130,11 @@ func TestNewSmartSearchJob_ResultCount(t *testing.T) {
 		require.Equal(t, RESULT_THRESHOLD, len(sent))
 	})
 }
+
+func TestNotifierRulesAnnotation(t *testing.T) {
+	q, _ := query.ParseStandard("test")
+	b, _ := query.ToBasicQuery(q)
+	n := &notifier{autoQuery: &autoQuery{description: "test", rules: []string{"lang-patterns", "regexp-patterns"}, query: b}}
+	err := n.New(1, 1).(*alertobserver.ErrLuckyQueries)
+	autogold.Want("rules annotation", "lang-patterns,regexp-patterns").Equal(t, err.ProposedQueries[0].Annotations[search.SmartSearchRules])
+}
@@ -295,6 +295,82 @@ func (c *Client) SymbolInfo(ctx context.Context, args types.RepoCommitPathPoint)
 	return result, nil
 }
 
+func (c *Client) SymbolReferences(ctx context.Context, args types.SymbolReferencesArgs) (result *types.SymbolReferences, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "squirrel.Client.SymbolReferences") //nolint:staticcheck // OT is deprecated
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+		}
+		span.Finish()
+	}()
+	span.SetTag("Repo", args.Repo)
+	span.SetTag("CommitID", args.Commit)
+	span.SetTag("Candidates", len(args.Candidates))
+
+	resp, err := c.httpPost(ctx, "symbolReferences", api.RepoName(args.Repo), args)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// best-effort inclusion of body in error message
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, errors.Errorf(
+			"Squirrel.SymbolReferences http status %d: %s",
+			resp.StatusCode,
+			string(body),
+		)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding response body")
+	}
+
+	// 🚨 SECURITY: We have a valid result, so we need to apply sub-repo permissions filtering.
+	if result == nil || c.SubRepoPermsChecker == nil {
+		return result, err
+	}
+
+	checker := c.SubRepoPermsChecker()
+	if !authz.SubRepoEnabled(checker) {
+		return result, err
+	}
+
+	a := actor.FromContext(ctx)
+	canRead := func(path string) (bool, error) {
+		perm, err := authz.ActorPermissions(ctx, checker, a, authz.RepoContent{
+			Repo: api.RepoName(args.Repo),
+			Path: path,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "checking sub-repo permissions")
+		}
+		return perm.Include(authz.Read), nil
+	}
+
+	if ok, err := canRead(args.Path); err != nil || !ok {
+		return nil, err
+	}
+
+	// Filter in place
+	filtered := result.References[:0]
+	for _, ref := range result.References {
+		ok, err := canRead(ref.Path)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, ref)
+		}
+	}
+	result.References = filtered
+
+	return result, nil
+}
+
 func (c *Client) httpPost(
 	ctx context.Context,
 	method string,
@@ -147,3 +147,73 @@ func TestDefinitionWithFiltering(t *testing.T) {
 		t.Fatal("expected nil result when getting a definition for an unauthorized path")
 	}
 }
+
+func TestReferencesWithFiltering(t *testing.T) {
+	location := func(path string) types.RepoCommitPathRange {
+		return types.RepoCommitPathRange{
+			RepoCommitPath: types.RepoCommitPath{
+				Repo:   "somerepo",
+				Commit: "somecommit",
+				Path:   path,
+			},
+			Range: types.Range{Row: 0, Column: 0, Length: 3},
+		}
+	}
+
+	// Start an HTTP server that responds with references in file1 and file2.
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(types.SymbolReferences{
+			Definition: types.RepoCommitPathMaybeRange{RepoCommitPath: location("file1").RepoCommitPath},
+			References: []types.RepoCommitPathRange{location("file1"), location("file2")},
+		})
+	}))
+	t.Cleanup(func() {
+		srv.Close()
+	})
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultHook(func() bool {
+		return true
+	})
+	checker.PermissionsFunc.SetDefaultHook(func(ctx context.Context, i int32, content authz.RepoContent) (authz.Perms, error) {
+		if content.Path == "file1" {
+			return authz.Read, nil
+		}
+		return authz.None, nil
+	})
+	client := &Client{
+		URL:                 srv.URL,
+		HTTPClient:          http.DefaultClient,
+		SubRepoPermsChecker: func() authz.SubRepoPermissionChecker { return checker },
+	}
+	ctx = actor.WithActor(ctx, &actor.Actor{
+		UID: 1,
+	})
+
+	// Request the references from file1.
+	results, err := client.SymbolReferences(ctx, types.SymbolReferencesArgs{
+		RepoCommitPathPoint: types.RepoCommitPathPoint{RepoCommitPath: location("file1").RepoCommitPath},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results == nil {
+		t.Fatal("nil result")
+	}
+	wantCount := 1
+	if len(results.References) != wantCount {
+		t.Fatalf("Want %d references, got %d", wantCount, len(results.References))
+	}
+
+	// Request the references from file2, which isn't readable.
+	results, err = client.SymbolReferences(ctx, types.SymbolReferencesArgs{
+		RepoCommitPathPoint: types.RepoCommitPathPoint{RepoCommitPath: location("file2").RepoCommitPath},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error when getting references for an unauthorized path: %s", err)
+	}
+	if results != nil {
+		t.Fatal("expected nil result when getting references for an unauthorized path")
+	}
+}
@@ -200,3 +200,23 @@ func (s SymbolInfo) String() string {
 	}
 	return fmt.Sprintf("SymbolInfo{Definition: %s %s, Hover: %q}", s.Definition.RepoCommitPath, rnge, hover)
 }
+
+type SymbolReferencesArgs struct {
+	RepoCommitPathPoint
+	// Candidates are the locations that textually match the name of the symbol, usually from a
+	// word search in the repository.
+	Candidates []RepoCommitPathRange `json:"candidates"`
+}
+
+type SymbolReferences struct {
+	Definition RepoCommitPathMaybeRange `json:"definition"`
+	References []RepoCommitPathRange    `json:"references"`
+}
+
+func (s SymbolReferences) String() string {
+	rnge := "<nil>"
+	if s.Definition.Range != nil {
+		rnge = s.Definition.Range.String()
+	}
+	return fmt.Sprintf("SymbolReferences{Definition: %s %s, References: %d}", s.Definition.RepoCommitPath, rnge, len(s.References))
+}
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS insight_series DROP COLUMN IF EXISTS aggregation;
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS insight_series
+	ADD COLUMN IF NOT EXISTS aggregation text;
+
+COMMENT ON COLUMN insight_series.aggregation IS 'How the numbers captured by a numeric-compute series are combined into a single value (SUM, MAX or AVERAGE).';
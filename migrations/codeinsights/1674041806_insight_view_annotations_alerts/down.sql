@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS insight_view_alerts;
+DROP TABLE IF EXISTS insight_view_annotations;
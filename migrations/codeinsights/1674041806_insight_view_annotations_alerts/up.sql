@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS insight_view_annotations (
+    id SERIAL CONSTRAINT insight_view_annotations_pkey PRIMARY KEY,
+    insight_view_id INT NOT NULL,
+    series_id TEXT,
+    annotation_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    label TEXT NOT NULL,
+    created_by INT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT insight_view_annotations_insight_view_id_fk FOREIGN KEY (insight_view_id) REFERENCES insight_view(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_view_annotations_insight_view_id_idx ON insight_view_annotations USING btree (insight_view_id);
+
+COMMENT ON TABLE insight_view_annotations IS 'Annotations that mark an event at a point in time on an insight view.';
+COMMENT ON COLUMN insight_view_annotations.series_id IS 'The series the annotation belongs to. Annotations without a series apply to every series of the view.';
+COMMENT ON COLUMN insight_view_annotations.created_by IS 'User ID that created the annotation.';
+
+CREATE TABLE IF NOT EXISTS insight_view_alerts (
+    id SERIAL CONSTRAINT insight_view_alerts_pkey PRIMARY KEY,
+    insight_view_id INT NOT NULL,
+    series_id TEXT NOT NULL,
+    condition TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    user_id INT NOT NULL,
+    email_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    slack_webhook_url TEXT,
+    webhook_url TEXT,
+    encryption_key_id TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    breached BOOLEAN NOT NULL DEFAULT FALSE,
+    last_triggered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT insight_view_alerts_insight_view_id_fk FOREIGN KEY (insight_view_id) REFERENCES insight_view(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_view_alerts_insight_view_id_idx ON insight_view_alerts USING btree (insight_view_id);
+CREATE INDEX IF NOT EXISTS insight_view_alerts_series_id_idx ON insight_view_alerts USING btree (series_id);
+
+COMMENT ON TABLE insight_view_alerts IS 'Threshold rules on an insight series that send notifications when a new snapshot breaches them.';
+COMMENT ON COLUMN insight_view_alerts.condition IS 'How the latest value of the series is compared to the threshold: ABOVE, BELOW or INCREASE_PERCENT (the increase over the previous point, in percent).';
+COMMENT ON COLUMN insight_view_alerts.user_id IS 'User ID that owns the alert. Notifications are evaluated with the permissions of this user, and emails are sent to them.';
+COMMENT ON COLUMN insight_view_alerts.slack_webhook_url IS 'The Slack webhook URL notified when the alert breaches, encrypted with encryption_key_id';
+COMMENT ON COLUMN insight_view_alerts.webhook_url IS 'The webhook URL notified when the alert breaches, encrypted with encryption_key_id';
+COMMENT ON COLUMN insight_view_alerts.breached IS 'Whether the latest evaluation breached the threshold. Notifications are only sent when an alert starts breaching.';
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS series_points DROP COLUMN IF EXISTS value_count;
+
+ALTER TABLE IF EXISTS series_points_snapshots DROP COLUMN IF EXISTS value_count;
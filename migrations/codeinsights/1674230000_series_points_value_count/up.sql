@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS series_points
+	ADD COLUMN IF NOT EXISTS value_count integer;
+
+ALTER TABLE IF EXISTS series_points_snapshots
+	ADD COLUMN IF NOT EXISTS value_count integer;
+
+COMMENT ON COLUMN series_points.value_count IS 'The number of values that value is the sum of, for points of numeric-compute series that average the captured values. Null for other series.';
@@ -0,0 +1,9 @@
+ALTER TABLE cm_action_jobs
+    DROP COLUMN IF EXISTS last_delivery_error,
+    DROP COLUMN IF EXISTS delivery_attempts;
+
+ALTER TABLE cm_webhooks
+    DROP COLUMN IF EXISTS encryption_key_id,
+    DROP COLUMN IF EXISTS secret,
+    DROP COLUMN IF EXISTS headers,
+    DROP COLUMN IF EXISTS payload_template;
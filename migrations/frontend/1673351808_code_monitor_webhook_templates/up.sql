@@ -0,0 +1,16 @@
+ALTER TABLE cm_webhooks
+    ADD COLUMN IF NOT EXISTS payload_template text,
+    ADD COLUMN IF NOT EXISTS headers text,
+    ADD COLUMN IF NOT EXISTS secret text,
+    ADD COLUMN IF NOT EXISTS encryption_key_id text;
+
+COMMENT ON COLUMN cm_webhooks.payload_template IS 'A Go text/template used to render the request body. When not set, the default JSON payload is sent';
+COMMENT ON COLUMN cm_webhooks.headers IS 'A JSON object of additional request headers, encrypted with encryption_key_id';
+COMMENT ON COLUMN cm_webhooks.secret IS 'The secret used to sign request bodies with HMAC-SHA256, encrypted with encryption_key_id';
+
+ALTER TABLE cm_action_jobs
+    ADD COLUMN IF NOT EXISTS delivery_attempts integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_delivery_error text;
+
+COMMENT ON COLUMN cm_action_jobs.delivery_attempts IS 'The number of times delivery of the notification was attempted';
+COMMENT ON COLUMN cm_action_jobs.last_delivery_error IS 'The error of the most recent failed delivery attempt';
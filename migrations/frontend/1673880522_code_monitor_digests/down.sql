@@ -0,0 +1,7 @@
+ALTER TABLE cm_slack_webhooks
+    DROP COLUMN IF EXISTS last_digest_trigger_event,
+    DROP COLUMN IF EXISTS digest_interval;
+
+ALTER TABLE cm_emails
+    DROP COLUMN IF EXISTS last_digest_trigger_event,
+    DROP COLUMN IF EXISTS digest_interval;
@@ -0,0 +1,13 @@
+ALTER TABLE cm_emails
+    ADD COLUMN IF NOT EXISTS digest_interval text,
+    ADD COLUMN IF NOT EXISTS last_digest_trigger_event integer;
+
+COMMENT ON COLUMN cm_emails.digest_interval IS 'If set, results are collected and sent as a single digest email per interval. One of HOURLY, DAILY or WEEKLY';
+COMMENT ON COLUMN cm_emails.last_digest_trigger_event IS 'The ID of the most recent cm_trigger_jobs event whose results were included in a digest';
+
+ALTER TABLE cm_slack_webhooks
+    ADD COLUMN IF NOT EXISTS digest_interval text,
+    ADD COLUMN IF NOT EXISTS last_digest_trigger_event integer;
+
+COMMENT ON COLUMN cm_slack_webhooks.digest_interval IS 'If set, results are collected and sent as a single digest message per interval. One of HOURLY, DAILY or WEEKLY';
+COMMENT ON COLUMN cm_slack_webhooks.last_digest_trigger_event IS 'The ID of the most recent cm_trigger_jobs event whose results were included in a digest';
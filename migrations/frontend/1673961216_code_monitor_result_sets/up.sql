@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS cm_result_sets (
+    monitor_id bigint NOT NULL PRIMARY KEY REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    fingerprints jsonb NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE cm_result_sets IS 'The results of the most recent run of a code monitor whose query does not search commits';
+COMMENT ON COLUMN cm_result_sets.fingerprints IS 'A compact fingerprint of each result, which identifies the results that appear or disappear on the next run';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS repo_update_schedule (
+    repo_id integer NOT NULL PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    interval_seconds integer NOT NULL,
+    due_at timestamp with time zone NOT NULL,
+    priority integer NOT NULL DEFAULT 0,
+    lease_owner text,
+    lease_expires_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS repo_update_schedule_due_at ON repo_update_schedule(due_at);
+
+COMMENT ON TABLE repo_update_schedule IS 'The schedule of git fetches of repositories, shared by all repo-updater instances';
+COMMENT ON COLUMN repo_update_schedule.interval_seconds IS 'The interval between fetches, learned from how often the repository changes';
+COMMENT ON COLUMN repo_update_schedule.priority IS 'The priority of the fetch requested since the last fetch';
+COMMENT ON COLUMN repo_update_schedule.lease_owner IS 'The repo-updater instance fetching the repository, if any';
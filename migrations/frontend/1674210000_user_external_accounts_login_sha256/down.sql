@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS user_external_accounts_login_sha256;
+
+ALTER TABLE user_external_accounts DROP COLUMN IF EXISTS login_sha256;
@@ -0,0 +1,18 @@
+ALTER TABLE user_external_accounts ADD COLUMN IF NOT EXISTS login_sha256 text;
+
+-- Encrypted account data cannot be read here; those rows are hashed lazily
+-- by the application the first time accounts are listed by login.
+UPDATE user_external_accounts
+SET login_sha256 = CASE WHEN COALESCE(account_data, '') = '' THEN ''
+    ELSE COALESCE(encode(sha256(convert_to(LOWER(account_data::jsonb ->> CASE service_type
+        WHEN 'github' THEN 'login'
+        WHEN 'gitlab' THEN 'username'
+        WHEN 'bitbucketServer' THEN 'slug'
+        WHEN 'bitbucketCloud' THEN 'username'
+    END), 'UTF8')), 'hex'), '')
+END
+WHERE login_sha256 IS NULL AND encryption_key_id IN ('', 'unmigrated');
+
+CREATE INDEX IF NOT EXISTS user_external_accounts_login_sha256 ON user_external_accounts(service_type, login_sha256) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN user_external_accounts.login_sha256 IS 'Hex SHA-256 of the lowercase code host login found in account_data, empty if the account has none, or NULL if not computed yet';
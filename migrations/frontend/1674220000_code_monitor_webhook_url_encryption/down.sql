@@ -0,0 +1,7 @@
+COMMENT ON COLUMN cm_webhooks.url IS 'The webhook URL we send the code monitor event to';
+
+ALTER TABLE cm_webhooks DROP COLUMN IF EXISTS url_encryption_key_id;
+
+COMMENT ON COLUMN cm_slack_webhooks.url IS 'The Slack webhook URL we send the code monitor event to';
+
+ALTER TABLE cm_slack_webhooks DROP COLUMN IF EXISTS encryption_key_id;
@@ -0,0 +1,9 @@
+ALTER TABLE cm_slack_webhooks ADD COLUMN IF NOT EXISTS encryption_key_id text;
+
+COMMENT ON COLUMN cm_slack_webhooks.url IS 'The Slack webhook URL we send the code monitor event to, encrypted with encryption_key_id';
+
+-- Webhook URLs were stored in plaintext next to headers and secrets encrypted
+-- with encryption_key_id, so they are tracked with a key ID of their own.
+ALTER TABLE cm_webhooks ADD COLUMN IF NOT EXISTS url_encryption_key_id text;
+
+COMMENT ON COLUMN cm_webhooks.url IS 'The webhook URL we send the code monitor event to, encrypted with url_encryption_key_id';
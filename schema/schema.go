@@ -508,6 +508,7 @@ type EmailTemplates struct {
 type EncryptionKey struct {
 	Cloudkms *CloudKMSEncryptionKey
 	Awskms   *AWSKMSEncryptionKey
+	Vault    *VaultEncryptionKey
 	Mounted  *MountedEncryptionKey
 	Noop     *NoOpEncryptionKey
 }
@@ -519,6 +520,9 @@ func (v EncryptionKey) MarshalJSON() ([]byte, error) {
 	if v.Awskms != nil {
 		return json.Marshal(v.Awskms)
 	}
+	if v.Vault != nil {
+		return json.Marshal(v.Vault)
+	}
 	if v.Mounted != nil {
 		return json.Marshal(v.Mounted)
 	}
@@ -543,8 +547,10 @@ func (v *EncryptionKey) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Mounted)
 	case "noop":
 		return json.Unmarshal(data, &v.Noop)
+	case "vault":
+		return json.Unmarshal(data, &v.Vault)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"cloudkms", "awskms", "mounted", "noop"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"cloudkms", "awskms", "vault", "mounted", "noop"})
 }
 
 // EncryptionKeys description: Configuration for encryption keys used to encrypt data at rest in the database.
@@ -552,6 +558,8 @@ type EncryptionKeys struct {
 	BatchChangesCredentialKey *EncryptionKey `json:"batchChangesCredentialKey,omitempty"`
 	// CacheSize description: number of values to keep in LRU cache
 	CacheSize int `json:"cacheSize,omitempty"`
+	// DecryptionKeys description: Keys that are no longer used to encrypt data, but that data encrypted before the keys above were changed can still be decrypted with. Records encrypted with these keys are re-encrypted with the current keys in the background, after which they can be removed.
+	DecryptionKeys []EncryptionKey `json:"decryptionKeys,omitempty"`
 	// EnableCache description: enable LRU cache for decryption APIs
 	EnableCache            bool           `json:"enableCache,omitempty"`
 	ExecutorSecretKey      *EncryptionKey `json:"executorSecretKey,omitempty"`
@@ -1822,6 +1830,14 @@ type SearchScope struct {
 	Value string `json:"value"`
 }
 
+// SearchSmartSearch description: Configures the rules that Smart Search applies to queries that return no results. Rules not listed in `rules` are enabled and tried after the listed ones, in their default order.
+type SearchSmartSearch struct {
+	// RewriteRules description: Additional rules that rewrite the patterns of a query with a regular expression, for example to map the vocabulary of your codebase. A rewrite rule applies when its pattern matches at least one pattern of the query.
+	RewriteRules []*SmartSearchRewriteRule `json:"rewriteRules,omitempty"`
+	// Rules description: Enables or disables Smart Search rules, and sets the order in which they are tried. Rules are referred to by name: the built-in rules are `unquote-patterns`, `type-patterns`, `lang-patterns`, `symbol-patterns`, `code-host-filters`, `rewrite-repo-filter`, `regexp-patterns` and `unordered-patterns`. Rewrite rules are referred to by their name as well.
+	Rules []*SmartSearchRule `json:"rules,omitempty"`
+}
+
 // Sentry description: Configuration for Sentry
 type Sentry struct {
 	// BackendDSN description: Sentry Data Source Name (DSN) for backend errors. Per the Sentry docs (https://docs.sentry.io/quickstart/#about-the-dsn), it should match the following pattern: '{PROTOCOL}://{PUBLIC_KEY}@{HOST}/{PATH}{PROJECT_ID}'.
@@ -2430,6 +2446,8 @@ type SiteConfiguration struct {
 	SearchLargeFiles []string `json:"search.largeFiles,omitempty"`
 	// SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 	SearchLimits *SearchLimits `json:"search.limits,omitempty"`
+	// SearchSmartSearch description: Configures the rules that Smart Search applies to queries that return no results. Rules not listed in `rules` are enabled and tried after the listed ones, in their default order.
+	SearchSmartSearch *SearchSmartSearch `json:"search.smartSearch,omitempty"`
 	// SyntaxHighlighting description: Syntax highlighting configuration
 	SyntaxHighlighting *SyntaxHighlighting `json:"syntaxHighlighting,omitempty"`
 	// UpdateChannel description: The channel on which to automatically check for Sourcegraph updates.
@@ -2567,6 +2585,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "search.index.symbols.enabled")
 	delete(m, "search.largeFiles")
 	delete(m, "search.limits")
+	delete(m, "search.smartSearch")
 	delete(m, "syntaxHighlighting")
 	delete(m, "update.channel")
 	delete(m, "webhook.logging")
@@ -2579,6 +2598,25 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type SmartSearchRewriteRule struct {
+	// Description description: A description of the rule shown to users alongside the results of the rewritten query. Defaults to the name of the rule.
+	Description string `json:"description,omitempty"`
+	// Kind description: Whether the rule narrows the query, in which case it is combined with other narrowing rules, or widens it.
+	Kind string `json:"kind,omitempty"`
+	// Name description: A unique name for the rule, used in `rules` and in telemetry.
+	Name string `json:"name"`
+	// Pattern description: A Go regular expression matched against every pattern of the query.
+	Pattern string `json:"pattern"`
+	// Replacement description: The replacement for matches of `pattern`. It may refer to capture groups, such as `$1`.
+	Replacement string `json:"replacement"`
+}
+type SmartSearchRule struct {
+	// Enabled description: Whether the rule is applied.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Name description: The name of the rule.
+	Name string `json:"name"`
+}
+
 // SrcCliVersionCache description: Configuration related to the src-cli version cache. This should only be used on sourcegraph.com.
 type SrcCliVersionCache struct {
 	// Enabled description: Enables the src-cli version cache API endpoint.
@@ -2671,6 +2709,31 @@ type UsernameIdentity struct {
 	Type string `json:"type"`
 }
 
+// VaultAppRole description: AppRole credentials, used to authenticate to Vault.
+type VaultAppRole struct {
+	// MountPath description: The path the AppRole auth method is mounted at.
+	MountPath string `json:"mountPath,omitempty"`
+	RoleId    string `json:"roleId"`
+	SecretId  string `json:"secretId"`
+}
+
+// VaultEncryptionKey description: HashiCorp Vault transit secrets engine encryption key. Either token or appRole must be set to authenticate to Vault.
+type VaultEncryptionKey struct {
+	// Address description: The URL of the Vault server.
+	Address string `json:"address"`
+	// AppRole description: AppRole credentials, used to authenticate to Vault.
+	AppRole *VaultAppRole `json:"appRole,omitempty"`
+	// KeyName description: The name of the transit encryption key.
+	KeyName string `json:"keyName"`
+	// MountPath description: The path the transit secrets engine is mounted at.
+	MountPath string `json:"mountPath,omitempty"`
+	// Namespace description: The Vault Enterprise namespace of the transit secrets engine and auth method.
+	Namespace string `json:"namespace,omitempty"`
+	// Token description: A Vault token, used to authenticate to Vault.
+	Token string `json:"token,omitempty"`
+	Type  string `json:"type"`
+}
+
 // WebhookLogging description: Configuration for logging incoming webhooks.
 type WebhookLogging struct {
 	// Enabled description: Whether incoming webhooks are logged. If omitted, logging is enabled on sites without encryption. If one or more encryption keys are present, this setting must be enabled manually; as webhooks may contain sensitive data, admins of encrypted sites may want to enable webhook encryption via encryption.keys.webhookLogKey.